@@ -0,0 +1,247 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// DistinctValues returns the distinct values of a property across all entities, in the
+// database's ascending order, such as every country present on User nodes for a filter
+// dropdown. Entities lacking the property are skipped unless IncludeNulls is given, in
+// which case they contribute a single nil value, sorted last.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a mapped property (e.g., "country").
+//   - opts: Optional per-call settings, such as IncludeNulls or UseIndex.
+//
+// Returns:
+//
+//	The distinct values, or an error if the property is not mapped or the query fails.
+func (r *Repository[T]) DistinctValues(ctx context.Context, propName string, opts ...FindOption) ([]interface{}, error) {
+	options, err := parseOptions("DistinctValues", opts, lookupOptions|optIncludeNulls)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.requireMappedProperty(propName); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("MATCH (n:%s)", r.meta.Label)
+	if !options.includeNulls {
+		query += fmt.Sprintf("\nWHERE n.%s IS NOT NULL", propName)
+	}
+	query += fmt.Sprintf("\nRETURN DISTINCT n.%s AS value\nORDER BY value", propName)
+
+	eagerResult, err := r.run(ctx, query, map[string]interface{}{}, options)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(eagerResult.Records))
+	for i, record := range eagerResult.Records {
+		values[i], _ = record.Get("value")
+	}
+	return values, nil
+}
+
+// SumByProperty returns the sum of a numeric property across all entities, such as the
+// total value of all orders. Integer sums are converted to float64; entities lacking the
+// property are ignored, and the sum of no entities is 0.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a property mapped to a numeric field (e.g., "total").
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	The sum, or an error if the property is not mapped to a numeric field or the query fails.
+func (r *Repository[T]) SumByProperty(ctx context.Context, propName string, opts ...FindOption) (float64, error) {
+	return r.aggregateProperty(ctx, "SumByProperty", "sum", propName, opts)
+}
+
+// AvgByProperty returns the average of a numeric property across the entities that have
+// it, such as the average age of users. The average of no entities is 0.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a property mapped to a numeric field (e.g., "age").
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	The average, or an error if the property is not mapped to a numeric field or the query fails.
+func (r *Repository[T]) AvgByProperty(ctx context.Context, propName string, opts ...FindOption) (float64, error) {
+	return r.aggregateProperty(ctx, "AvgByProperty", "avg", propName, opts)
+}
+
+// MinMaxByProperty returns the smallest and largest values of a property across all
+// entities, in a single query. Any orderable property (numbers, strings, temporal values)
+// is accepted, and the values are returned as read from the database. Both are nil when
+// no entity has the property.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a mapped property (e.g., "createdAt").
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	The minimum and maximum values, or an error if the property is not mapped or the query fails.
+func (r *Repository[T]) MinMaxByProperty(ctx context.Context, propName string, opts ...FindOption) (interface{}, interface{}, error) {
+	options, err := parseOptions("MinMaxByProperty", opts, lookupOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := r.requireMappedProperty(propName); err != nil {
+		return nil, nil, err
+	}
+	query := fmt.Sprintf("MATCH (n:%s)\nRETURN min(n.%[2]s) AS min, max(n.%[2]s) AS max", r.meta.Label, propName)
+	eagerResult, err := r.run(ctx, query, map[string]interface{}{}, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return nil, nil, nil
+	}
+	minValue, _ := eagerResult.Records[0].Get("min")
+	maxValue, _ := eagerResult.Records[0].Get("max")
+	return minValue, maxValue, nil
+}
+
+// AggregateWithQuery executes a custom aggregation query and returns its result as a
+// float64, for filtered roll-ups such as the total of the orders of one customer. Like
+// CountWithQuery, the QueryBuilder defines the MATCH logic and MUST return a single
+// numerical value, here with the alias "value". A null or missing result is 0.
+//
+// Example:
+//
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("o", "Order").WithProperties(map[string]any{"customerId": "c1"})).
+//	    Return("sum(o.total) AS value") // The "AS value" is required.
+//	total, err := orderRepo.AggregateWithQuery(ctx, qb)
+func (r *Repository[T]) AggregateWithQuery(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (float64, error) {
+	options, err := parseOptions("AggregateWithQuery", opts, lookupOptions)
+	if err != nil {
+		return 0, err
+	}
+	query, params, err := buildQuery("AggregateWithQuery", r.meta.Label, qb)
+	if err != nil {
+		return 0, err
+	}
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return 0, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return 0, nil
+	}
+	value, ok := eagerResult.Records[0].Get("value")
+	if !ok {
+		return 0, fmt.Errorf("a 'value' column was not returned by the query; ensure your query includes 'RETURN ... AS value'")
+	}
+	return aggregateFloat(value)
+}
+
+// aggregateProperty runs `MATCH (n:Label) RETURN fn(n.prop) AS value` for a numeric property.
+func (r *Repository[T]) aggregateProperty(ctx context.Context, operation, function, propName string, opts []FindOption) (float64, error) {
+	options, err := parseOptions(operation, opts, lookupOptions)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.requireNumericProperty(propName); err != nil {
+		return 0, err
+	}
+	query := fmt.Sprintf("MATCH (n:%s)\nRETURN %s(n.%s) AS value", r.meta.Label, function, propName)
+	eagerResult, err := r.run(ctx, query, map[string]interface{}{}, options)
+	if err != nil {
+		return 0, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return 0, nil
+	}
+	value, _ := eagerResult.Records[0].Get("value")
+	return aggregateFloat(value)
+}
+
+// aggregateFloat converts an aggregate result to float64, treating null as 0.
+func aggregateFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("aggregate returned a non-numeric value of type %T", value)
+}
+
+// requireNumericProperty checks that propName is mapped to an integer or float field.
+func (r *Repository[T]) requireNumericProperty(propName string) error {
+	if err := r.requireMappedProperty(propName); err != nil {
+		return err
+	}
+	fieldName, _ := r.meta.fieldForProperty(propName)
+	fieldType := r.meta.Types[fieldName]
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	}
+	return fmt.Errorf("property '%s' of entity type %s is mapped to field %s of type %s, not a number",
+		propName, r.meta.Label, fieldName, r.meta.Types[fieldName])
+}
+
+// GroupCount is the number of entities sharing a property value, as returned by
+// CountGroupedBy.
+type GroupCount struct {
+	// Value is the property value, or nil for the group of entities lacking the property.
+	Value interface{}
+	// Count is the number of entities in the group.
+	Count int64
+}
+
+// CountGroupedBy counts the entities per value of a property in a single query, such as
+// the number of users per country for a dashboard. Groups are ordered by decreasing count,
+// then by value. Entities lacking the property are not dropped: they form a group whose
+// Value is nil.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a mapped property (e.g., "country").
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	One GroupCount per distinct value, or an error if the property is not mapped or the
+//	query fails.
+func (r *Repository[T]) CountGroupedBy(ctx context.Context, propName string, opts ...FindOption) ([]GroupCount, error) {
+	options, err := parseOptions("CountGroupedBy", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.requireMappedProperty(propName); err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("MATCH (n:%s)\nRETURN n.%s AS key, count(n) AS count\nORDER BY count DESC, key", r.meta.Label, propName)
+	eagerResult, err := r.run(ctx, query, map[string]interface{}{}, options)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]GroupCount, len(eagerResult.Records))
+	for i, record := range eagerResult.Records {
+		key, _ := record.Get("key")
+		count, _ := record.Get("count")
+		groups[i].Value = key
+		groups[i].Count, _ = count.(int64)
+	}
+	return groups, nil
+}
@@ -0,0 +1,101 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Contact renamed its mail property to email, keeping two legacy spellings readable.
+type Contact struct {
+	ContactID string `crud:"pk,property:contactId"`
+	Email     string `crud:"property:email,aliases:mail|e_mail"`
+}
+
+// findContact maps a Contact node holding the given properties.
+func findContact(t *testing.T, props map[string]any) *Contact {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	props["contactId"] = "c1"
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{ElementID: "4:test:c1", Labels: []string{"Contact"}, Props: props}), nil
+	}
+	repo, err := neopersist.NewRepository[Contact](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	contact, err := repo.FindByID(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	return contact
+}
+
+func TestAliasesFallBackToLegacyProperties(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]any
+		want  string
+	}{
+		{name: "only the alias", props: map[string]any{"mail": "old@example.com"}, want: "old@example.com"},
+		{name: "only the second alias", props: map[string]any{"e_mail": "older@example.com"}, want: "older@example.com"},
+		{name: "first alias wins", props: map[string]any{"e_mail": "older@example.com", "mail": "old@example.com"}, want: "old@example.com"},
+		{name: "both keys", props: map[string]any{"email": "new@example.com", "mail": "old@example.com"}, want: "new@example.com"},
+		{name: "neither", props: map[string]any{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findContact(t, tt.props).Email; got != tt.want {
+				t.Errorf("Email = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveWritesOnlyThePrimaryProperty(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{Labels: []string{"Contact"}, Props: map[string]any{"contactId": "c1"}}), nil
+	}
+	repo, err := neopersist.NewRepository[Contact](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	if err := repo.Save(context.Background(), &Contact{ContactID: "c1", Email: "new@example.com"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	for _, call := range runner.Calls() {
+		if !strings.Contains(call.Query, "n.email =") || strings.Contains(call.Query, "n.mail") || strings.Contains(call.Query, "n.e_mail") {
+			t.Errorf("Save sent %q, want only the primary email property written", call.Query)
+		}
+	}
+}
+
+func TestBackfillAliasesMovesLegacyValuesInBatches(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		migrated := int64(0)
+		if index == 0 {
+			migrated = 3
+		}
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("migrated", migrated)}}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	migrated, err := pm.BackfillAliases(context.Background(), Contact{})
+	if err != nil {
+		t.Fatalf("BackfillAliases: %v", err)
+	}
+	if migrated != 3 {
+		t.Errorf("migrated = %d, want 3", migrated)
+	}
+	neopersisttest.AssertCalls(t, runner,
+		neopersisttest.Expect{Query: "MATCH (n:Contact) WHERE n.mail IS NOT NULL WITH n LIMIT $batchSize " +
+			"SET n.email = coalesce(n.email, n.mail) REMOVE n.mail RETURN count(n) AS migrated"},
+		neopersisttest.Expect{Query: "MATCH (n:Contact) WHERE n.e_mail IS NOT NULL WITH n LIMIT $batchSize " +
+			"SET n.email = coalesce(n.email, n.e_mail) REMOVE n.e_mail RETURN count(n) AS migrated"},
+	)
+}
@@ -0,0 +1,49 @@
+package neopersist
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+)
+
+// []byte fields are stored as Neo4j byte array properties and loaded from the []byte the
+// runner returns for them, copied so that the entity does not share the driver's buffer.
+// Graphs that stored blobs as base64 strings instead can tag the field with the `base64`
+// tag component (e.g., `crud:"property:avatar,base64"`): it is then written as a standard
+// base64 string, and loaded from either a base64 string or a byte array.
+
+// isBytesType reports whether typ is []byte or a named type based on it.
+func isBytesType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// base64PropertyValue encodes a field tagged `base64` into the string written to the
+// database. A nil slice is written as null, which removes the property.
+func base64PropertyValue(field reflect.Value) interface{} {
+	if field.IsNil() {
+		return nil
+	}
+	return base64.StdEncoding.EncodeToString(field.Bytes())
+}
+
+// setBytesValue assigns a byte array or, for fields tagged `base64`, a base64 string to a
+// []byte field. It reports false, leaving the field untouched, for other values, so that
+// lists of integers written by older versions are still converted element by element.
+func setBytesValue(field reflect.Value, value any, allowBase64 bool) (bool, error) {
+	switch v := value.(type) {
+	case []byte:
+		field.SetBytes(append([]byte{}, v...))
+		return true, nil
+	case string:
+		if !allowBase64 {
+			return true, fmt.Errorf("cannot assign a string to field of type %s; tag it 'base64' if blobs are stored as base64 strings", field.Type())
+		}
+		data, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return true, fmt.Errorf("invalid base64 string: %w", err)
+		}
+		field.SetBytes(data)
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,134 @@
+package neopersist_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Avatar has a blob stored as a byte array and one stored as a base64 string.
+type Avatar struct {
+	ID    string `crud:"pk,property:id"`
+	Image []byte `crud:"property:image"`
+	Thumb []byte `crud:"property:thumb,base64"`
+}
+
+func newAvatarRepo(t *testing.T, props map[string]any) (*neopersist.Repository[Avatar], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Avatar](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	props["id"] = "a1"
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{ElementID: "4:test:a1", Labels: []string{"Avatar"}, Props: props}), nil
+	}
+	return repo, runner
+}
+
+func TestBytesLoadFromByteArrays(t *testing.T) {
+	image := []byte{0x89, 'P', 'N', 'G'}
+	repo, _ := newAvatarRepo(t, map[string]any{"image": image, "thumb": []byte{1, 2}})
+
+	avatar, err := repo.FindByID(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !bytes.Equal(avatar.Image, image) || !bytes.Equal(avatar.Thumb, []byte{1, 2}) {
+		t.Errorf("loaded %v and %v", avatar.Image, avatar.Thumb)
+	}
+	// The entity must not share the buffer returned by the runner.
+	image[0] = 0
+	if avatar.Image[0] != 0x89 {
+		t.Error("the loaded bytes share the runner's buffer")
+	}
+}
+
+func TestBytesLoadFromLegacyIntegerLists(t *testing.T) {
+	repo, _ := newAvatarRepo(t, map[string]any{"image": []any{int64(1), int64(255)}})
+
+	avatar, err := repo.FindByID(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !bytes.Equal(avatar.Image, []byte{1, 255}) {
+		t.Errorf("Image = %v, want [1 255]", avatar.Image)
+	}
+}
+
+func TestBytesRejectStringsWithoutTheBase64Tag(t *testing.T) {
+	repo, _ := newAvatarRepo(t, map[string]any{"image": "iVBORw=="})
+
+	_, err := repo.FindByID(context.Background(), "a1")
+	if err == nil || !strings.Contains(err.Error(), "tag it 'base64'") {
+		t.Fatalf("FindByID error = %v, want a hint at the base64 tag", err)
+	}
+}
+
+func TestBase64FieldsFallBackToStrings(t *testing.T) {
+	thumb := []byte("tiny image")
+	repo, runner := newAvatarRepo(t, map[string]any{"thumb": base64.StdEncoding.EncodeToString(thumb)})
+	ctx := context.Background()
+
+	avatar, err := repo.FindByID(ctx, "a1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !bytes.Equal(avatar.Thumb, thumb) {
+		t.Errorf("Thumb = %q, want %q", avatar.Thumb, thumb)
+	}
+
+	runner.Reset()
+	if err := repo.Save(ctx, avatar); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if value := setValue(t, runner.Calls()[0], "thumb"); value != base64.StdEncoding.EncodeToString(thumb) {
+		t.Errorf("thumb saved as %#v, want the base64 string", value)
+	}
+
+	runner.Reset()
+	if _, err := repo.FindByProperty(ctx, "Thumb", thumb); err != nil {
+		t.Fatalf("FindByProperty: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Params: map[string]any{"thumb": base64.StdEncoding.EncodeToString(thumb)},
+	})
+}
+
+func TestBase64FieldsRejectInvalidStrings(t *testing.T) {
+	repo, _ := newAvatarRepo(t, map[string]any{"thumb": "not base64!"})
+
+	_, err := repo.FindByID(context.Background(), "a1")
+	if err == nil || !strings.Contains(err.Error(), "invalid base64 string") {
+		t.Fatalf("FindByID error = %v, want an invalid base64 error", err)
+	}
+}
+
+func TestBytesLoadFromProjections(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Avatar](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord(
+			"id", "a1",
+			"image", []byte{7, 8},
+			"thumb", base64.StdEncoding.EncodeToString([]byte{9}),
+		)}}, nil
+	}
+
+	avatars, err := repo.FindRaw(context.Background(), "MATCH (n:Avatar) RETURN n.id AS id, n.image AS image, n.thumb AS thumb", nil)
+	if err != nil {
+		t.Fatalf("FindRaw: %v", err)
+	}
+	if len(avatars) != 1 || !bytes.Equal(avatars[0].Image, []byte{7, 8}) || !bytes.Equal(avatars[0].Thumb, []byte{9}) {
+		t.Errorf("projected avatars = %+v", avatars)
+	}
+}
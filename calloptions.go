@@ -0,0 +1,130 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CallConfig carries the per-call execution settings given with OnDatabase, WithTimeout
+// and ReadOnly. The zero value means the runner's defaults.
+type CallConfig struct {
+	// Database overrides the runner's target database, if not empty.
+	Database string
+	// Timeout bounds the call, if positive. It is applied as a context deadline and, by
+	// runners that support it, as the server-side transaction timeout.
+	Timeout time.Duration
+	// ReadOnly routes the query to a reader of the cluster, such as a follower.
+	ReadOnly bool
+}
+
+// ConfigurableRunner is implemented by runners that honor per-call settings, such as
+// Neo4jExecutor. Repository calls given OnDatabase or ReadOnly require it; WithTimeout
+// also works with plain runners, through the context deadline.
+type ConfigurableRunner interface {
+	DBRunner
+	// RunWithConfig executes a query like Run, applying the given settings.
+	RunWithConfig(ctx context.Context, query string, params map[string]interface{}, config CallConfig) (*ResultSet, error)
+}
+
+// ConfigurableStreamRunner is implemented by stream runners that honor per-call settings,
+// such as Neo4jExecutor. Streaming calls given OnDatabase or ReadOnly require it.
+type ConfigurableStreamRunner interface {
+	StreamRunner
+	// StreamWithConfig executes a query like Stream, applying the given settings.
+	StreamWithConfig(ctx context.Context, query string, params map[string]interface{}, config CallConfig) (RecordStream, error)
+}
+
+// OnDatabase returns a QueryOption running a single call against the named database
+// instead of the runner's default, for multi-tenant setups with a database per tenant:
+//
+//	user, err := userRepo.FindByID(ctx, id, neopersist.OnDatabase("tenant_42"))
+func OnDatabase(name string) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optDatabase
+		o.call.Database = name
+	}
+}
+
+// WithTimeout returns a QueryOption bounding a single call: the context is given a
+// deadline of d, and runners implementing ConfigurableRunner also set it as the
+// transaction timeout, so that the server aborts the query too.
+func WithTimeout(d time.Duration) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optTimeout
+		o.call.Timeout = d
+	}
+}
+
+// ReadOnly returns a QueryOption routing a single read call to a reader of the cluster,
+// taking load off the leader. It is not accepted by write methods.
+func ReadOnly() QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optReadOnly
+		o.call.ReadOnly = true
+	}
+}
+
+// execute runs a query with the call's settings, using RunWithConfig when they need the
+// runner's cooperation.
+func execute(ctx context.Context, runner DBRunner, query string, params map[string]interface{}, config CallConfig) (*ResultSet, error) {
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+	if configurable, ok := runner.(ConfigurableRunner); ok && config != (CallConfig{}) {
+		return configurable.RunWithConfig(ctx, query, params, config)
+	}
+	if config.Database != "" || config.ReadOnly {
+		return nil, fmt.Errorf("runner %T does not implement ConfigurableRunner, so OnDatabase and ReadOnly cannot be applied", runner)
+	}
+	return runner.Run(ctx, query, params)
+}
+
+// executeStream streams a query with the call's settings, using StreamWithConfig when they
+// need the runner's cooperation. A timeout bounds the whole stream, up to its last record.
+func executeStream(ctx context.Context, runner StreamRunner, query string, params map[string]interface{}, config CallConfig) (RecordStream, error) {
+	configurable, ok := runner.(ConfigurableStreamRunner)
+	if !ok && (config.Database != "" || config.ReadOnly) {
+		return nil, fmt.Errorf("runner %T does not implement ConfigurableStreamRunner, so OnDatabase and ReadOnly cannot be applied", runner)
+	}
+	open := func(ctx context.Context) (RecordStream, error) {
+		if ok && config != (CallConfig{}) {
+			return configurable.StreamWithConfig(ctx, query, params, config)
+		}
+		return runner.Stream(ctx, query, params)
+	}
+	if config.Timeout <= 0 {
+		return open(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	stream, err := open(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	deadline, _ := ctx.Deadline()
+	return &deadlineStream{RecordStream: stream, deadline: deadline, cancel: cancel}, nil
+}
+
+// deadlineStream bounds every Next call of a stream by the deadline of a WithTimeout call.
+type deadlineStream struct {
+	RecordStream
+	deadline time.Time
+	cancel   context.CancelFunc
+}
+
+// Next fetches the next record, failing once the deadline has passed.
+func (s *deadlineStream) Next(ctx context.Context) (*Record, error) {
+	ctx, cancel := context.WithDeadline(ctx, s.deadline)
+	defer cancel()
+	return s.RecordStream.Next(ctx)
+}
+
+// Close closes the stream and releases the deadline's timer.
+func (s *deadlineStream) Close(ctx context.Context) error {
+	defer s.cancel()
+	return s.RecordStream.Close(ctx)
+}
@@ -0,0 +1,298 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/examples/models"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// Ticket is an entity with a declared index.
+type Ticket struct {
+	ID     string `crud:"pk,property:id"`
+	Tenant string `crud:"property:tenant,index"`
+}
+
+// configRunner is a FakeRunner that also records the CallConfig of each query, recording
+// the zero CallConfig for queries sent through plain Run.
+type configRunner struct {
+	*neopersisttest.FakeRunner
+
+	mu      sync.Mutex
+	configs []neopersist.CallConfig
+}
+
+func newConfigRunner() *configRunner {
+	runner := &configRunner{FakeRunner: neopersisttest.NewFakeRunner()}
+	// Answer the advisory lock as free, so that Migrate and EnsureSchema get past it.
+	runner.Respond = func(_ int, query string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		if strings.Contains(query, "RETURN acquired") {
+			return recordSet(neopersist.NewRecord("acquired", true)), nil
+		}
+		return &neopersist.ResultSet{}, nil
+	}
+	return runner
+}
+
+func (c *configRunner) Run(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+	return c.RunWithConfig(ctx, query, params, neopersist.CallConfig{})
+}
+
+func (c *configRunner) RunWithConfig(ctx context.Context, query string, params map[string]interface{}, config neopersist.CallConfig) (*neopersist.ResultSet, error) {
+	c.mu.Lock()
+	c.configs = append(c.configs, config)
+	c.mu.Unlock()
+	return c.FakeRunner.Run(ctx, query, params)
+}
+
+// takeConfigs returns and discards the recorded configurations.
+func (c *configRunner) takeConfigs() []neopersist.CallConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	configs := c.configs
+	c.configs = nil
+	return configs
+}
+
+// routedCalls returns every repository and manager method accepting per-call options,
+// each calling the method with opts and returning its error.
+func routedCalls(t *testing.T, pm *neopersist.PersistenceManager) map[string]func(opts ...neopersist.QueryOption) error {
+	t.Helper()
+	users, err := neopersist.RepositoryFor[User](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor[User]: %v", err)
+	}
+	posts, err := neopersist.RepositoryFor[models.Post](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor[Post]: %v", err)
+	}
+	tickets, err := neopersist.RepositoryFor[Ticket](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor[Ticket]: %v", err)
+	}
+	articles, err := neopersist.RepositoryFor[Article](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor[Article]: %v", err)
+	}
+	counter, err := pm.MaintainCounter(neopersist.CounterSpec{OwnerLabel: "User", Property: "postCount", RelType: "WROTE"})
+	if err != nil {
+		t.Fatalf("MaintainCounter: %v", err)
+	}
+	ctx := context.Background()
+	adults := []neopersist.Condition{neopersist.Field("Age").Gte(18)}
+	match := func() *gocypher.QueryBuilder {
+		return gocypher.NewQueryBuilder().Match(gocypher.N("n", "User").WithProperties(map[string]interface{}{"userId": "u1"}))
+	}
+	noop := neopersist.Migration{ID: "001", Up: func(context.Context, neopersist.DBRunner) error { return nil }}
+
+	return map[string]func(opts ...neopersist.QueryOption) error{
+		"FindByID": func(opts ...neopersist.QueryOption) error {
+			_, err := users.FindByID(ctx, "u1", opts...)
+			return err
+		},
+		"FindPaged": func(opts ...neopersist.QueryOption) error {
+			_, err := users.FindPaged(ctx, neopersist.PageRequest{Limit: 10}, opts...)
+			return err
+		},
+		"FindPageWithCount": func(opts ...neopersist.QueryOption) error {
+			_, _, err := users.FindPageWithCount(ctx, 10, 0, "Name", false, opts...)
+			return err
+		},
+		"FindByIDWith": func(opts ...neopersist.QueryOption) error {
+			_, err := posts.FindByIDWith(ctx, "p1", []string{"Author"}, opts...)
+			return err
+		},
+		"Query.Count": func(opts ...neopersist.QueryOption) error {
+			_, err := users.Where(adults...).Count(ctx, opts...)
+			return err
+		},
+		"Query.Exists": func(opts ...neopersist.QueryOption) error {
+			_, err := users.Where(adults...).Exists(ctx, opts...)
+			return err
+		},
+		"ChangesSince": func(opts ...neopersist.QueryOption) error {
+			_, _, err := articles.ChangesSince(ctx, time.Time{}, 10, opts...)
+			return err
+		},
+		"DeleteAll": func(opts ...neopersist.QueryOption) error {
+			_, err := users.DeleteAll(ctx, append(opts, neopersist.ConfirmDestructive())...)
+			return err
+		},
+		"DeleteWhere": func(opts ...neopersist.QueryOption) error {
+			_, err := users.DeleteWhere(ctx, match(), opts...)
+			return err
+		},
+		"DeleteRelationsWhere": func(opts ...neopersist.QueryOption) error {
+			_, err := users.DeleteRelationsWhere(ctx, "FOLLOWS", adults, opts...)
+			return err
+		},
+		"DeleteCascade": func(opts ...neopersist.QueryOption) error {
+			_, err := posts.DeleteCascade(ctx, "p1", []string{"WROTE"}, opts...)
+			return err
+		},
+		"EstimateDelete": func(opts ...neopersist.QueryOption) error {
+			_, err := users.EstimateDelete(ctx, "u1", opts...)
+			return err
+		},
+		"EstimateDeleteAll": func(opts ...neopersist.QueryOption) error {
+			_, err := users.EstimateDeleteAll(ctx, opts...)
+			return err
+		},
+		"EstimateDeleteWhere": func(opts ...neopersist.QueryOption) error {
+			_, err := users.EstimateDeleteWhere(ctx, match(), opts...)
+			return err
+		},
+		"EstimateDeleteRelationsWhere": func(opts ...neopersist.QueryOption) error {
+			_, err := users.EstimateDeleteRelationsWhere(ctx, "FOLLOWS", adults, opts...)
+			return err
+		},
+		"EstimateDeleteCascade": func(opts ...neopersist.QueryOption) error {
+			_, err := posts.EstimateDeleteCascade(ctx, "p1", []string{"WROTE"}, opts...)
+			return err
+		},
+		"EnsureConstraints": func(opts ...neopersist.QueryOption) error {
+			_, err := users.EnsureConstraints(ctx, opts...)
+			return err
+		},
+		"EnsureIndexes": func(opts ...neopersist.QueryOption) error {
+			_, err := tickets.EnsureIndexes(ctx, opts...)
+			return err
+		},
+		"ValidateAgainstDatabase": func(opts ...neopersist.QueryOption) error {
+			_, err := users.ValidateAgainstDatabase(ctx, opts...)
+			return err
+		},
+		"PersistenceManager.CreateRelation": func(opts ...neopersist.QueryOption) error {
+			return pm.CreateRelation(ctx, &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "WROTE", nil, opts...)
+		},
+		"PersistenceManager.Relate": func(opts ...neopersist.QueryOption) error {
+			return pm.Relate(ctx, &models.Post{PostID: "p1"}, "Author", &models.User{UserID: "u1"}, opts...)
+		},
+		"PersistenceManager.DeleteRelation": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.DeleteRelation(ctx, &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "WROTE", opts...)
+			return err
+		},
+		"PersistenceManager.BackfillAliases": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.BackfillAliases(ctx, Contact{}, opts...)
+			return err
+		},
+		"PersistenceManager.FindGraph": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.FindGraph(ctx, match().Return("n"), opts...)
+			return err
+		},
+		"PersistenceManager.DescribeQuery": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.DescribeQuery(ctx, match().Return("n"), opts...)
+			return err
+		},
+		"PersistenceManager.QueryRaw": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.QueryRaw(ctx, "MATCH (n:User) RETURN n", nil, opts...)
+			return err
+		},
+		"PersistenceManager.ExecuteWithStats": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.ExecuteWithStats(ctx, "MATCH (n:User) SET n.seen = true", nil, opts...)
+			return err
+		},
+		"PersistenceManager.EnsureConstraints": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.EnsureConstraints(ctx, []any{User{}}, opts...)
+			return err
+		},
+		"PersistenceManager.EnsureIndexes": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.EnsureIndexes(ctx, []any{Ticket{}}, opts...)
+			return err
+		},
+		"PersistenceManager.ValidateAgainstDatabase": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.ValidateAgainstDatabase(ctx, []any{User{}}, opts...)
+			return err
+		},
+		"PersistenceManager.EnsureSchema": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.EnsureSchema(ctx, []any{Ticket{}}, opts...)
+			return err
+		},
+		"PersistenceManager.Migrate": func(opts ...neopersist.QueryOption) error {
+			_, err := pm.Migrate(ctx, []neopersist.Migration{noop}, opts...)
+			return err
+		},
+		"Counter.RecountAll": func(opts ...neopersist.QueryOption) error {
+			_, err := counter.RecountAll(ctx, opts...)
+			return err
+		},
+		"Counter.Drift": func(opts ...neopersist.QueryOption) error {
+			_, err := counter.Drift(ctx, opts...)
+			return err
+		},
+		"FindTree": func(opts ...neopersist.QueryOption) error {
+			_, err := neopersist.FindTree[models.Post](ctx, pm, "p1", 1, opts...)
+			return err
+		},
+	}
+}
+
+func TestCallOptionsReachEveryQuery(t *testing.T) {
+	runner := newConfigRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+	want := neopersist.CallConfig{Database: "tenant_42", Timeout: time.Minute}
+
+	for name, call := range routedCalls(t, pm) {
+		err := call(neopersist.OnDatabase("tenant_42"), neopersist.WithTimeout(time.Minute))
+		if errors.Is(err, neopersist.ErrUnsupportedOption) {
+			t.Errorf("%s rejected OnDatabase or WithTimeout: %v", name, err)
+		}
+		configs := runner.takeConfigs()
+		if len(configs) == 0 {
+			t.Errorf("%s sent no query (err = %v)", name, err)
+		}
+		for i, config := range configs {
+			if config != want {
+				t.Errorf("%s: query %d ran with %+v, want %+v", name, i, config, want)
+			}
+		}
+	}
+}
+
+func TestEmptyFindPagedCountsOnTheSameDatabase(t *testing.T) {
+	runner := newConfigRunner()
+	repo, err := neopersist.NewRepository[User](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	page, err := repo.FindPaged(context.Background(), neopersist.PageRequest{Limit: 10, Offset: 50}, neopersist.OnDatabase("tenant_42"))
+	if err != nil {
+		t.Fatalf("FindPaged: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("Items = %v, want an empty page", page.Items)
+	}
+	neopersisttest.AssertQuery(t, runner.FakeRunner, neopersisttest.Expect{Query: "MATCH (c:User) RETURN count(c) AS total"})
+	configs := runner.takeConfigs()
+	if len(configs) != 2 {
+		t.Fatalf("sent %d queries, want the page and the count", len(configs))
+	}
+	for i, config := range configs {
+		if config.Database != "tenant_42" {
+			t.Errorf("query %d ran on database %q, want tenant_42", i, config.Database)
+		}
+	}
+}
+
+func TestCallOptionsRejectUnsupportedOptions(t *testing.T) {
+	runner := newConfigRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+
+	for name, call := range routedCalls(t, pm) {
+		err := call(neopersist.WithSeed(1))
+		if !errors.Is(err, neopersist.ErrUnsupportedOption) {
+			t.Errorf("%s with WithSeed: err = %v, want ErrUnsupportedOption", name, err)
+		}
+	}
+	if configs := runner.takeConfigs(); len(configs) != 0 {
+		t.Errorf("sent %d queries with unsupported options, want none", len(configs))
+	}
+}
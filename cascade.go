@@ -0,0 +1,100 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeleteCascade deletes the node with the given primary key together with its dependents:
+// the nodes it points to through outgoing relationships of the given types that have no
+// other incoming relationship, from any other node. This is orphan removal for
+// aggregate-style models, such as an Order owning its LineItems, whereas Delete leaves
+// the children behind. Only direct children are considered, and every removed node is
+// detached first.
+//
+// Example:
+//
+//	removed, err := orderRepo.DeleteCascade(ctx, "order-1", []string{"CONTAINS"})
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to delete.
+//   - relTypes: The outgoing relationship types leading to dependents; at least one.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The number of dependent nodes removed, not counting the entity itself, or an error if
+//	the repository is read-only, no valid relationship type is given or the query fails.
+//	Deleting a key that does not exist removes nothing and is not an error.
+func (r *Repository[T]) DeleteCascade(ctx context.Context, id interface{}, relTypes []string, opts ...WriteOption) (int64, error) {
+	options, err := r.parseWriteOptions("DeleteCascade", opts, writeOptions)
+	if err != nil {
+		return 0, err
+	}
+	plan, err := r.deleteCascadePlan(id, relTypes)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := plan.execute(ctx, r.runnerWith(options), 0)
+	if err != nil {
+		return 0, err
+	}
+	// The entity itself is always part of the deleted nodes when it exists.
+	return max(deleted-1, 0), nil
+}
+
+// EstimateDeleteCascade is the dry-run counterpart of DeleteCascade. It reports the nodes,
+// entity included, and relationships DeleteCascade would remove, without modifying
+// anything.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity that would be deleted.
+//   - relTypes: The outgoing relationship types leading to dependents; at least one.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or ReadOnly.
+//
+// Returns:
+//
+//	A DeleteEstimate broken down by label and relationship type, or an error if no valid
+//	relationship type is given or the query fails.
+func (r *Repository[T]) EstimateDeleteCascade(ctx context.Context, id interface{}, relTypes []string, opts ...FindOption) (*DeleteEstimate, error) {
+	options, err := parseOptions("EstimateDeleteCascade", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := r.deleteCascadePlan(id, relTypes)
+	if err != nil {
+		return nil, err
+	}
+	return plan.estimate(ctx, r.runnerWith(options))
+}
+
+// deleteCascadePlan binds the entity with the given primary key and its orphaned children
+// through relTypes to a single variable, so that they are deleted, or estimated, together.
+func (r *Repository[T]) deleteCascadePlan(id interface{}, relTypes []string) (*deletePlan, error) {
+	if len(relTypes) == 0 {
+		return nil, fmt.Errorf("DeleteCascade needs at least one relationship type")
+	}
+	for _, relType := range relTypes {
+		if !identifierPattern.MatchString(relType) {
+			return nil, fmt.Errorf("invalid relationship type '%s'", relType)
+		}
+	}
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// A child is a dependent when every relationship pointing to it starts at the parent.
+	match := fmt.Sprintf(
+		"MATCH (parent:%s {%s: $id})\n"+
+			"OPTIONAL MATCH (parent)-[:%s]->(child)\n"+
+			"WHERE child <> parent AND size([(child)<--(other) WHERE other <> parent | other]) = 0\n"+
+			"WITH parent, collect(DISTINCT child) AS children\n"+
+			"UNWIND [parent] + children AS doomed",
+		r.meta.Label, r.meta.PKProp, strings.Join(relTypes, "|"),
+	)
+	return &deletePlan{match: match, alias: "doomed", params: map[string]interface{}{"id": key}}, nil
+}
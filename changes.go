@@ -0,0 +1,251 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Change is an entity reported by the polling change feed, together with the timestamp
+// at which it was last modified.
+type Change[T any] struct {
+	// Entity is the current state of the modified entity.
+	Entity *T
+	// UpdatedAt is the value of the entity's `updatedAt` field.
+	UpdatedAt time.Time
+}
+
+// touchUpdatedAt sets the entity's `updatedAt` field, if it declares one, to the current time.
+func (r *Repository[T]) touchUpdatedAt(val reflect.Value) {
+	if r.meta.UpdatedAtField == "" {
+		return
+	}
+	val.FieldByName(r.meta.UpdatedAtField).Set(reflect.ValueOf(time.Now().UTC()))
+}
+
+// Touch sets the `updatedAt` property of the node with the given primary key to the
+// current time, leaving every other property untouched. It is the cheap way to record
+// activity such as "last seen", without loading and re-saving the entity, which would
+// overwrite concurrent edits. The entity must declare an `updatedAt` field.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to touch.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	ErrNotFound if no node has the key, or an error if the entity has no `updatedAt`
+//	field or the query fails.
+func (r *Repository[T]) Touch(ctx context.Context, id interface{}, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("Touch", opts, writeOptions)
+	if err != nil {
+		return err
+	}
+	if r.meta.ChangeTrackingErr != nil {
+		return fmt.Errorf("cannot touch: %w", r.meta.ChangeTrackingErr)
+	}
+	query := fmt.Sprintf("MATCH (n:%s {%s: $pk})\nSET n.%s = $now\nRETURN n.%s AS pk",
+		r.meta.Label, r.meta.PKProp, r.meta.UpdatedAtProp, r.meta.PKProp)
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return err
+	}
+	params := map[string]interface{}{"pk": key, "now": r.meta.timeParam(time.Now().UTC())}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return err
+	}
+	if len(eagerResult.Records) == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ChangesSince returns the entities modified after the given watermark, ordered by their
+// `updatedAt` timestamp with ties broken by primary key, together with the new watermark
+// to persist and pass to the next call. The entity must declare a time.Time field tagged
+// with `updatedAt` (e.g., `crud:"property:updatedAt,updatedAt"`), which Save and SaveAll
+// keep current; other writers must maintain the property themselves.
+//
+// When a page is full, trailing changes sharing the last timestamp are held back and the
+// watermark is set just before them, so that the next call returns them all together
+// instead of skipping the ones beyond the limit. If every change in a full page shares the
+// same timestamp they are returned as is, and changes beyond the limit with that exact
+// timestamp may be missed; use a limit larger than the expected burst size.
+//
+// Deletions are not observable through this feed, since a deleted node no longer matches.
+// Entities that must report removals need to be soft-deleted (updating `updatedAt`)
+// rather than removed with Delete.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - since: The watermark returned by the previous call, or the zero time to start over.
+//   - limit: The maximum number of changes to return.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or CollectMappingErrors.
+//
+// Returns:
+//
+//	The changes found, the new watermark (equal to since if there were no changes), or an
+//	error if the entity does not track changes or the query fails.
+func (r *Repository[T]) ChangesSince(ctx context.Context, since time.Time, limit int, opts ...FindOption) ([]Change[T], time.Time, error) {
+	options, err := parseOptions("ChangesSince", opts, pageOptions)
+	if err != nil {
+		return nil, since, err
+	}
+	if r.meta.ChangeTrackingErr != nil {
+		return nil, since, r.meta.ChangeTrackingErr
+	}
+	if limit <= 0 {
+		return nil, since, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	query := fmt.Sprintf(
+		"MATCH (n:%s)\n"+
+			"WHERE n.%s > $since\n"+
+			"RETURN n\n"+
+			"ORDER BY n.%s, n.%s\n"+
+			"LIMIT $limit",
+		r.meta.Label,
+		r.meta.UpdatedAtProp,
+		r.meta.UpdatedAtProp, r.meta.PKProp,
+	)
+	params := map[string]interface{}{"since": r.meta.timeParam(since), "limit": int64(limit)}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, since, err
+	}
+	entities, err := r.mapRecords(ctx, eagerResult.Records, options)
+	if err != nil {
+		return nil, since, err
+	}
+
+	changes := make([]Change[T], len(entities))
+	for i, entity := range entities {
+		updatedAt := reflect.ValueOf(entity).Elem().FieldByName(r.meta.UpdatedAtField).Interface().(time.Time)
+		changes[i] = Change[T]{Entity: entity, UpdatedAt: updatedAt}
+	}
+	if len(changes) == 0 {
+		return changes, since, nil
+	}
+
+	// Hold back a trailing run of equal timestamps in a full page, so it is not split.
+	if len(changes) == limit {
+		last := changes[len(changes)-1].UpdatedAt
+		cut := len(changes)
+		for cut > 0 && changes[cut-1].UpdatedAt.Equal(last) {
+			cut--
+		}
+		if cut > 0 {
+			changes = changes[:cut]
+		}
+	}
+
+	return changes, changes[len(changes)-1].UpdatedAt, nil
+}
+
+// Watcher is a background poller started by Repository.Watch.
+type Watcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	watermark time.Time
+	err       error
+}
+
+// Watch starts a goroutine that polls ChangesSince every interval, starting at the given
+// watermark, and passes each non-empty batch of changes to fn. When a batch is full the
+// next one is fetched immediately instead of waiting for the interval.
+//
+// The watermark only advances after fn returns successfully, so a batch is redelivered if
+// the process stops while handling it. Polling stops when ctx is cancelled, when Stop is
+// called, or when fn or a query returns an error. The options are passed to every
+// ChangesSince call.
+//
+// Example:
+//
+//	w := userRepo.Watch(ctx, lastWatermark, 5*time.Second, 100, func(changes []neopersist.Change[models.User]) error {
+//	    return publish(changes)
+//	})
+//	defer func() { lastWatermark, _ = w.Stop() }()
+func (r *Repository[T]) Watch(ctx context.Context, since time.Time, interval time.Duration, limit int, fn func([]Change[T]) error, opts ...FindOption) *Watcher {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &Watcher{cancel: cancel, done: make(chan struct{}), watermark: since}
+
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			changes, next, err := r.ChangesSince(ctx, w.Watermark(), limit, opts...)
+			if err == nil && len(changes) > 0 {
+				err = fn(changes)
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					w.setErr(err)
+				}
+				return
+			}
+			w.setWatermark(next)
+
+			// A full batch suggests there is more to read, so poll again right away.
+			if len(changes) < limit {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			} else if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return w
+}
+
+// Watermark returns the watermark of the last batch that was handled successfully.
+func (w *Watcher) Watermark() time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.watermark
+}
+
+// Done returns a channel that is closed once the watcher has stopped polling.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Err returns the error that stopped the watcher, if any. Cancellation is not an error.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Stop cancels polling, waits for an in-flight callback to finish, and returns the final
+// watermark to persist together with the error that stopped the watcher, if any.
+func (w *Watcher) Stop() (time.Time, error) {
+	w.cancel()
+	<-w.done
+	return w.Watermark(), w.Err()
+}
+
+func (w *Watcher) setWatermark(t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.watermark = t
+}
+
+func (w *Watcher) setErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
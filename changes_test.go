@@ -0,0 +1,235 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Article tracks changes through its updatedAt field and is soft-deleted by setting
+// deletedAt.
+type Article struct {
+	ArticleID string     `crud:"pk,property:articleId"`
+	Title     string     `crud:"property:title"`
+	DeletedAt *time.Time `crud:"property:deletedAt"`
+	UpdatedAt time.Time  `crud:"property:updatedAt,updatedAt"`
+}
+
+// articleNode returns an Article node last modified at updatedAt.
+func articleNode(id string, updatedAt time.Time, deletedAt *time.Time) neopersist.Node {
+	props := map[string]any{"articleId": id, "title": "Title " + id, "updatedAt": updatedAt}
+	if deletedAt != nil {
+		props["deletedAt"] = *deletedAt
+	}
+	return neopersist.Node{ElementID: "4:test:" + id, Labels: []string{"Article"}, Props: props}
+}
+
+func newArticleRepo(t *testing.T) (*neopersist.Repository[Article], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Article](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	return repo, runner
+}
+
+var feedStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestChangesSinceOrdersByTimestampThenKey(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	t1, t2 := feedStart.Add(time.Second), feedStart.Add(2*time.Second)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(articleNode("a1", t1, nil), articleNode("a2", t2, nil)), nil
+	}
+
+	changes, watermark, err := repo.ChangesSince(context.Background(), feedStart, 10)
+	if err != nil {
+		t.Fatalf("ChangesSince: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query:       "MATCH (n:Article) WHERE n.updatedAt > $since RETURN n ORDER BY n.updatedAt, n.articleId LIMIT $limit",
+		Params:      map[string]any{"since": feedStart, "limit": int64(10)},
+		ExactParams: true,
+	})
+	if len(changes) != 2 || changes[0].Entity.ArticleID != "a1" || !changes[1].UpdatedAt.Equal(t2) {
+		t.Fatalf("changes = %+v, want a1 then a2", changes)
+	}
+	if !watermark.Equal(t2) {
+		t.Errorf("watermark = %v, want %v", watermark, t2)
+	}
+}
+
+func TestChangesSinceKeepsTheWatermarkWithoutChanges(t *testing.T) {
+	repo, _ := newArticleRepo(t)
+	changes, watermark, err := repo.ChangesSince(context.Background(), feedStart, 10)
+	if err != nil {
+		t.Fatalf("ChangesSince: %v", err)
+	}
+	if len(changes) != 0 || !watermark.Equal(feedStart) {
+		t.Errorf("got %d change(s) and watermark %v, want none and %v", len(changes), watermark, feedStart)
+	}
+}
+
+func TestChangesSinceHoldsBackTrailingTiesOfAFullPage(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	t1, t2 := feedStart.Add(time.Second), feedStart.Add(2*time.Second)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(articleNode("a1", t1, nil), articleNode("a2", t2, nil), articleNode("a3", t2, nil)), nil
+	}
+
+	changes, watermark, err := repo.ChangesSince(context.Background(), feedStart, 3)
+	if err != nil {
+		t.Fatalf("ChangesSince: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Entity.ArticleID != "a1" {
+		t.Fatalf("changes = %+v, want only a1", changes)
+	}
+	if !watermark.Equal(t1) {
+		t.Errorf("watermark = %v, want %v so that a2 and a3 are read together", watermark, t1)
+	}
+}
+
+func TestChangesSinceReturnsAFullPageOfTiesAsIs(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	t1 := feedStart.Add(time.Second)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(articleNode("a1", t1, nil), articleNode("a2", t1, nil)), nil
+	}
+
+	changes, watermark, err := repo.ChangesSince(context.Background(), feedStart, 2)
+	if err != nil {
+		t.Fatalf("ChangesSince: %v", err)
+	}
+	if len(changes) != 2 || !watermark.Equal(t1) {
+		t.Errorf("got %d change(s) and watermark %v, want 2 and %v", len(changes), watermark, t1)
+	}
+}
+
+func TestChangesSinceRequiresAnUpdatedAtField(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	if _, _, err := repo.ChangesSince(context.Background(), feedStart, 10); err == nil {
+		t.Fatal("expected an error for an entity without an updatedAt field")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestTouchRequiresAnUpdatedAtField(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	if err := repo.Touch(context.Background(), "u1"); err == nil || !strings.Contains(err.Error(), "no time.Time field tagged 'updatedAt'") {
+		t.Fatalf("Touch: err = %v, want the error recorded when parsing User", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestAnInvalidUpdatedAtFieldFailsWhenParsingTheEntity(t *testing.T) {
+	type Stamped struct {
+		ID        string `crud:"pk,property:id"`
+		UpdatedAt int64  `crud:"property:updatedAt,updatedAt"`
+	}
+	if _, err := neopersist.NewRepository[Stamped](neopersisttest.NewFakeRunner()); !errors.Is(err, neopersist.ErrInvalidTags) {
+		t.Fatalf("NewRepository: err = %v, want ErrInvalidTags", err)
+	}
+}
+
+func TestSoftDeletesAreObservableThroughChangesSince(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	ctx := context.Background()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(articleNode("a1", feedStart, nil)), nil
+	}
+
+	// Soft-deleting through PatchProperties bumps updatedAt, so the feed picks it up.
+	if err := repo.PatchProperties(ctx, "a1", map[string]interface{}{"deletedAt": feedStart}); err != nil {
+		t.Fatalf("PatchProperties: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"MATCH (n:Article {articleId: $articleId})", "n.deletedAt", "n.updatedAt"},
+	})
+
+	deletedAt := feedStart
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(articleNode("a1", feedStart.Add(time.Second), &deletedAt)), nil
+	}
+	changes, _, err := repo.ChangesSince(ctx, feedStart, 10)
+	if err != nil {
+		t.Fatalf("ChangesSince: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Entity.DeletedAt == nil || !changes[0].Entity.DeletedAt.Equal(deletedAt) {
+		t.Fatalf("changes = %+v, want the soft-deleted a1 with its deletedAt", changes)
+	}
+}
+
+func TestHardDeletesDoNotTouchTheChangeFeed(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	if err := repo.Delete(context.Background(), "a1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	for _, call := range runner.Calls() {
+		if !strings.Contains(call.Query, "DETACH DELETE n") || strings.Contains(call.Query, "updatedAt") {
+			t.Errorf("Delete sent %q; a hard delete removes the node and leaves nothing for the feed", call.Query)
+		}
+	}
+}
+
+func TestWatchDeliversBatchesAndStopsWithTheLastWatermark(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	t1 := feedStart.Add(time.Second)
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 0 {
+			return nodeResult(articleNode("a1", t1, nil)), nil
+		}
+		return &neopersist.ResultSet{}, nil
+	}
+
+	delivered := make(chan []neopersist.Change[Article], 1)
+	w := repo.Watch(context.Background(), feedStart, time.Millisecond, 10, func(changes []neopersist.Change[Article]) error {
+		delivered <- changes
+		return nil
+	})
+	select {
+	case changes := <-delivered:
+		if len(changes) != 1 || changes[0].Entity.ArticleID != "a1" {
+			t.Errorf("delivered %+v, want a1", changes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no batch delivered")
+	}
+
+	watermark, err := w.Stop()
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if !watermark.Equal(t1) {
+		t.Errorf("watermark = %v, want %v", watermark, t1)
+	}
+	select {
+	case <-w.Done():
+	default:
+		t.Error("Done is not closed after Stop")
+	}
+}
+
+func TestWatchStopsOnCallbackError(t *testing.T) {
+	repo, runner := newArticleRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(articleNode("a1", feedStart.Add(time.Second), nil)), nil
+	}
+	failure := errors.New("publish failed")
+	w := repo.Watch(context.Background(), feedStart, time.Millisecond, 10, func([]neopersist.Change[Article]) error {
+		return failure
+	})
+	<-w.Done()
+	watermark, err := w.Stop()
+	if !errors.Is(err, failure) {
+		t.Errorf("err = %v, want %v", err, failure)
+	}
+	if !watermark.Equal(feedStart) {
+		t.Errorf("watermark = %v, want it unchanged at %v since the batch was not handled", watermark, feedStart)
+	}
+}
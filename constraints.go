@@ -0,0 +1,135 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// SchemaChanges reports the outcome of schema bootstrapping methods such as
+// EnsureConstraints and EnsureIndexes, by the names of the schema rules involved.
+type SchemaChanges struct {
+	// Created lists the rules created by the call.
+	Created []string
+	// Existing lists the rules that already existed, under this name or as an equivalent
+	// rule, and were left untouched.
+	Existing []string
+}
+
+// EnsureConstraints creates the uniqueness constraints the entity type relies on: one on
+// the primary key property, and one on every property tagged with the `unique` tag
+// component. It is idempotent, so it can run at every startup, including concurrently
+// from several replicas.
+//
+// Constraints are named "<Label>_<property>_unique" and created with
+// `CREATE CONSTRAINT ... IF NOT EXISTS FOR (n:Label) REQUIRE n.prop IS UNIQUE`, a syntax
+// supported from Neo4j 4.4 on; older servers, which only know the ON ... ASSERT form, are
+// rejected with a *features.UnsupportedError when the runner can report the server
+// version. Creating a constraint fails if existing nodes already hold duplicate
+// values.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The constraints created and those that already existed, or an error if the
+//	repository is read-only or a constraint cannot be created.
+func (r *Repository[T]) EnsureConstraints(ctx context.Context, opts ...WriteOption) (*SchemaChanges, error) {
+	options, err := r.parseWriteOptions("EnsureConstraints", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	changes := &SchemaChanges{}
+	if err := ensureConstraints(ctx, r.runnerWith(options), r.meta, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// EnsureConstraints runs Repository.EnsureConstraints for each given entity type and
+// aggregates the outcome into a single report.
+//
+// Example:
+//
+//	changes, err := manager.EnsureConstraints(ctx, []any{models.User{}, models.Post{}})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Printf("created constraints: %v", changes.Created)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entityTypes: Values of, or pointers to, the entity structs to constrain.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The constraints created and those that already existed, or an error if a type has
+//	invalid tags or a constraint cannot be created.
+func (pm *PersistenceManager) EnsureConstraints(ctx context.Context, entityTypes []any, opts ...WriteOption) (*SchemaChanges, error) {
+	options, err := parseOptions("EnsureConstraints", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	changes := &SchemaChanges{}
+	for _, entityType := range entityTypes {
+		meta, err := pm.metadataFor(reflect.TypeOf(entityType))
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureConstraints(ctx, pm.runnerWith(options), meta, changes); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// ensureConstraints creates the uniqueness constraints of one entity, recording them in
+// changes.
+func ensureConstraints(ctx context.Context, runner DBRunner, meta *entityMetadata, changes *SchemaChanges) error {
+	if err := requireFeature(ctx, runner, features.SchemaCommands); err != nil {
+		return err
+	}
+	for _, prop := range meta.uniqueProperties() {
+		name := meta.constraintName(prop)
+		query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS\nFOR (n:%s)\nREQUIRE n.%s IS UNIQUE", quoteIdentifier(name), meta.Label, prop)
+		result, err := runner.Run(ctx, query, nil)
+		switch {
+		case isSchemaAlreadyExists(err):
+			changes.Existing = append(changes.Existing, name)
+		case err != nil:
+			return fmt.Errorf("could not create constraint %s: %w", name, err)
+		case result.Counters.ConstraintsAdded > 0:
+			changes.Created = append(changes.Created, name)
+		default:
+			changes.Existing = append(changes.Existing, name)
+		}
+	}
+	return nil
+}
+
+// uniqueProperties returns the properties EnsureConstraints makes unique: the primary key
+// and the properties of the fields tagged `unique`.
+func (m *entityMetadata) uniqueProperties() []string {
+	props := []string{m.PKProp}
+	for _, fieldName := range m.Unique {
+		props = append(props, m.Mappings[fieldName])
+	}
+	return props
+}
+
+// constraintName returns the name of the uniqueness constraint on prop.
+func (m *entityMetadata) constraintName(prop string) string {
+	return m.Label + "_" + prop + "_unique"
+}
+
+// quoteIdentifier quotes a schema rule name with backticks, so that it may hold any
+// character.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
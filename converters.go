@@ -0,0 +1,153 @@
+package neopersist
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PropertyConverter converts the values of a domain type, such as Money or EmailAddress,
+// to and from the primitive values Neo4j stores. Converters are registered per Go type
+// with WithConverter or PersistenceManager.RegisterConverter, and apply to every mapped
+// field of exactly that type, except the primary key.
+//
+// Example:
+//
+//	type centsConverter struct{}
+//
+//	func (centsConverter) ToProperty(v any) (any, error) {
+//	    return v.(Money).Cents, nil
+//	}
+//
+//	func (centsConverter) FromProperty(p any) (any, error) {
+//	    cents, ok := p.(int64)
+//	    if !ok {
+//	        return nil, fmt.Errorf("expected an integer, got %T", p)
+//	    }
+//	    return Money{Cents: cents}, nil
+//	}
+type PropertyConverter interface {
+	// ToProperty converts a field value into the value written to the database.
+	ToProperty(v any) (any, error)
+	// FromProperty converts a stored value into a value assignable to the field.
+	FromProperty(p any) (any, error)
+}
+
+// ConversionError reports a PropertyConverter failing to convert a field's value, in
+// either direction. When loading, it is wrapped in a *MappingError.
+type ConversionError struct {
+	// Label is the label of the entity type.
+	Label string
+	// Field is the name of the struct field being converted.
+	Field string
+	// Err is the error returned by the converter.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("could not convert field %s of entity type %s: %v", e.Field, e.Label, e.Err)
+}
+
+// Unwrap returns the error returned by the converter.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// WithConverter returns a RepositoryOption converting the mapped fields of type typ with
+// conv, as described on PropertyConverter. Converters given to the repository take
+// precedence over those registered on its manager.
+//
+// Example:
+//
+//	repo, err := neopersist.NewRepository[models.Order](runner,
+//	    neopersist.WithConverter(reflect.TypeOf(Money{}), centsConverter{}))
+func WithConverter(typ reflect.Type, conv PropertyConverter) RepositoryOption {
+	return func(c *repositoryConfig) {
+		if c.converters == nil {
+			c.converters = make(map[reflect.Type]PropertyConverter)
+		}
+		c.converters[typ] = conv
+	}
+}
+
+// RegisterConverter registers a PropertyConverter for fields of type typ on the manager.
+// Repositories created afterwards with RepositoryFor apply it like WithConverter.
+func (pm *PersistenceManager) RegisterConverter(typ reflect.Type, conv PropertyConverter) {
+	pm.converters.Store(typ, conv)
+}
+
+// converterOptions returns a WithConverter option for every converter registered on the
+// manager.
+func (pm *PersistenceManager) converterOptions() []RepositoryOption {
+	var opts []RepositoryOption
+	pm.converters.Range(func(typ, conv any) bool {
+		opts = append(opts, WithConverter(typ.(reflect.Type), conv.(PropertyConverter)))
+		return true
+	})
+	return opts
+}
+
+// resolveConverters assigns the given converters to the mapped fields of their type.
+func (m *entityMetadata) resolveConverters(converters map[reflect.Type]PropertyConverter) {
+	for fieldName, typ := range m.Types {
+		conv, ok := converters[typ]
+		if !ok || fieldName == m.PKField || m.JSONFields[fieldName] || m.Base64Fields[fieldName] {
+			continue
+		}
+		if m.Converters == nil {
+			m.Converters = make(map[string]PropertyConverter)
+		}
+		m.Converters[fieldName] = conv
+	}
+}
+
+// propertyValue converts the mapped field fieldName into the value sent to the database,
+// as JSON or base64 if it is tagged `json` or `base64`, with its converter if it has one,
+// or with toPropertyValue otherwise.
+func (m *entityMetadata) propertyValue(fieldName string, field reflect.Value) (interface{}, error) {
+	if m.Base64Fields[fieldName] {
+		return base64PropertyValue(field), nil
+	}
+	if m.JSONFields[fieldName] {
+		value, err := jsonPropertyValue(field)
+		if err != nil {
+			return nil, &ConversionError{Label: m.Label, Field: fieldName, Err: err}
+		}
+		return value, nil
+	}
+	conv, ok := m.Converters[fieldName]
+	if !ok {
+		return m.toPropertyValue(field), nil
+	}
+	value, err := conv.ToProperty(field.Interface())
+	if err != nil {
+		return nil, &ConversionError{Label: m.Label, Field: fieldName, Err: err}
+	}
+	return value, nil
+}
+
+// conditionValue converts a value compared with the mapped field fieldName in a query, such
+// as a Field condition or FindByProperty, the way the field itself would be written.
+func (m *entityMetadata) conditionValue(fieldName string, value interface{}) (interface{}, error) {
+	if m.JSONFields[fieldName] && !isNilValue(value) {
+		converted, err := jsonPropertyValue(reflect.ValueOf(value))
+		if err != nil {
+			return nil, &ConversionError{Label: m.Label, Field: fieldName, Err: err}
+		}
+		return converted, nil
+	}
+	if m.Base64Fields[fieldName] {
+		if data, ok := value.([]byte); ok {
+			return base64PropertyValue(reflect.ValueOf(data)), nil
+		}
+	}
+	conv, ok := m.Converters[fieldName]
+	if !ok || isNilValue(value) {
+		return m.paramValue(value), nil
+	}
+	converted, err := conv.ToProperty(value)
+	if err != nil {
+		return nil, &ConversionError{Label: m.Label, Field: fieldName, Err: err}
+	}
+	return converted, nil
+}
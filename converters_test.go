@@ -0,0 +1,107 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// failingConverter fails every conversion.
+type failingConverter struct{}
+
+func (failingConverter) ToProperty(any) (any, error)   { return nil, fmt.Errorf("refusing to write") }
+func (failingConverter) FromProperty(any) (any, error) { return nil, fmt.Errorf("refusing to read") }
+
+func TestConvertersWriteTheirPropertyValue(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+	ctx := context.Background()
+
+	if err := repo.Save(ctx, &Shipment{ID: "s1", Price: Money{Cents: 1999}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if price := setValue(t, runner.Calls()[0], "price"); price != int64(1999) {
+		t.Errorf("price = %#v, want 1999 cents", price)
+	}
+
+	runner.Reset()
+	if err := repo.SaveAll(ctx, []*Shipment{{ID: "s1", Price: Money{Cents: 1999}}, {ID: "s2", Price: Money{Cents: 5}}}); err != nil {
+		t.Fatalf("SaveAll: %v", err)
+	}
+	rows := runner.Calls()[0].Params["rows"].([]map[string]interface{})
+	for i, want := range []int64{1999, 5} {
+		if got := rows[i]["props"].(map[string]interface{})["price"]; got != want {
+			t.Errorf("row %d price = %#v, want %d", i, got, want)
+		}
+	}
+}
+
+func TestConvertersReadTheirPropertyValue(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{Labels: []string{"Shipment"}, Props: map[string]any{"id": "s1", "price": int64(2500)}}), nil
+	}
+
+	shipment, err := repo.FindByID(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if shipment.Price != (Money{Cents: 2500}) {
+		t.Errorf("Price = %+v, want 2500 cents", shipment.Price)
+	}
+}
+
+func TestConversionErrorsNameTheEntityAndField(t *testing.T) {
+	t.Run("save", func(t *testing.T) {
+		repo, runner := newShipmentRepo(t, neopersist.WithConverter(reflect.TypeOf(Money{}), failingConverter{}))
+
+		err := repo.Save(context.Background(), &Shipment{ID: "s1"})
+		var conversion *neopersist.ConversionError
+		if !errors.As(err, &conversion) || conversion.Label != "Shipment" || conversion.Field != "Price" {
+			t.Fatalf("Save error = %v, want a *ConversionError for Shipment.Price", err)
+		}
+		if !strings.Contains(err.Error(), "refusing to write") {
+			t.Errorf("error %q does not include the converter's error", err)
+		}
+		neopersisttest.AssertCallCount(t, runner, 0)
+	})
+
+	t.Run("load", func(t *testing.T) {
+		repo, runner := newShipmentRepo(t)
+		runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+			return nodeResult(neopersist.Node{Labels: []string{"Shipment"}, Props: map[string]any{"id": "s1", "price": "12.50"}}), nil
+		}
+
+		_, err := repo.FindByID(context.Background(), "s1")
+		var conversion *neopersist.ConversionError
+		if !errors.As(err, &conversion) || conversion.Label != "Shipment" || conversion.Field != "Price" {
+			t.Fatalf("FindByID error = %v, want a *ConversionError for Shipment.Price", err)
+		}
+		if !strings.Contains(err.Error(), "could not convert field Price of entity type Shipment: expected an integer, got string") {
+			t.Errorf("error %q does not describe the conversion", err)
+		}
+	})
+}
+
+func TestRegisteredConvertersApplyToManagedRepositories(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+	pm.RegisterConverter(reflect.TypeOf(Money{}), centsConverter{})
+	neopersist.RegisterEnumParser(pm, ParseShipmentStatus)
+
+	repo, err := neopersist.RepositoryFor[Shipment](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor: %v", err)
+	}
+	if err := repo.Save(context.Background(), &Shipment{ID: "s1", Price: Money{Cents: 42}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if price := setValue(t, runner.Calls()[0], "price"); price != int64(42) {
+		t.Errorf("price = %#v, want 42 cents", price)
+	}
+}
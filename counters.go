@@ -0,0 +1,279 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// identifierPattern matches the labels, relationship types and property names that may be
+// interpolated into generated Cypher without quoting.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// counterDriftSampleSize is the maximum number of drifted nodes listed in a CounterDriftReport.
+const counterDriftSampleSize = 100
+
+// CounterSpec declares a denormalized counter property kept on owner nodes, such as the
+// number of posts a user wrote stored in User.postCount.
+type CounterSpec struct {
+	// OwnerLabel is the label of the nodes holding the counter (e.g., "User").
+	OwnerLabel string
+	// Property is the counter property on the owner nodes (e.g., "postCount").
+	Property string
+	// RelType is the type of the counted relationships (e.g., "WROTE").
+	RelType string
+	// Direction is the direction of the counted relationships relative to the owner;
+	// empty means Outgoing.
+	Direction Direction
+}
+
+// pattern renders the counted relationships of the owner bound to alias.
+func (s CounterSpec) pattern(alias string) string {
+	if s.Direction == Incoming {
+		return fmt.Sprintf("(%s)<-[:%s]-()", alias, s.RelType)
+	}
+	return fmt.Sprintf("(%s)-[:%s]->()", alias, s.RelType)
+}
+
+// Counter maintains a counter declared with MaintainCounter.
+type Counter struct {
+	pm   *PersistenceManager
+	spec CounterSpec
+}
+
+// CounterDrift describes an owner node whose stored counter differs from the actual count.
+type CounterDrift struct {
+	// ElementID is the element ID of the owner node.
+	ElementID string
+	// Stored is the stored counter value, or nil if the property is missing.
+	Stored *int64
+	// Actual is the actual number of matching relationships.
+	Actual int64
+}
+
+// CounterDriftReport compares stored counters with the actual relationship counts.
+type CounterDriftReport struct {
+	// Checked is the number of owner nodes inspected.
+	Checked int64
+	// Drifted is the number of owner nodes whose counter is missing or wrong.
+	Drifted int64
+	// Samples lists up to 100 of the drifted nodes.
+	Samples []CounterDrift
+}
+
+// MaintainCounter registers a denormalized counter. From then on, CreateRelation and
+// Relate increment the counter of the owner when they create a matching relationship, and
+// DeleteRelation decrements it, in the same statement as the write; the update is an
+// atomic `+=` on the owner node, so concurrent writers do not lose increments.
+//
+// Relationships written by other means (raw queries, other services) are not tracked.
+// Use the returned Counter's Drift to detect the resulting drift and RecountAll to repair it.
+//
+// Example:
+//
+//	counter, err := manager.MaintainCounter(neopersist.CounterSpec{
+//	    OwnerLabel: "User", Property: "postCount", RelType: "WROTE", Direction: neopersist.Outgoing,
+//	})
+//
+// Returns:
+//
+//	A Counter handle, or an error if the spec is incomplete or contains invalid identifiers.
+func (pm *PersistenceManager) MaintainCounter(spec CounterSpec) (*Counter, error) {
+	if spec.Direction == "" {
+		spec.Direction = Outgoing
+	}
+	if spec.Direction != Outgoing && spec.Direction != Incoming {
+		return nil, fmt.Errorf("counter %s.%s has invalid direction '%s'", spec.OwnerLabel, spec.Property, spec.Direction)
+	}
+	for _, ident := range []string{spec.OwnerLabel, spec.Property, spec.RelType} {
+		if !identifierPattern.MatchString(ident) {
+			return nil, fmt.Errorf("counter spec has invalid identifier '%s'", ident)
+		}
+	}
+
+	pm.countersMu.Lock()
+	defer pm.countersMu.Unlock()
+	for _, existing := range pm.counters {
+		if existing.OwnerLabel == spec.OwnerLabel && existing.Property == spec.Property {
+			return nil, fmt.Errorf("counter %s.%s is already maintained", spec.OwnerLabel, spec.Property)
+		}
+	}
+	pm.counters = append(pm.counters, spec)
+	return &Counter{pm: pm, spec: spec}, nil
+}
+
+// counterUpdates returns the SET items adjusting, by delta, the counters affected by a
+// relationship of relType from the node bound to fromAlias to the node bound to toAlias.
+// delta is a Cypher expression, such as "1" or "-size(rels)".
+func (pm *PersistenceManager) counterUpdates(fromLabel, fromAlias, toLabel, toAlias, relType, delta string) []string {
+	pm.countersMu.RLock()
+	defer pm.countersMu.RUnlock()
+
+	var updates []string
+	for _, spec := range pm.counters {
+		if spec.RelType != relType {
+			continue
+		}
+		owner := ""
+		switch {
+		case spec.Direction == Outgoing && spec.OwnerLabel == fromLabel:
+			owner = fromAlias
+		case spec.Direction == Incoming && spec.OwnerLabel == toLabel:
+			owner = toAlias
+		default:
+			continue
+		}
+		updates = append(updates, fmt.Sprintf("%[1]s.%[2]s = coalesce(%[1]s.%[2]s, 0) + %[3]s", owner, spec.Property, delta))
+	}
+	return updates
+}
+
+// RecountAll recomputes the counter from the actual relationships on every owner node
+// whose stored value is missing or wrong, in batches of 1000 nodes per transaction.
+// Optional WriteOption values, such as OnDatabase or WithTimeout, apply to every batch.
+//
+// Returns:
+//
+//	The number of owner nodes whose counter was corrected, or an error if a batch fails.
+func (c *Counter) RecountAll(ctx context.Context, opts ...WriteOption) (int64, error) {
+	options, err := parseOptions("RecountAll", opts, writeOptions)
+	if err != nil {
+		return 0, err
+	}
+	query := fmt.Sprintf(
+		"MATCH (o:%[1]s)\n"+
+			"WITH o, size([%[2]s | 1]) AS actual\n"+
+			"WHERE o.%[3]s IS NULL OR o.%[3]s <> actual\n"+
+			"WITH o, actual LIMIT $batchSize\n"+
+			"SET o.%[3]s = actual\n"+
+			"RETURN count(o) AS fixed",
+		c.spec.OwnerLabel, c.spec.pattern("o"), c.spec.Property,
+	)
+	params := map[string]interface{}{"batchSize": defaultBatchSize}
+
+	var total int64
+	for {
+		result, err := c.pm.run(ctx, query, params, options)
+		if err != nil {
+			return total, fmt.Errorf("could not recount %s.%s: %w", c.spec.OwnerLabel, c.spec.Property, err)
+		}
+		var fixed int64
+		if len(result.Records) > 0 {
+			value, _ := result.Records[0].Get("fixed")
+			fixed, _ = value.(int64)
+		}
+		total += fixed
+		if fixed < defaultBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Drift compares the stored counter of every owner node with the actual number of
+// relationships, without modifying anything. Optional FindOption values, such as
+// OnDatabase or ReadOnly, apply to the check.
+func (c *Counter) Drift(ctx context.Context, opts ...FindOption) (*CounterDriftReport, error) {
+	options, err := parseOptions("Drift", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireFeature(ctx, c.pm.runner, features.ElementID); err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(
+		"MATCH (o:%[1]s)\n"+
+			"WITH o, size([%[2]s | 1]) AS actual\n"+
+			"WITH count(o) AS checked, collect(CASE WHEN o.%[3]s IS NULL OR o.%[3]s <> actual "+
+			"THEN {id: elementId(o), stored: o.%[3]s, actual: actual} END) AS drifted\n"+
+			"RETURN checked, size(drifted) AS driftedCount, drifted[0..$sampleSize] AS samples",
+		c.spec.OwnerLabel, c.spec.pattern("o"), c.spec.Property,
+	)
+	result, err := c.pm.run(ctx, query, map[string]interface{}{"sampleSize": counterDriftSampleSize}, options)
+	if err != nil {
+		return nil, fmt.Errorf("could not check drift of %s.%s: %w", c.spec.OwnerLabel, c.spec.Property, err)
+	}
+
+	report := &CounterDriftReport{}
+	if len(result.Records) == 0 {
+		return report, nil
+	}
+	record := result.Records[0]
+	checked, _ := record.Get("checked")
+	drifted, _ := record.Get("driftedCount")
+	samples, _ := record.Get("samples")
+	report.Checked, _ = checked.(int64)
+	report.Drifted, _ = drifted.(int64)
+	list, _ := samples.([]interface{})
+	for _, item := range list {
+		sample, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		drift := CounterDrift{}
+		drift.ElementID, _ = sample["id"].(string)
+		drift.Actual, _ = sample["actual"].(int64)
+		if stored, ok := sample["stored"].(int64); ok {
+			drift.Stored = &stored
+		}
+		report.Samples = append(report.Samples, drift)
+	}
+	return report, nil
+}
+
+// DeleteRelation deletes the relationships of the given type from one entity to another
+// and returns how many were deleted. Counters registered with MaintainCounter are
+// decremented in the same statement.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - fromEntity: A pointer to the entity the relationships start from.
+//   - toEntity: A pointer to the entity the relationships point to.
+//   - relType: The relationship type (e.g., "WROTE").
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The number of relationships deleted, or an error if the query fails.
+func (pm *PersistenceManager) DeleteRelation(ctx context.Context, fromEntity any, toEntity any, relType string, opts ...WriteOption) (int64, error) {
+	options, err := parseOptions("DeleteRelation", opts, writeOptions)
+	if err != nil {
+		return 0, err
+	}
+	if !identifierPattern.MatchString(relType) {
+		return 0, fmt.Errorf("invalid relationship type '%s'", relType)
+	}
+	fromMeta, fromPKVal, err := pm.getEntityMetaAndPK(fromEntity)
+	if err != nil {
+		return 0, err
+	}
+	toMeta, toPKVal, err := pm.getEntityMetaAndPK(toEntity)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(
+		"MATCH (a:%s {%s: $fromId})-[r:%s]->(b:%s {%s: $toId})\n"+
+			"WITH a, b, collect(r) AS rels\n"+
+			"FOREACH (r IN rels | DELETE r)",
+		fromMeta.Label, fromMeta.PKProp, relType, toMeta.Label, toMeta.PKProp,
+	)
+	if updates := pm.counterUpdates(fromMeta.Label, "a", toMeta.Label, "b", relType, "-size(rels)"); len(updates) > 0 {
+		query += "\nSET " + strings.Join(updates, ", ")
+	}
+	query += "\nRETURN size(rels) AS deleted"
+	params := map[string]interface{}{"fromId": fromPKVal, "toId": toPKVal}
+
+	result, err := pm.run(ctx, query, params, options)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	if len(result.Records) > 0 {
+		value, _ := result.Records[0].Get("deleted")
+		deleted, _ = value.(int64)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,197 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/examples/models"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// newCountedManager returns a manager maintaining User.postCount over outgoing WROTE
+// relationships and Post.authorCount over incoming ones.
+func newCountedManager(t *testing.T) (*neopersist.PersistenceManager, *neopersisttest.FakeRunner, *neopersist.Counter) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+	posts, err := pm.MaintainCounter(neopersist.CounterSpec{OwnerLabel: "User", Property: "postCount", RelType: "WROTE"})
+	if err != nil {
+		t.Fatalf("MaintainCounter: %v", err)
+	}
+	_, err = pm.MaintainCounter(neopersist.CounterSpec{
+		OwnerLabel: "Post", Property: "authorCount", RelType: "WROTE", Direction: neopersist.Incoming,
+	})
+	if err != nil {
+		t.Fatalf("MaintainCounter: %v", err)
+	}
+	return pm, runner, posts
+}
+
+func TestMaintainCounterRejectsInvalidSpecs(t *testing.T) {
+	pm := neopersist.NewPersistenceManager(neopersisttest.NewFakeRunner())
+	if _, err := pm.MaintainCounter(neopersist.CounterSpec{OwnerLabel: "User", Property: "postCount", RelType: "WROTE"}); err != nil {
+		t.Fatalf("MaintainCounter: %v", err)
+	}
+
+	for name, spec := range map[string]neopersist.CounterSpec{
+		"duplicate":          {OwnerLabel: "User", Property: "postCount", RelType: "LIKED"},
+		"invalid direction":  {OwnerLabel: "User", Property: "likeCount", RelType: "LIKED", Direction: "both"},
+		"invalid identifier": {OwnerLabel: "User", Property: "like count", RelType: "LIKED"},
+		"missing type":       {OwnerLabel: "User", Property: "likeCount"},
+	} {
+		if _, err := pm.MaintainCounter(spec); err == nil {
+			t.Errorf("%s: MaintainCounter(%+v) succeeded", name, spec)
+		}
+	}
+}
+
+func TestCreateRelationIncrementsCounters(t *testing.T) {
+	pm, runner, _ := newCountedManager(t)
+	ctx := context.Background()
+
+	if err := pm.CreateRelation(ctx, &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "WROTE", nil); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	// The outgoing spec updates the start node and the incoming spec the end node.
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{
+			"CREATE (a)-[r:WROTE]->(b)",
+			"SET a.postCount = coalesce(a.postCount, 0) + 1, b.authorCount = coalesce(b.authorCount, 0) + 1",
+		},
+	})
+
+	runner.Reset()
+	if err := pm.CreateRelation(ctx, &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "LIKED", nil); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	if query := runner.Calls()[0].Query; strings.Contains(query, "SET") {
+		t.Errorf("a relationship of another type updated a counter: %s", query)
+	}
+}
+
+func TestRelateIncrementsCountersOnlyOnCreate(t *testing.T) {
+	pm, runner, _ := newCountedManager(t)
+
+	// Post.Author is declared incoming, so the user is bound to b and the post to a.
+	if err := pm.Relate(context.Background(), &models.Post{PostID: "p1"}, "Author", &models.User{UserID: "u1"}); err != nil {
+		t.Fatalf("Relate: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (a:Post {postId: $fromId}) MATCH (b:User {userId: $toId}) MERGE (a)<-[r:WROTE]-(b) " +
+			"ON CREATE SET b.postCount = coalesce(b.postCount, 0) + 1, a.authorCount = coalesce(a.authorCount, 0) + 1",
+		Params:      map[string]any{"fromId": "p1", "toId": "u1"},
+		ExactParams: true,
+	})
+}
+
+func TestDeleteRelationDecrementsCounters(t *testing.T) {
+	pm, runner, _ := newCountedManager(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("deleted", int64(2))}}, nil
+	}
+
+	deleted, err := pm.DeleteRelation(context.Background(), &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "WROTE")
+	if err != nil {
+		t.Fatalf("DeleteRelation: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (a:User {userId: $fromId})-[r:WROTE]->(b:Post {postId: $toId}) " +
+			"WITH a, b, collect(r) AS rels FOREACH (r IN rels | DELETE r) " +
+			"SET a.postCount = coalesce(a.postCount, 0) + -size(rels), b.authorCount = coalesce(b.authorCount, 0) + -size(rels) " +
+			"RETURN size(rels) AS deleted",
+		Params:      map[string]any{"fromId": "u1", "toId": "p1"},
+		ExactParams: true,
+	})
+}
+
+func TestRecountAllStopsOnAShortBatch(t *testing.T) {
+	_, runner, counter := newCountedManager(t)
+	batches := []int64{1000, 1000, 3}
+	runner.Respond = func(index int, _ string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("fixed", batches[index])}}, nil
+	}
+
+	fixed, err := counter.RecountAll(context.Background())
+	if err != nil {
+		t.Fatalf("RecountAll: %v", err)
+	}
+	if fixed != 2003 {
+		t.Errorf("fixed = %d, want 2003", fixed)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (o:User) WITH o, size([(o)-[:WROTE]->() | 1]) AS actual " +
+			"WHERE o.postCount IS NULL OR o.postCount <> actual WITH o, actual LIMIT $batchSize " +
+			"SET o.postCount = actual RETURN count(o) AS fixed",
+		Params: map[string]any{"batchSize": 1000},
+		Times:  3,
+	})
+}
+
+func TestRecountAllReportsTheFixedCountOfAFailedBatch(t *testing.T) {
+	_, runner, counter := newCountedManager(t)
+	failure := errors.New("connection lost")
+	runner.Respond = func(index int, _ string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 1 {
+			return nil, failure
+		}
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("fixed", int64(1000))}}, nil
+	}
+
+	fixed, err := counter.RecountAll(context.Background())
+	if !errors.Is(err, failure) || fixed != 1000 {
+		t.Errorf("RecountAll = %d, %v, want 1000 fixed before the failure", fixed, err)
+	}
+}
+
+func TestDriftDecodesSamples(t *testing.T) {
+	_, runner, counter := newCountedManager(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		samples := []interface{}{
+			map[string]interface{}{"id": "4:db:1", "stored": int64(3), "actual": int64(2)},
+			map[string]interface{}{"id": "4:db:2", "stored": nil, "actual": int64(1)},
+		}
+		return &neopersist.ResultSet{Records: []*neopersist.Record{
+			neopersist.NewRecord("checked", int64(5), "driftedCount", int64(2), "samples", samples),
+		}}, nil
+	}
+
+	report, err := counter.Drift(context.Background())
+	if err != nil {
+		t.Fatalf("Drift: %v", err)
+	}
+	stored := int64(3)
+	want := &neopersist.CounterDriftReport{
+		Checked: 5,
+		Drifted: 2,
+		Samples: []neopersist.CounterDrift{
+			{ElementID: "4:db:1", Stored: &stored, Actual: 2},
+			{ElementID: "4:db:2", Actual: 1},
+		},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"MATCH (o:User)", "size([(o)-[:WROTE]->() | 1]) AS actual", "drifted[0..$sampleSize] AS samples"},
+		Params:        map[string]any{"sampleSize": 100},
+	})
+}
+
+func TestDriftRequiresElementIDs(t *testing.T) {
+	_, runner, counter := newCountedManager(t)
+	runner.ServerVersion = "4.4"
+
+	var unsupported *features.UnsupportedError
+	if _, err := counter.Drift(context.Background()); !errors.As(err, &unsupported) {
+		t.Fatalf("Drift error = %v, want a *features.UnsupportedError", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
@@ -0,0 +1,514 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Condition is a predicate on the repository's entity, bound to the alias n, that is
+// rendered into the WHERE clause of a Query. Values are always passed as parameters.
+type Condition interface {
+	// render returns the Cypher predicate, registering its parameters in params.
+	render(meta *entityMetadata, params *paramSet) (string, error)
+}
+
+// paramSet collects the parameters of a query's conditions, renaming those whose names
+// are already taken so that independent conditions cannot clobber each other.
+type paramSet struct {
+	values map[string]interface{}
+}
+
+// add stores value under name, or under a fresh name derived from it if name is taken,
+// and returns the name used.
+func (p *paramSet) add(name string, value interface{}) string {
+	unique := name
+	for i := 1; ; i++ {
+		if _, taken := p.values[unique]; !taken {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	p.values[unique] = value
+	return unique
+}
+
+// rawParamPattern matches parameter references such as $threshold in a raw fragment.
+var rawParamPattern = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// rawForbiddenKeywords are the words a raw fragment may not contain outside string
+// literals: clauses that could end the WHERE clause, and subquery expressions that could
+// read data the query was not meant to reach.
+var rawForbiddenKeywords = map[string]bool{
+	"RETURN": true, "DELETE": true, "DETACH": true, "SET": true, "REMOVE": true,
+	"CREATE": true, "MERGE": true, "CALL": true, "WITH": true, "UNION": true,
+	"MATCH": true, "OPTIONAL": true, "LOAD": true, "UNWIND": true, "FOREACH": true,
+	"EXISTS": true, "USE": true, "FINISH": true,
+}
+
+// rawSubqueryKeywords are the functions that, followed by a brace, open a subquery.
+var rawSubqueryKeywords = map[string]bool{"COUNT": true, "COLLECT": true}
+
+// checkRawFragment scans a raw fragment outside its string literals and quoted names, and
+// rejects forbidden keywords, comments, semicolons and unbalanced or mismatched
+// parentheses, brackets and braces, any of which could let the fragment close the WHERE
+// clause or open a subquery.
+func checkRawFragment(fragment string) error {
+	tokens, err := scanCypher(fragment)
+	if err != nil {
+		return err
+	}
+	previous := ""
+	for _, token := range tokens {
+		switch {
+		case token.kind == tokenComment:
+			return fmt.Errorf("forbidden token %q", token.text[:2])
+		case token.text == ";":
+			return fmt.Errorf("forbidden token %q", ";")
+		case token.text == "{" && rawSubqueryKeywords[previous]:
+			return fmt.Errorf("forbidden %s subquery", previous)
+		case token.isKeyword(rawForbiddenKeywords):
+			// The WITH of the STARTS WITH and ENDS WITH operators is not a clause.
+			if token.upper() != "WITH" || (previous != "STARTS" && previous != "ENDS") {
+				return fmt.Errorf("forbidden keyword %q", token.text)
+			}
+		}
+		previous = ""
+		if token.kind == tokenWord {
+			previous = token.upper()
+		}
+	}
+	return nil
+}
+
+// RawCondition is a hand-written Cypher predicate, created with Raw.
+type RawCondition struct {
+	fragment string
+	params   map[string]interface{}
+	unsafe   bool
+}
+
+// Raw returns a Condition for a Cypher predicate the other conditions cannot express. The
+// fragment refers to the entity as n and to values as $name parameters, which must all be
+// present in params:
+//
+//	neopersist.Raw("n.score * $w > $threshold", map[string]any{"w": 0.5, "threshold": 10})
+//
+// Parameters are renamed if another condition of the same query uses the same name, so
+// fragments can be composed freely. Fragments containing clause keywords (RETURN, MATCH,
+// LOAD, CALL, ...), subqueries (EXISTS, COUNT or COLLECT braces), comments, semicolons or
+// unbalanced parentheses, brackets or braces are rejected when the query is built, since
+// they could end the WHERE clause or read other data; call Unsafe to allow them for
+// trusted fragments.
+func Raw(fragment string, params map[string]interface{}) *RawCondition {
+	return &RawCondition{fragment: fragment, params: params}
+}
+
+// Unsafe allows the fragment to contain otherwise forbidden keywords, for trusted input
+// such as a pattern predicate using WITH inside a subquery. Never use it with user input.
+func (c *RawCondition) Unsafe() *RawCondition {
+	c.unsafe = true
+	return c
+}
+
+// render validates the fragment and rewrites its parameter references to their final names.
+func (c *RawCondition) render(_ *entityMetadata, params *paramSet) (string, error) {
+	if strings.TrimSpace(c.fragment) == "" {
+		return "", fmt.Errorf("raw condition is empty")
+	}
+	if !c.unsafe {
+		if err := checkRawFragment(c.fragment); err != nil {
+			return "", fmt.Errorf("raw condition %q rejected: %w; use Unsafe for trusted fragments", c.fragment, err)
+		}
+	}
+
+	renamed := make(map[string]string, len(c.params))
+	var missing []string
+	rendered := rawParamPattern.ReplaceAllStringFunc(c.fragment, func(ref string) string {
+		name := ref[1:]
+		value, ok := c.params[name]
+		if !ok {
+			missing = append(missing, name)
+			return ref
+		}
+		if _, done := renamed[name]; !done {
+			renamed[name] = params.add(name, value)
+		}
+		return "$" + renamed[name]
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("raw condition %q references parameters without values: %s", c.fragment, strings.Join(missing, ", "))
+	}
+	return "(" + rendered + ")", nil
+}
+
+// logicalCondition combines conditions with AND or OR.
+type logicalCondition struct {
+	operator   string
+	conditions []Condition
+}
+
+// And returns a Condition that holds when all the given conditions hold.
+func And(conditions ...Condition) Condition {
+	return &logicalCondition{operator: "AND", conditions: conditions}
+}
+
+// Or returns a Condition that holds when any of the given conditions holds.
+func Or(conditions ...Condition) Condition {
+	return &logicalCondition{operator: "OR", conditions: conditions}
+}
+
+func (c *logicalCondition) render(meta *entityMetadata, params *paramSet) (string, error) {
+	if len(c.conditions) == 0 {
+		return "", fmt.Errorf("%s needs at least one condition", c.operator)
+	}
+	parts := make([]string, len(c.conditions))
+	for i, cond := range c.conditions {
+		if cond == nil {
+			return "", fmt.Errorf("%s condition %d is nil", c.operator, i)
+		}
+		part, err := cond.render(meta, params)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = part
+	}
+	return "(" + strings.Join(parts, " "+c.operator+" ") + ")", nil
+}
+
+// notCondition negates a condition.
+type notCondition struct {
+	condition Condition
+}
+
+// Not returns a Condition that holds when the given condition does not.
+func Not(condition Condition) Condition {
+	return &notCondition{condition: condition}
+}
+
+func (c *notCondition) render(meta *entityMetadata, params *paramSet) (string, error) {
+	if c.condition == nil {
+		return "", fmt.Errorf("NOT condition is nil")
+	}
+	inner, err := c.condition.render(meta, params)
+	if err != nil {
+		return "", err
+	}
+	return "(NOT " + inner + ")", nil
+}
+
+// FieldRef refers to a mapped field of the repository's entity by its Go struct field
+// name, as in Field("Name").Eq("Alice"). The field is resolved to its property name when
+// the query is built, so renaming a property tag does not break the query, and an unknown
+// field fails the build instead of silently matching nothing.
+type FieldRef struct {
+	name string
+}
+
+// Field returns a reference to the mapped struct field with the given Go name, from which
+// conditions are created.
+func Field(name string) FieldRef {
+	return FieldRef{name: name}
+}
+
+// fieldCondition compares a mapped field with a value, or tests it for null when the
+// operator is unary.
+type fieldCondition struct {
+	field    string
+	operator string
+	value    interface{}
+	unary    bool
+}
+
+// Eq returns a Condition that holds when the field equals value.
+func (f FieldRef) Eq(value interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: "=", value: value}
+}
+
+// Ne returns a Condition that holds when the field differs from value.
+func (f FieldRef) Ne(value interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: "<>", value: value}
+}
+
+// Gt returns a Condition that holds when the field is greater than value.
+func (f FieldRef) Gt(value interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: ">", value: value}
+}
+
+// Gte returns a Condition that holds when the field is greater than or equal to value.
+func (f FieldRef) Gte(value interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: ">=", value: value}
+}
+
+// Lt returns a Condition that holds when the field is less than value.
+func (f FieldRef) Lt(value interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: "<", value: value}
+}
+
+// Lte returns a Condition that holds when the field is less than or equal to value.
+func (f FieldRef) Lte(value interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: "<=", value: value}
+}
+
+// In returns a Condition that holds when the field equals one of the elements of values,
+// which must be a slice.
+func (f FieldRef) In(values interface{}) Condition {
+	return &fieldCondition{field: f.name, operator: "IN", value: values}
+}
+
+// Contains returns a Condition that holds when the string field contains substr.
+func (f FieldRef) Contains(substr string) Condition {
+	return &fieldCondition{field: f.name, operator: "CONTAINS", value: substr}
+}
+
+// StartsWith returns a Condition that holds when the string field starts with prefix.
+func (f FieldRef) StartsWith(prefix string) Condition {
+	return &fieldCondition{field: f.name, operator: "STARTS WITH", value: prefix}
+}
+
+// EndsWith returns a Condition that holds when the string field ends with suffix.
+func (f FieldRef) EndsWith(suffix string) Condition {
+	return &fieldCondition{field: f.name, operator: "ENDS WITH", value: suffix}
+}
+
+// IsNull returns a Condition that holds when the node lacks the field's property.
+func (f FieldRef) IsNull() Condition {
+	return &fieldCondition{field: f.name, operator: "IS NULL", unary: true}
+}
+
+// IsNotNull returns a Condition that holds when the node has the field's property.
+func (f FieldRef) IsNotNull() Condition {
+	return &fieldCondition{field: f.name, operator: "IS NOT NULL", unary: true}
+}
+
+// render resolves the field to its property and passes the value as a parameter.
+func (c *fieldCondition) render(meta *entityMetadata, params *paramSet) (string, error) {
+	propName, ok := meta.Mappings[c.field]
+	if !ok {
+		return "", fmt.Errorf("field '%s' is not a mapped field of entity type %s", c.field, meta.Label)
+	}
+	if c.unary {
+		return fmt.Sprintf("(n.%s %s)", propName, c.operator), nil
+	}
+	value := c.value
+	if c.operator != "IN" {
+		var err error
+		if value, err = meta.conditionValue(c.field, value); err != nil {
+			return "", err
+		}
+	}
+	name := params.add(propName, value)
+	return fmt.Sprintf("(n.%s %s $%s)", propName, c.operator, name), nil
+}
+
+// Query is a filtered query over a repository's entities, started with Repository.Query
+// or Repository.Where. Conditions added to it are combined with AND.
+type Query[T any] struct {
+	repo       *Repository[T]
+	conditions []Condition
+	sorts      []Sort
+	limit      int64
+}
+
+// Query starts a Query over all entities of type T, to be narrowed with Where and shaped
+// with OrderBy and Limit.
+//
+// Example:
+//
+//	users, err := userRepo.Query().
+//	    Where(neopersist.Field("Name").Eq("Alice")).
+//	    And(neopersist.Field("Age").Gt(30)).
+//	    OrderBy("Name").
+//	    Limit(10).
+//	    Find(ctx)
+func (r *Repository[T]) Query() *Query[T] {
+	return &Query[T]{repo: r}
+}
+
+// Where starts a Query matching the entities that satisfy all the given conditions.
+//
+// Example:
+//
+//	users, err := userRepo.Where(neopersist.Raw("n.score * $w > $threshold", map[string]any{"w": 0.5, "threshold": 10})).Find(ctx)
+func (r *Repository[T]) Where(conditions ...Condition) *Query[T] {
+	return &Query[T]{repo: r, conditions: conditions}
+}
+
+// Where adds conditions that must hold. It is a synonym of And that reads better as the
+// first call after Repository.Query.
+func (q *Query[T]) Where(conditions ...Condition) *Query[T] {
+	return q.And(conditions...)
+}
+
+// And adds conditions that must also hold.
+func (q *Query[T]) And(conditions ...Condition) *Query[T] {
+	q.conditions = append(q.conditions, conditions...)
+	return q
+}
+
+// OrderBy sorts the results by the given field in ascending order, after any previous
+// orderings. The field is a Go struct field name or a mapped property name; ties are
+// broken by primary key. Count ignores the ordering.
+func (q *Query[T]) OrderBy(field string) *Query[T] {
+	q.sorts = append(q.sorts, Asc(field))
+	return q
+}
+
+// OrderByDesc sorts the results by the given field in descending order, after any
+// previous orderings.
+func (q *Query[T]) OrderByDesc(field string) *Query[T] {
+	q.sorts = append(q.sorts, Desc(field))
+	return q
+}
+
+// Limit caps the number of entities returned by Find. Count ignores the limit.
+func (q *Query[T]) Limit(n int64) *Query[T] {
+	q.limit = n
+	return q
+}
+
+// build renders `MATCH (n:Label) WHERE ... RETURN <returns>`, followed by the ORDER BY and
+// LIMIT clauses when shaped is set.
+func (q *Query[T]) build(returns string, shaped bool) (string, map[string]interface{}, error) {
+	meta := q.repo.meta
+	params := &paramSet{values: make(map[string]interface{})}
+	query := fmt.Sprintf("MATCH (n:%s)", meta.Label)
+	if len(q.conditions) > 0 {
+		where, err := And(q.conditions...).render(meta, params)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not build conditions for %s: %w", meta.Label, err)
+		}
+		query += "\nWHERE " + where
+	}
+	query += "\nRETURN " + returns
+	if !shaped {
+		return query, params.values, nil
+	}
+	if len(q.sorts) > 0 {
+		order, err := q.repo.orderClause(q.sorts)
+		if err != nil {
+			return "", nil, err
+		}
+		query += "\nORDER BY " + order
+	}
+	if q.limit != 0 {
+		if q.limit < 0 {
+			return "", nil, fmt.Errorf("query limit must be positive, got %d", q.limit)
+		}
+		query += "\nLIMIT $" + params.add("queryLimit", q.limit)
+	}
+	return query, params.values, nil
+}
+
+// Find returns every entity matching the query's conditions, in the query's order and up to
+// its limit.
+func (q *Query[T]) Find(ctx context.Context, opts ...FindOption) ([]*T, error) {
+	options, err := q.repo.parseListOptions("Query.Find", opts)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := q.build("n", true)
+	if err != nil {
+		return nil, err
+	}
+	eagerResult, err := q.repo.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return q.repo.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// FindOne returns the single entity matching the query's conditions, ErrNotFound if there
+// is none, or a *MultipleFoundError if there are several.
+func (q *Query[T]) FindOne(ctx context.Context, opts ...FindOption) (*T, error) {
+	options, err := parseOptions("Query.FindOne", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := q.build("n", true)
+	if err != nil {
+		return nil, err
+	}
+	eagerResult, err := q.repo.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return nil, ErrNotFound
+	}
+	if len(eagerResult.Records) > 1 {
+		return nil, &MultipleFoundError{Label: q.repo.meta.Label, Count: len(eagerResult.Records)}
+	}
+	entity := new(T)
+	if err := mapRecordToStruct(eagerResult.Records[0], entity, q.repo.meta); err != nil {
+		return nil, err
+	}
+	return entity, q.repo.afterLoad(ctx, entity, -1)
+}
+
+// Count returns the number of entities matching the query's conditions.
+func (q *Query[T]) Count(ctx context.Context, opts ...FindOption) (int64, error) {
+	options, err := parseOptions("Query.Count", opts, lookupOptions)
+	if err != nil {
+		return 0, err
+	}
+	query, params, err := q.build("count(n) AS count", false)
+	if err != nil {
+		return 0, err
+	}
+	eagerResult, err := q.repo.run(ctx, query, params, options)
+	if err != nil {
+		return 0, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return 0, nil
+	}
+	count, _ := eagerResult.Records[0].Get("count")
+	n, _ := count.(int64)
+	return n, nil
+}
+
+// Exists reports whether any entity matches the query's conditions, stopping at the first
+// match instead of counting them all.
+func (q *Query[T]) Exists(ctx context.Context, opts ...FindOption) (bool, error) {
+	options, err := parseOptions("Query.Exists", opts, lookupOptions)
+	if err != nil {
+		return false, err
+	}
+	query, params, err := q.build("true AS exists", false)
+	if err != nil {
+		return false, err
+	}
+	eagerResult, err := q.repo.run(ctx, query+"\nLIMIT 1", params, options)
+	if err != nil {
+		return false, err
+	}
+	return existsFromResult(eagerResult), nil
+}
+
+// CountWhere returns the number of entities satisfying all the given conditions. It is
+// the typed counterpart of CountWithQuery: the count column is generated internally, so
+// there is no alias to get wrong. Without conditions it counts every entity.
+//
+// The conditions are variadic, so CountWhere takes no per-call settings; to pass some,
+// such as OnDatabase, count through Where instead:
+//
+//	n, err := userRepo.Where(conditions...).Count(ctx, neopersist.OnDatabase("tenant_42"))
+//
+// Example:
+//
+//	adults, err := userRepo.CountWhere(ctx, neopersist.Field("Age").Gte(18))
+func (r *Repository[T]) CountWhere(ctx context.Context, conditions ...Condition) (int64, error) {
+	return r.Where(conditions...).Count(ctx)
+}
+
+// ExistsWhere reports whether any entity satisfies all the given conditions. Like
+// CountWhere, it takes no per-call settings; use Where(conditions...).Exists to pass some.
+//
+// Example:
+//
+//	taken, err := userRepo.ExistsWhere(ctx, neopersist.Field("Email").Eq(email))
+func (r *Repository[T]) ExistsWhere(ctx context.Context, conditions ...Condition) (bool, error) {
+	return r.Where(conditions...).Exists(ctx)
+}
@@ -0,0 +1,113 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+func TestRawConditionsRenameCollidingParameters(t *testing.T) {
+	repo, runner := newUserRepo(t)
+
+	_, err := repo.Where(
+		neopersist.Raw("n.score * $w > $threshold", map[string]any{"w": 0.5, "threshold": 10}),
+		neopersist.Or(
+			neopersist.Raw("n.age > $threshold", map[string]any{"threshold": 18}),
+			neopersist.Raw("n.rank < $threshold OR n.bonus > $threshold", map[string]any{"threshold": 3}),
+		),
+	).Find(context.Background())
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (n:User) WHERE ((n.score * $w > $threshold) AND ((n.age > $threshold_1) OR (n.rank < $threshold_2 OR n.bonus > $threshold_2))) RETURN n",
+		Params: map[string]any{
+			"w": 0.5, "threshold": 10, "threshold_1": 18, "threshold_2": 3,
+		},
+		ExactParams: true,
+	})
+}
+
+func TestRawConditionRejectsFragmentsEscapingTheWhereClause(t *testing.T) {
+	rejected := map[string]string{
+		"closing paren then LOAD CSV":  "n.name = $x OR true) LOAD CSV FROM 'http:' + '/' + '/evil/x' AS l MATCH (m:Secret) WHERE (true",
+		"EXISTS subquery":              "n.name = $x OR EXISTS { MATCH (s:Secret) WHERE s.owner = n.userId }",
+		"COUNT subquery":               "COUNT { MATCH (s:Secret) } > 0",
+		"CALL subquery":                "true CALL { MATCH (s:Secret) RETURN s }",
+		"UNWIND":                       "true UNWIND [1] AS x",
+		"FOREACH":                      "true FOREACH (x IN [1] | SET n.a = x)",
+		"RETURN":                       "true RETURN n",
+		"line comment":                 "n.age > $x // rest",
+		"block comment":                "n.age > $x /* rest */",
+		"semicolon":                    "n.age > $x; MATCH (m) DETACH DELETE m",
+		"unclosed paren":               "(n.age > $x",
+		"mismatched brackets":          "n.tags[0) = $x",
+		"unterminated string":          "n.name = 'abc",
+		"keyword after string literal": "n.name = 'a' MATCH (m)",
+	}
+	for name, fragment := range rejected {
+		repo, runner := newUserRepo(t)
+		if _, err := repo.Where(neopersist.Raw(fragment, map[string]any{"x": 1})).Find(context.Background()); err == nil {
+			t.Errorf("%s: %q was accepted", name, fragment)
+		}
+		neopersisttest.AssertCallCount(t, runner, 0)
+	}
+
+	accepted := []string{
+		"n.name STARTS WITH $x OR n.name ENDS WITH $x",
+		"n.note = 'RETURN n; // not a clause' AND n.label = \"it's (fine\"",
+		"n.set = $x AND n.match IN [$x, ($x + 1)]",
+		"size([t IN n.tags WHERE t = $x]) > 0",
+		"n.config = {depth: $x}",
+	}
+	for _, fragment := range accepted {
+		repo, _ := newUserRepo(t)
+		if _, err := repo.Where(neopersist.Raw(fragment, map[string]any{"x": 1})).Find(context.Background()); err != nil {
+			t.Errorf("%q was rejected: %v", fragment, err)
+		}
+	}
+
+	repo, _ := newUserRepo(t)
+	trusted := neopersist.Raw("EXISTS { MATCH (n)-[:OWNS]->(:Secret) }", nil).Unsafe()
+	if _, err := repo.Where(trusted).Find(context.Background()); err != nil {
+		t.Errorf("Unsafe fragment was rejected: %v", err)
+	}
+}
+
+func TestCountWhereAndExistsWhereTakeVariadicConditions(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(_ int, query string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		if strings.Contains(query, "count(n)") {
+			return &neopersist.ResultSet{Keys: []string{"count"}, Records: []*neopersist.Record{neopersist.NewRecord("count", int64(4))}}, nil
+		}
+		return &neopersist.ResultSet{Keys: []string{"exists"}, Records: []*neopersist.Record{neopersist.NewRecord("exists", true)}}, nil
+	}
+	ctx := context.Background()
+
+	count, err := repo.CountWhere(ctx, neopersist.Field("Age").Gte(18), neopersist.Field("Name").Eq("Alice"))
+	if err != nil || count != 4 {
+		t.Fatalf("CountWhere = %d, %v, want 4", count, err)
+	}
+	exists, err := repo.ExistsWhere(ctx, neopersist.Field("Email").Eq("alice@example.com"))
+	if err != nil || !exists {
+		t.Fatalf("ExistsWhere = %v, %v, want true", exists, err)
+	}
+	if _, err := repo.CountWhere(ctx); err != nil {
+		t.Fatalf("CountWhere without conditions: %v", err)
+	}
+
+	neopersisttest.AssertCalls(t, runner,
+		neopersisttest.Expect{
+			Query:  "MATCH (n:User) WHERE ((n.age >= $age) AND (n.name = $name)) RETURN count(n) AS count",
+			Params: map[string]any{"age": 18, "name": "Alice"},
+		},
+		neopersisttest.Expect{
+			Query:  "MATCH (n:User) WHERE ((n.email = $email)) RETURN true AS exists LIMIT 1",
+			Params: map[string]any{"email": "alice@example.com"},
+		},
+		neopersisttest.Expect{Query: "MATCH (n:User) RETURN count(n) AS count"},
+	)
+}
@@ -0,0 +1,221 @@
+package neopersist
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// Cursor is an opaque, URL-safe position in a keyset-paginated result, as returned by
+// FindAfter. It can be sent to API clients as is and passed back verbatim to fetch the
+// next page. The empty Cursor starts from the first page in primary key order; use
+// StartCursor to start a different ordering.
+type Cursor string
+
+// cursorState is the decoded content of a Cursor.
+type cursorState struct {
+	// OrderBy is the field or property the pages are ordered by; empty means the primary key.
+	OrderBy string `json:"o,omitempty"`
+	// Desc reports descending order.
+	Desc bool `json:"d,omitempty"`
+	// After is false for a start cursor, which has not seen any entity yet.
+	After bool `json:"a,omitempty"`
+	// Value is the ordering value of the last entity seen.
+	Value any `json:"v,omitempty"`
+	// ID is the primary key of the last entity seen.
+	ID any `json:"id,omitempty"`
+}
+
+// StartCursor returns a Cursor for the first page of a keyset pagination ordered by the
+// given Go field name or mapped property name. The ordering is carried by every cursor
+// FindAfter returns, so it only has to be chosen once.
+func StartCursor(orderBy string, desc bool) Cursor {
+	cursor, _ := encodeCursor(cursorState{OrderBy: orderBy, Desc: desc})
+	return cursor
+}
+
+// encodeCursor serializes a cursor state.
+func encodeCursor(state cursorState) (Cursor, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("could not encode cursor: %w", err)
+	}
+	return Cursor(base64.RawURLEncoding.EncodeToString(data)), nil
+}
+
+// decodeCursor parses a cursor, keeping integers exact instead of turning them into float64.
+func decodeCursor(cursor Cursor) (cursorState, error) {
+	var state cursorState
+	if cursor == "" {
+		return state, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err != nil {
+		return state, fmt.Errorf("invalid cursor: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		return state, fmt.Errorf("invalid cursor: %w", err)
+	}
+	state.Value = fromJSONNumber(state.Value)
+	state.ID = fromJSONNumber(state.ID)
+	return state, nil
+}
+
+// fromJSONNumber converts a json.Number into an int64 when possible, or a float64.
+func fromJSONNumber(value any) any {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// checkCursorValue rejects ordering values whose JSON encoding would not round-trip to a
+// comparable database value.
+func checkCursorValue(value any) error {
+	switch value.(type) {
+	case nil, string, bool, int64, float64:
+		return nil
+	}
+	return fmt.Errorf("cannot paginate on values of type %T: only strings, numbers and booleans are supported", value)
+}
+
+// FindAfter retrieves the page of at most limit entities that follows the cursor, using
+// keyset pagination: instead of skipping rows like FindPage, the query seeks past the last
+// seen ordering value and primary key, so deep pages cost the same as the first one.
+//
+// It returns the cursor of the next page, or an empty Cursor once the last page has been
+// returned. Entities whose ordering property is null are placed after all others in
+// ascending order, and before them in descending order, matching Cypher's ORDER BY.
+//
+// Example:
+//
+//	cursor := neopersist.StartCursor("Name", false)
+//	for {
+//	    users, next, err := userRepo.FindAfter(ctx, cursor, 100)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(users)
+//	    if next == "" {
+//	        break
+//	    }
+//	    cursor = next
+//	}
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - cursor: The cursor returned by the previous call, a StartCursor, or empty.
+//   - limit: The maximum number of entities to return; must be positive.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	The entities of the page and the cursor of the next one, or an error if the cursor is
+//	invalid, the ordering is not a mapped field, or the query fails.
+func (r *Repository[T]) FindAfter(ctx context.Context, cursor Cursor, limit int64, opts ...FindOption) ([]*T, Cursor, error) {
+	options, err := parseOptions("FindAfter", opts, pageOptions)
+	if err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("page limit must be positive, got %d", limit)
+	}
+	state, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	orderProp, err := r.orderProperty(state.OrderBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label)).
+		Return(fmt.Sprintf("n, n.%s AS cursorValue, n.%s AS cursorId", orderProp, r.meta.PKProp)).
+		Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// gocypher has no WHERE, ORDER BY or LIMIT, so the seek predicate and ordering are
+	// spliced into the built query. Property names come from the validated mappings.
+	if state.After {
+		query = strings.Replace(query, "\nRETURN ", "\nWHERE "+keysetPredicate(orderProp, r.meta.PKProp, state)+"\nRETURN ", 1)
+		params["cursorValue"] = state.Value
+		params["cursorId"] = state.ID
+	}
+	direction := ""
+	if state.Desc {
+		direction = " DESC"
+	}
+	order := fmt.Sprintf("n.%s%s", orderProp, direction)
+	if orderProp != r.meta.PKProp {
+		order += fmt.Sprintf(", n.%s%s", r.meta.PKProp, direction)
+	}
+	query = fmt.Sprintf("%s\nORDER BY %s\nLIMIT $pageLimit", query, order)
+	params["pageLimit"] = limit
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, "", err
+	}
+	entities, err := r.mapRecords(ctx, eagerResult.Records, options)
+	if err != nil || int64(len(eagerResult.Records)) < limit {
+		return entities, "", err
+	}
+
+	last := eagerResult.Records[len(eagerResult.Records)-1]
+	value, _ := last.Get("cursorValue")
+	id, _ := last.Get("cursorId")
+	if err := checkCursorValue(value); err != nil {
+		return nil, "", err
+	}
+	if err := checkCursorValue(id); err != nil {
+		return nil, "", err
+	}
+	next, err := encodeCursor(cursorState{OrderBy: state.OrderBy, Desc: state.Desc, After: true, Value: value, ID: id})
+	if err != nil {
+		return nil, "", err
+	}
+	return entities, next, nil
+}
+
+// keysetPredicate renders the condition selecting the entities after the cursor position.
+// Cypher has no row-value comparison, so (n.prop, n.pk) > ($v, $id) is expanded, and null
+// ordering values, which sort last ascending and first descending, are handled explicitly.
+func keysetPredicate(orderProp, pkProp string, state cursorState) string {
+	cmp := ">"
+	if state.Desc {
+		cmp = "<"
+	}
+	if orderProp == pkProp {
+		return fmt.Sprintf("n.%s %s $cursorId", pkProp, cmp)
+	}
+
+	tie := fmt.Sprintf("n.%s %s $cursorId", pkProp, cmp)
+	switch {
+	case state.Value == nil && !state.Desc:
+		// Inside the trailing null block: only later nulls remain.
+		return fmt.Sprintf("(n.%s IS NULL AND %s)", orderProp, tie)
+	case state.Value == nil && state.Desc:
+		// Inside the leading null block: later nulls, then every non-null value.
+		return fmt.Sprintf("((n.%s IS NULL AND %s) OR n.%s IS NOT NULL)", orderProp, tie, orderProp)
+	case !state.Desc:
+		// Greater values, equal values with a greater key, then the trailing nulls.
+		return fmt.Sprintf("(n.%[1]s > $cursorValue OR (n.%[1]s = $cursorValue AND %[2]s) OR n.%[1]s IS NULL)", orderProp, tie)
+	default:
+		return fmt.Sprintf("(n.%[1]s < $cursorValue OR (n.%[1]s = $cursorValue AND %[2]s))", orderProp, tie)
+	}
+}
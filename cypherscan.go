@@ -0,0 +1,119 @@
+package neopersist
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cypherTokenKind classifies the tokens produced by scanCypher.
+type cypherTokenKind int
+
+const (
+	// tokenWord is a keyword, identifier or number.
+	tokenWord cypherTokenKind = iota
+	// tokenQuoted is a string literal or a backtick-quoted name.
+	tokenQuoted
+	// tokenComment is a line or block comment.
+	tokenComment
+	// tokenPunct is any other single character, such as a delimiter or an operator.
+	tokenPunct
+)
+
+// cypherToken is a token of a Cypher text, as produced by scanCypher.
+type cypherToken struct {
+	kind cypherTokenKind
+	// text is the token as written.
+	text string
+	// depth is the number of parentheses, brackets and braces enclosing the token; an
+	// opening or closing delimiter is counted outside the pair it belongs to.
+	depth int
+	// member is set on words directly preceded by '.' or '$', which name a property or a
+	// parameter and are never keywords.
+	member bool
+}
+
+// upper returns the token text in upper case, for keyword comparisons.
+func (t cypherToken) upper() string {
+	return strings.ToUpper(t.text)
+}
+
+// isKeyword reports whether the token is a word spelling one of the given keywords.
+func (t cypherToken) isKeyword(keywords map[string]bool) bool {
+	return t.kind == tokenWord && !t.member && keywords[t.upper()]
+}
+
+// scanCypher splits a Cypher text into tokens, skipping whitespace, so that keywords can be
+// told apart from string contents, comments and property names. It fails on unterminated
+// quotes or comments and on unbalanced or mismatched parentheses, brackets and braces.
+func scanCypher(text string) ([]cypherToken, error) {
+	var tokens []cypherToken
+	var open []byte
+	closing := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	for i := 0; i < len(text); {
+		c := text[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			i++
+			for i < len(text) && text[i] != c {
+				if text[i] == '\\' && c != '`' {
+					i++
+				}
+				i++
+			}
+			if i >= len(text) {
+				return nil, fmt.Errorf("unterminated %c quote", c)
+			}
+			i++
+			tokens = append(tokens, cypherToken{kind: tokenQuoted, text: text[start:i], depth: len(open)})
+			continue
+		case c == '/' && i+1 < len(text) && text[i+1] == '/':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			tokens = append(tokens, cypherToken{kind: tokenComment, text: text[start:i], depth: len(open)})
+			continue
+		case c == '/' && i+1 < len(text) && text[i+1] == '*':
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+			tokens = append(tokens, cypherToken{kind: tokenComment, text: text[start:i], depth: len(open)})
+			continue
+		case isWordByte(c):
+			for i < len(text) && isWordByte(text[i]) {
+				i++
+			}
+			member := start > 0 && (text[start-1] == '.' || text[start-1] == '$')
+			tokens = append(tokens, cypherToken{kind: tokenWord, text: text[start:i], depth: len(open), member: member})
+			continue
+		}
+
+		i++
+		depth := len(open)
+		switch c {
+		case '(', '[', '{':
+			open = append(open, c)
+		case ')', ']', '}':
+			if len(open) == 0 || open[len(open)-1] != closing[c] {
+				return nil, fmt.Errorf("unbalanced %q", c)
+			}
+			open = open[:len(open)-1]
+			depth = len(open)
+		}
+		tokens = append(tokens, cypherToken{kind: tokenPunct, text: string(c), depth: depth})
+	}
+	if len(open) > 0 {
+		return nil, fmt.Errorf("unbalanced %q", open[len(open)-1])
+	}
+	return tokens, nil
+}
+
+// isWordByte reports whether c may be part of a Cypher keyword or identifier.
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -4,19 +4,82 @@ package neopersist
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
 )
 
 // DBRunner defines the interface for a generic query executor.
 // It abstracts the execution of a Cypher query, allowing for different implementations
-// or mocking in tests.
+// or mocking in tests. Results are returned as a driver-independent ResultSet.
 type DBRunner interface {
 	// Run executes a given Cypher query with parameters and returns a fully-buffered result.
+	Run(ctx context.Context, query string, params map[string]interface{}) (*ResultSet, error)
+}
+
+// V5Runner is the shape of runners written against earlier versions of this package,
+// which returned the Neo4j v5 driver's EagerResult. Wrap them with FromV5Runner.
+type V5Runner interface {
 	Run(ctx context.Context, query string, params map[string]interface{}) (*neo4j.EagerResult, error)
 }
 
+// FromV5Runner adapts a runner returning driver results into a DBRunner. Parameters are
+// converted to driver values before they are passed on, and results and errors are
+// converted back, so the wrapped runner sees exactly what it saw before.
+//
+// Example:
+//
+//	repo, err := neopersist.NewRepository[models.User](neopersist.FromV5Runner(legacyRunner))
+func FromV5Runner(runner V5Runner) DBRunner {
+	return v5Runner{runner: runner}
+}
+
+// v5Runner is the DBRunner returned by FromV5Runner.
+type v5Runner struct {
+	runner V5Runner
+}
+
+// Run converts the parameters, delegates to the wrapped runner and converts its result.
+func (r v5Runner) Run(ctx context.Context, query string, params map[string]interface{}) (*ResultSet, error) {
+	result, err := r.runner.Run(ctx, query, toDriverParams(params))
+	if err != nil {
+		return nil, fromDriverError(err)
+	}
+	return fromEagerResult(result), nil
+}
+
+//---
+
+// ErrResultLimitExceeded is a sentinel error returned when a query produces more records
+// than the executor is allowed to buffer in memory. The concrete error returned is a
+// *ResultLimitError, which carries the limit and the offending query.
+var ErrResultLimitExceeded = errors.New("result limit exceeded")
+
+// ResultLimitError reports that a query was aborted because it returned more records
+// than the configured buffering limit. It matches ErrResultLimitExceeded via errors.Is.
+type ResultLimitError struct {
+	// Limit is the maximum number of records that were allowed to be buffered.
+	Limit int
+	// Query is the Cypher query that exceeded the limit.
+	Query string
+}
+
+// Error implements the error interface.
+func (e *ResultLimitError) Error() string {
+	return fmt.Sprintf("%s: query returned more than %d records: %s", ErrResultLimitExceeded, e.Limit, e.Query)
+}
+
+// Unwrap allows errors.Is(err, ErrResultLimitExceeded) to match a *ResultLimitError.
+func (e *ResultLimitError) Unwrap() error {
+	return ErrResultLimitExceeded
+}
+
 //---
 
 // Neo4jExecutor is a concrete implementation of the DBRunner interface that uses the
@@ -24,6 +87,49 @@ type DBRunner interface {
 type Neo4jExecutor struct {
 	Driver neo4j.DriverWithContext
 	DBName string
+	// MaxBufferedRecords is the maximum number of records a single query may buffer
+	// before it is aborted with a *ResultLimitError. Zero means no limit.
+	MaxBufferedRecords int
+
+	// serverInfoMu guards serverInfo, which caches the result of the first ServerInfo call.
+	serverInfoMu sync.Mutex
+	serverInfo   *ServerInfo
+
+	// maxPoolSize is the configured connection pool size; zero means the driver default.
+	maxPoolSize int
+	// metrics is notified of connection usage changes, if set.
+	metrics MetricsCollector
+	// defaultQueryTags are attached to every transaction; see WithDefaultQueryTags.
+	defaultQueryTags map[string]string
+	poolCounters
+}
+
+// ExecutorOption configures optional behavior of a Neo4jExecutor.
+type ExecutorOption func(*Neo4jExecutor)
+
+// WithMaxBufferedRecords guards the process against runaway queries by limiting how many
+// records a single query may buffer in memory. Once the limit is exceeded the query is
+// aborted and Run returns a *ResultLimitError. A value of zero or less disables the guard.
+//
+// Individual calls that legitimately need more records can raise or disable the limit
+// with WithRecordLimit.
+func WithMaxBufferedRecords(n int) ExecutorOption {
+	return func(e *Neo4jExecutor) {
+		e.MaxBufferedRecords = n
+	}
+}
+
+// WithDefaultQueryTags returns an ExecutorOption that attaches the given tags (e.g., the
+// service name and version) to the transaction metadata of every query, so that queries
+// can be attributed in the server's query log and in SHOW TRANSACTIONS. Tags set on the
+// context with WithQueryTag are merged on top and win on conflicting keys.
+func WithDefaultQueryTags(tags map[string]string) ExecutorOption {
+	return func(e *Neo4jExecutor) {
+		e.defaultQueryTags = make(map[string]string, len(tags))
+		for k, v := range tags {
+			e.defaultQueryTags[k] = v
+		}
+	}
 }
 
 // NewNeo4jExecutor creates and initializes a new Neo4jExecutor.
@@ -34,16 +140,27 @@ type Neo4jExecutor struct {
 //   - username: The username for authentication.
 //   - password: The password for authentication.
 //   - dbName: The name of the database to connect to (e.g., "neo4j").
+//   - opts: Optional settings such as WithMaxBufferedRecords.
 //
 // Returns:
 //
 //	A pointer to the newly created Neo4jExecutor or an error if the driver creation fails.
-func NewNeo4jExecutor(uri, username, password, dbName string) (*Neo4jExecutor, error) {
-	driver, err := neo4j.NewDriverWithContext(uri, neo4j.BasicAuth(username, password, ""))
+func NewNeo4jExecutor(uri, username, password, dbName string, opts ...ExecutorOption) (*Neo4jExecutor, error) {
+	executor := &Neo4jExecutor{DBName: dbName}
+	for _, opt := range opts {
+		opt(executor)
+	}
+
+	driver, err := neo4j.NewDriverWithContext(uri, neo4j.BasicAuth(username, password, ""), func(c *config.Config) {
+		if executor.maxPoolSize > 0 {
+			c.MaxConnectionPoolSize = executor.maxPoolSize
+		}
+	})
 	if err != nil {
 		return nil, fmt.Errorf("could not create Neo4j driver: %w", err)
 	}
-	return &Neo4jExecutor{Driver: driver, DBName: dbName}, nil
+	executor.Driver = driver
+	return executor, nil
 }
 
 // Verify checks the connectivity to the Neo4j database by running a simple query.
@@ -55,10 +172,82 @@ func (e *Neo4jExecutor) Verify(ctx context.Context) error {
 	return e.Driver.VerifyConnectivity(ctx)
 }
 
+// ServerInfo returns the version, edition and protocol of the connected Neo4j server.
+// The information is fetched on the first successful call and cached afterwards.
+//
+// Returns:
+//
+//	The server information, or an error if it could not be retrieved.
+func (e *Neo4jExecutor) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	e.serverInfoMu.Lock()
+	defer e.serverInfoMu.Unlock()
+
+	if e.serverInfo != nil {
+		return e.serverInfo, nil
+	}
+
+	driverInfo, err := e.Driver.GetServerInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get server info: %w", err)
+	}
+
+	result, err := e.Run(ctx, "CALL dbms.components() YIELD name, versions, edition "+
+		"WHERE name = 'Neo4j Kernel' RETURN versions[0] AS version, edition", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get server version: %w", err)
+	}
+	if len(result.Records) == 0 {
+		return nil, fmt.Errorf("could not get server version: dbms.components() returned no kernel component")
+	}
+
+	record := result.Records[0]
+	rawVersion, _ := record.Get("version")
+	edition, _ := record.Get("edition")
+	versionStr, _ := rawVersion.(string)
+	version, err := features.ParseVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	protocol := driverInfo.ProtocolVersion()
+	editionStr, _ := edition.(string)
+
+	e.serverInfo = &ServerInfo{
+		Address:         driverInfo.Address(),
+		Agent:           driverInfo.Agent(),
+		Version:         version,
+		Edition:         editionStr,
+		ProtocolVersion: fmt.Sprintf("%d.%d", protocol.Major, protocol.Minor),
+	}
+	return e.serverInfo, nil
+}
+
+// Supports reports whether the connected server supports the given feature. It relies on
+// the cached result of ServerInfo and returns false until ServerInfo has succeeded once,
+// so call ServerInfo during startup (e.g., right after Verify) before branching on it.
+func (e *Neo4jExecutor) Supports(f features.Feature) bool {
+	e.serverInfoMu.Lock()
+	defer e.serverInfoMu.Unlock()
+	return e.serverInfo != nil && e.serverInfo.Supports(f)
+}
+
+// RequireFeature returns a *features.UnsupportedError if the connected server does not
+// support the given feature, fetching the server information first if needed.
+func (e *Neo4jExecutor) RequireFeature(ctx context.Context, f features.Feature) error {
+	info, err := e.ServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	return features.Require(f, info.Version)
+}
+
 // Run executes a Cypher query using the modern ExecuteQuery function, which handles
 // session and transaction management automatically for robust and simple execution.
 // This function is suitable for both read and write operations.
 //
+// Records are buffered in memory. If the executor has a MaxBufferedRecords limit (or the
+// context carries an override set with WithRecordLimit), the query is aborted as soon as
+// the limit is exceeded and a *ResultLimitError is returned.
+//
 // Parameters:
 //   - ctx: The context for the query execution.
 //   - query: The Cypher query string to execute.
@@ -66,21 +255,511 @@ func (e *Neo4jExecutor) Verify(ctx context.Context) error {
 //
 // Returns:
 //
-//	An EagerResult containing all buffered records from the query, or an error if
-//	the execution fails.
-func (e *Neo4jExecutor) Run(ctx context.Context, query string, params map[string]interface{}) (*neo4j.EagerResult, error) {
+//	A ResultSet containing all buffered records from the query, or an error if the
+//	execution fails. Server errors are reported as *DBError.
+func (e *Neo4jExecutor) Run(ctx context.Context, query string, params map[string]interface{}) (*ResultSet, error) {
+	return e.RunWithConfig(ctx, query, params, CallConfig{})
+}
+
+// RunWithConfig executes a query like Run, applying per-call settings: a database
+// override, a transaction timeout and routing to cluster readers. It makes Neo4jExecutor
+// a ConfigurableRunner.
+func (e *Neo4jExecutor) RunWithConfig(ctx context.Context, query string, params map[string]interface{}, config CallConfig) (_ *ResultSet, err error) {
+	e.acquireConn()
+	defer func() { e.releaseConn(ctx, err) }()
+
+	limit := e.MaxBufferedRecords
+	if override, ok := recordLimitFromContext(ctx); ok {
+		limit = override
+	}
+
+	database := e.DBName
+	if config.Database != "" {
+		database = config.Database
+	}
+	configurers := []neo4j.ExecuteQueryConfigurationOption{neo4j.ExecuteQueryWithDatabase(database)}
+	if config.ReadOnly {
+		configurers = append(configurers, neo4j.ExecuteQueryWithReadersRouting())
+	}
+	var txConfig []func(*neo4j.TransactionConfig)
+	if metadata := e.txMetadata(ctx); metadata != nil {
+		txConfig = append(txConfig, neo4j.WithTxMetadata(metadata))
+	}
+	if config.Timeout > 0 {
+		txConfig = append(txConfig, neo4j.WithTxTimeout(config.Timeout))
+	}
+	if len(txConfig) > 0 {
+		configurers = append(configurers, neo4j.ExecuteQueryWithTransactionConfig(txConfig...))
+	}
+
 	result, err := neo4j.ExecuteQuery(
 		ctx,
 		e.Driver,
 		query,
-		params,
-		neo4j.EagerResultTransformer, // Buffers all results in memory before returning.
-		neo4j.ExecuteQueryWithDatabase(e.DBName),
+		toDriverParams(params),
+		newLimitedResultTransformer(limit, query), // Buffers results in memory, up to the limit.
+		configurers...,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("error executing neo4j query: %w", err)
+		return nil, fmt.Errorf("error executing neo4j query: %w", fromDriverError(err))
+	}
+
+	return fromEagerResult(result), nil
+}
+
+// Stream executes a Cypher query in an auto-commit transaction of its own session and
+// returns a cursor over its records, which are fetched from the server in batches as the
+// cursor advances instead of being buffered. It makes Neo4jExecutor a StreamRunner.
+//
+// The session, and the pooled connection it holds, stay open until the stream is closed,
+// so callers must always call Close. Since records are not buffered, MaxBufferedRecords
+// does not apply. Unlike Run, the query is not retried on transient errors.
+func (e *Neo4jExecutor) Stream(ctx context.Context, query string, params map[string]interface{}) (RecordStream, error) {
+	return e.StreamWithConfig(ctx, query, params, CallConfig{})
+}
+
+// StreamWithConfig streams a query like Stream, applying per-call settings: a database
+// override, a transaction timeout and routing to cluster readers. It makes Neo4jExecutor
+// a ConfigurableStreamRunner.
+func (e *Neo4jExecutor) StreamWithConfig(ctx context.Context, query string, params map[string]interface{}, config CallConfig) (RecordStream, error) {
+	e.acquireConn()
+	sessionConfig := neo4j.SessionConfig{DatabaseName: e.DBName}
+	if config.Database != "" {
+		sessionConfig.DatabaseName = config.Database
+	}
+	if config.ReadOnly {
+		sessionConfig.AccessMode = neo4j.AccessModeRead
+	}
+	session := e.Driver.NewSession(ctx, sessionConfig)
+
+	var configurers []func(*neo4j.TransactionConfig)
+	if metadata := e.txMetadata(ctx); metadata != nil {
+		configurers = append(configurers, neo4j.WithTxMetadata(metadata))
+	}
+	if config.Timeout > 0 {
+		configurers = append(configurers, neo4j.WithTxTimeout(config.Timeout))
+	}
+	result, err := session.Run(ctx, query, toDriverParams(params), configurers...)
+	if err != nil {
+		_ = session.Close(ctx)
+		e.releaseConn(ctx, err)
+		return nil, fmt.Errorf("error executing neo4j query: %w", fromDriverError(err))
 	}
+	return &driverStream{executor: e, session: session, result: result}, nil
+}
 
-	return result, nil
+// RunInTransaction calls fn with a DBRunner whose queries all run in one write
+// transaction on the executor's database, committed when fn returns nil and rolled back
+// when it returns an error. It makes Neo4jExecutor a TransactionRunner.
+//
+// The transaction is retried on transient errors, so fn may be called more than once and
+// must not have side effects outside the database. MaxBufferedRecords and WithRecordLimit
+// apply to each query run in the transaction.
+func (e *Neo4jExecutor) RunInTransaction(ctx context.Context, fn func(ctx context.Context, tx DBRunner) error) (err error) {
+	e.acquireConn()
+	defer func() { e.releaseConn(ctx, err) }()
+
+	limit := e.MaxBufferedRecords
+	if override, ok := recordLimitFromContext(ctx); ok {
+		limit = override
+	}
+	var configurers []func(*neo4j.TransactionConfig)
+	if metadata := e.txMetadata(ctx); metadata != nil {
+		configurers = append(configurers, neo4j.WithTxMetadata(metadata))
+	}
+
+	session := e.Driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: e.DBName})
+	defer session.Close(ctx)
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, fn(ctx, &transactionRunner{executor: e, tx: tx, limit: limit})
+	}, configurers...)
+	return fromDriverError(err)
+}
+
+// transactionRunner is the DBRunner handed to the function given to RunInTransaction.
+type transactionRunner struct {
+	executor *Neo4jExecutor
+	tx       neo4j.ManagedTransaction
+	limit    int
+}
+
+// Run executes the query in the transaction and buffers its records, up to the limit.
+func (t *transactionRunner) Run(ctx context.Context, query string, params map[string]interface{}) (*ResultSet, error) {
+	result, err := t.tx.Run(ctx, query, toDriverParams(params))
+	if err != nil {
+		return nil, fmt.Errorf("error executing neo4j query: %w", fromDriverError(err))
+	}
+	keys, err := result.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("error executing neo4j query: %w", fromDriverError(err))
+	}
+	transformer := newLimitedResultTransformer(t.limit, query)()
+	for result.Next(ctx) {
+		if err := transformer.Accept(result.Record()); err != nil {
+			return nil, err
+		}
+	}
+	summary, err := result.Consume(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing neo4j query: %w", fromDriverError(err))
+	}
+	eager, err := transformer.Complete(keys, summary)
+	if err != nil {
+		return nil, err
+	}
+	return fromEagerResult(eager), nil
+}
+
+// RequireFeature checks the feature against the executor the transaction belongs to.
+func (t *transactionRunner) RequireFeature(ctx context.Context, f features.Feature) error {
+	return t.executor.RequireFeature(ctx, f)
+}
+
+// driverStream is the RecordStream returned by Neo4jExecutor.Stream.
+type driverStream struct {
+	executor *Neo4jExecutor
+	session  neo4j.SessionWithContext
+	result   neo4j.ResultWithContext
+	closed   bool
+	// err is the error that ended the stream, if any, reported when the connection is
+	// released.
+	err error
+}
+
+// Next fetches the next record, or returns io.EOF once the result is exhausted.
+func (s *driverStream) Next(ctx context.Context) (*Record, error) {
+	if s.closed {
+		return nil, io.EOF
+	}
+	if s.result.Next(ctx) {
+		record := s.result.Record()
+		values := make([]any, len(record.Values))
+		for i, value := range record.Values {
+			values[i] = fromDriverValue(value)
+		}
+		return &Record{Keys: record.Keys, Values: values}, nil
+	}
+	if err := s.result.Err(); err != nil {
+		s.err = fmt.Errorf("error streaming neo4j query: %w", fromDriverError(err))
+		return nil, s.err
+	}
+	if err := ctx.Err(); err != nil {
+		s.err = err
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Close discards the unread records and closes the session. It is safe to call repeatedly.
+func (s *driverStream) Close(ctx context.Context) error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.session.Close(ctx)
+	s.executor.releaseConn(ctx, errors.Join(s.err, err))
+	return err
+}
+
+// recordLimitKey is the context key under which a per-call record limit is stored.
+type recordLimitKey struct{}
+
+// WithRecordLimit returns a copy of ctx that overrides the executor's MaxBufferedRecords
+// for every query run with it. Use it for calls that legitimately need to buffer more
+// records than the executor-wide guard allows. A value of zero or less disables the guard.
+func WithRecordLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, recordLimitKey{}, n)
+}
+
+// recordLimitFromContext extracts a per-call record limit set with WithRecordLimit.
+func recordLimitFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(recordLimitKey{}).(int)
+	return n, ok
+}
+
+// queryTagsKey is the context key under which per-call query tags are stored.
+type queryTagsKey struct{}
+
+// WithQueryTag returns a copy of ctx whose queries carry the given tags as transaction
+// metadata, for example the endpoint being served:
+//
+//	ctx = neopersist.WithQueryTag(ctx, map[string]string{"endpoint": "POST /invoices"})
+//
+// Tags accumulate: calling WithQueryTag on a context that already has tags merges them,
+// with the new values winning. Only the tags themselves are sent, never query parameters,
+// so avoid putting sensitive values in them.
+func WithQueryTag(ctx context.Context, tags map[string]string) context.Context {
+	existing, _ := ctx.Value(queryTagsKey{}).(map[string]string)
+	merged := make(map[string]string, len(existing)+len(tags))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, queryTagsKey{}, merged)
+}
+
+// txMetadata merges the executor's default tags with the context's tags into transaction
+// metadata, returning nil if there are none.
+func (e *Neo4jExecutor) txMetadata(ctx context.Context) map[string]any {
+	tags, _ := ctx.Value(queryTagsKey{}).(map[string]string)
+	if len(e.defaultQueryTags) == 0 && len(tags) == 0 {
+		return nil
+	}
+	metadata := make(map[string]any, len(e.defaultQueryTags)+len(tags))
+	for k, v := range e.defaultQueryTags {
+		metadata[k] = v
+	}
+	for k, v := range tags {
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// limitedResultTransformer is a neo4j.ResultTransformer that buffers records like the
+// driver's EagerResultTransformer, but aborts once more than limit records are received.
+type limitedResultTransformer struct {
+	limit   int
+	query   string
+	records []*neo4j.Record
+}
+
+// newLimitedResultTransformer returns a transformer factory suitable for neo4j.ExecuteQuery.
+// A new transformer is created per attempt, since ExecuteQuery may retry the transaction.
+func newLimitedResultTransformer(limit int, query string) func() neo4j.ResultTransformer[*neo4j.EagerResult] {
+	return func() neo4j.ResultTransformer[*neo4j.EagerResult] {
+		return &limitedResultTransformer{limit: limit, query: query}
+	}
+}
+
+// Accept buffers a record, failing if doing so would exceed the limit.
+func (t *limitedResultTransformer) Accept(record *neo4j.Record) error {
+	if t.limit > 0 && len(t.records) >= t.limit {
+		return &ResultLimitError{Limit: t.limit, Query: t.query}
+	}
+	t.records = append(t.records, record)
+	return nil
+}
+
+// Complete assembles the buffered records into an EagerResult.
+func (t *limitedResultTransformer) Complete(keys []string, summary neo4j.ResultSummary) (*neo4j.EagerResult, error) {
+	return &neo4j.EagerResult{Keys: keys, Records: t.records, Summary: summary}, nil
+}
+
+//---
+
+// The functions below are the only place where driver types are converted to and from the
+// package's own types.
+
+// toDriverParams converts query parameters into driver values, such as a Point into a
+// dbtype.Point2D. The map is copied only if a value needs converting.
+func toDriverParams(params map[string]interface{}) map[string]interface{} {
+	converted, _ := toDriverMap(params)
+	return converted
+}
+
+// toDriverMap converts the values of a map, reporting whether anything was converted.
+func toDriverMap(values map[string]any) (map[string]any, bool) {
+	var out map[string]any
+	for k, v := range values {
+		dv, changed := toDriverValue(v)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]any, len(values))
+			for k2, v2 := range values {
+				out[k2] = v2
+			}
+		}
+		out[k] = dv
+	}
+	if out == nil {
+		return values, false
+	}
+	return out, true
+}
+
+// toDriverValue converts a single parameter value, recursing into lists and maps. The
+// boolean reports whether anything was converted.
+func toDriverValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case Point:
+		return dbtype.Point2D{X: v.X, Y: v.Y, SpatialRefId: v.SRID}, true
+	case *Point:
+		if v == nil {
+			return nil, true
+		}
+		return dbtype.Point2D{X: v.X, Y: v.Y, SpatialRefId: v.SRID}, true
+	case Point3D:
+		return dbtype.Point3D{X: v.X, Y: v.Y, Z: v.Z, SpatialRefId: v.SRID}, true
+	case *Point3D:
+		if v == nil {
+			return nil, true
+		}
+		return dbtype.Point3D{X: v.X, Y: v.Y, Z: v.Z, SpatialRefId: v.SRID}, true
+	case LocalDateTime:
+		return dbtype.LocalDateTime(v), true
+	case LocalDate:
+		return dbtype.Date(v), true
+	case LocalTime:
+		return dbtype.LocalTime(v), true
+	case Duration:
+		return dbtype.Duration{Months: v.Months, Days: v.Days, Seconds: v.Seconds, Nanos: v.Nanos}, true
+	case []any:
+		var out []any
+		for i, item := range v {
+			dv, changed := toDriverValue(item)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = append([]any(nil), v...)
+			}
+			out[i] = dv
+		}
+		if out == nil {
+			return value, false
+		}
+		return out, true
+	case map[string]any:
+		return toDriverMap(v)
+	}
+	return value, false
+}
+
+// fromEagerResult converts a buffered driver result into a ResultSet.
+func fromEagerResult(result *neo4j.EagerResult) *ResultSet {
+	if result == nil {
+		return &ResultSet{}
+	}
+	set := &ResultSet{Keys: result.Keys, Records: make([]*Record, len(result.Records))}
+	for i, record := range result.Records {
+		values := make([]any, len(record.Values))
+		for j, value := range record.Values {
+			values[j] = fromDriverValue(value)
+		}
+		set.Records[i] = &Record{Keys: record.Keys, Values: values}
+	}
+	if result.Summary != nil {
+		counters := result.Summary.Counters()
+		set.Counters = Counters{
+			NodesCreated:         int64(counters.NodesCreated()),
+			NodesDeleted:         int64(counters.NodesDeleted()),
+			RelationshipsCreated: int64(counters.RelationshipsCreated()),
+			RelationshipsDeleted: int64(counters.RelationshipsDeleted()),
+			PropertiesSet:        int64(counters.PropertiesSet()),
+			LabelsAdded:          int64(counters.LabelsAdded()),
+			LabelsRemoved:        int64(counters.LabelsRemoved()),
+			ConstraintsAdded:     int64(counters.ConstraintsAdded()),
+			ConstraintsRemoved:   int64(counters.ConstraintsRemoved()),
+			IndexesAdded:         int64(counters.IndexesAdded()),
+			IndexesRemoved:       int64(counters.IndexesRemoved()),
+		}
+	}
+	return set
+}
+
+// fromDriverValue converts a driver value into the package's types, recursing into lists
+// and maps. Zoned temporal values become time.Time, dates, local times and local date
+// times become LocalDate, LocalTime and LocalDateTime, durations become Duration, and 2D
+// and 3D spatial values become Point and Point3D.
+func fromDriverValue(value any) any {
+	switch v := value.(type) {
+	case dbtype.Node:
+		return fromDriverNode(v)
+	case dbtype.Relationship:
+		return fromDriverRelationship(v)
+	case dbtype.Path:
+		path := Path{Nodes: make([]Node, len(v.Nodes)), Relationships: make([]Relationship, len(v.Relationships))}
+		for i, node := range v.Nodes {
+			path.Nodes[i] = fromDriverNode(node)
+		}
+		for i, rel := range v.Relationships {
+			path.Relationships[i] = fromDriverRelationship(rel)
+		}
+		return path
+	case dbtype.Point2D:
+		return Point{X: v.X, Y: v.Y, SRID: v.SpatialRefId}
+	case dbtype.Point3D:
+		return Point3D{X: v.X, Y: v.Y, Z: v.Z, SRID: v.SpatialRefId}
+	case dbtype.Date:
+		return LocalDate(v)
+	case dbtype.LocalDateTime:
+		return LocalDateTime(v)
+	case dbtype.LocalTime:
+		return LocalTime(v)
+	case dbtype.Time:
+		return v.Time()
+	case dbtype.Duration:
+		return Duration{Months: v.Months, Days: v.Days, Seconds: v.Seconds, Nanos: v.Nanos}
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = fromDriverValue(item)
+		}
+		return out
+	case map[string]any:
+		return fromDriverProps(v)
+	}
+	return value
+}
+
+// fromDriverProps converts the values of a property map.
+func fromDriverProps(props map[string]any) map[string]any {
+	if props == nil {
+		return nil
+	}
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		out[k] = fromDriverValue(v)
+	}
+	return out
+}
+
+// fromDriverNode converts a driver node.
+func fromDriverNode(n dbtype.Node) Node {
+	return Node{ElementID: n.ElementId, Labels: n.Labels, Props: fromDriverProps(n.Props)}
+}
+
+// fromDriverRelationship converts a driver relationship.
+func fromDriverRelationship(r dbtype.Relationship) Relationship {
+	return Relationship{
+		ElementID:      r.ElementId,
+		StartElementID: r.StartElementId,
+		EndElementID:   r.EndElementId,
+		Type:           r.Type,
+		Props:          fromDriverProps(r.Props),
+	}
+}
+
+// driverErrorDetails returns the server status code of err, if it is or wraps a server
+// error reported by the driver, and the errors the driver's wrapper types hold. Those
+// types do not implement Unwrap, so their causes cannot be reached with errors.As.
+func driverErrorDetails(err error) (code string, causes []error) {
+	var neoErr *neo4j.Neo4jError
+	if errors.As(err, &neoErr) {
+		code = neoErr.Code
+	}
+	var connectivity *neo4j.ConnectivityError
+	if errors.As(err, &connectivity) && connectivity.Inner != nil {
+		causes = append(causes, connectivity.Inner)
+	}
+	var limit *neo4j.TransactionExecutionLimit
+	if errors.As(err, &limit) {
+		causes = append(causes, limit.Errors...)
+	}
+	return code, causes
+}
+
+// fromDriverError wraps a server error reported by the driver into a *DBError, keeping the
+// original reachable through errors.As. Other errors are returned unchanged.
+func fromDriverError(err error) error {
+	var neoErr *neo4j.Neo4jError
+	if !errors.As(err, &neoErr) {
+		return err
+	}
+	return &DBError{Code: neoErr.Code, Message: neoErr.Msg, cause: err}
 }
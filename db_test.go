@@ -0,0 +1,95 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+func TestDriverErrorDetails(t *testing.T) {
+	timedOut := &neo4j.Neo4jError{Code: "Neo.ClientError.Transaction.TransactionTimedOutClientConfiguration"}
+	connectivity := &neo4j.ConnectivityError{Inner: netTimeout{}}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   string
+		wantCauses []error
+	}{
+		{name: "server error", err: timedOut, wantCode: timedOut.Code},
+		{name: "connectivity error", err: connectivity, wantCauses: []error{netTimeout{}}},
+		{name: "retries", err: &neo4j.TransactionExecutionLimit{Errors: []error{timedOut, connectivity}}, wantCauses: []error{timedOut, connectivity}},
+		{name: "other error", err: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, causes := driverErrorDetails(tt.err)
+			if code != tt.wantCode || !reflect.DeepEqual(causes, tt.wantCauses) {
+				t.Errorf("driverErrorDetails(%v) = %q, %v, want %q, %v", tt.err, code, causes, tt.wantCode, tt.wantCauses)
+			}
+		})
+	}
+}
+
+func TestIsTimeoutFollowsDriverErrorCauses(t *testing.T) {
+	ctx := context.Background()
+	retried := &neo4j.TransactionExecutionLimit{Errors: []error{
+		errors.New("first"),
+		&neo4j.ConnectivityError{Inner: context.DeadlineExceeded},
+	}}
+	if !isTimeout(ctx, retried) {
+		t.Errorf("isTimeout(%v) = false, want true for retries ending in a timeout", retried)
+	}
+	if !isTimeout(ctx, &neo4j.Neo4jError{Code: "Neo.ClientError.Transaction.TransactionTimedOut"}) {
+		t.Error("isTimeout = false for a server timeout reported by the driver")
+	}
+	if isTimeout(ctx, &neo4j.ConnectivityError{Inner: errors.New("connection refused")}) {
+		t.Error("isTimeout = true for a connectivity error without a timeout")
+	}
+}
+
+func TestDurationsRoundTripThroughTheDriver(t *testing.T) {
+	d := Duration{Months: 14, Days: -3, Seconds: 59, Nanos: 999}
+
+	converted, changed := toDriverValue(d)
+	if !changed {
+		t.Fatal("toDriverValue did not convert a Duration")
+	}
+	if _, ok := converted.(dbtype.Duration); !ok {
+		t.Fatalf("toDriverValue(Duration) = %T, want dbtype.Duration", converted)
+	}
+	if got := fromDriverValue(converted); got != d {
+		t.Errorf("round trip = %#v, want %#v", got, d)
+	}
+}
+
+func TestTemporalAndSpatialValuesKeepTheirDriverTypes(t *testing.T) {
+	at := time.Date(2024, 3, 9, 14, 30, 5, 7, time.UTC)
+	tests := []struct {
+		name   string
+		value  any
+		driver any
+	}{
+		{name: "date", value: LocalDate(at), driver: dbtype.Date(at)},
+		{name: "local time", value: LocalTime(at), driver: dbtype.LocalTime(at)},
+		{name: "local date time", value: LocalDateTime(at), driver: dbtype.LocalDateTime(at)},
+		{name: "2D point", value: CartesianPoint(1, 2), driver: dbtype.Point2D{X: 1, Y: 2, SpatialRefId: SRIDCartesian}},
+		{name: "3D point", value: GeoPoint3D(48.85, 2.35, 35), driver: dbtype.Point3D{X: 2.35, Y: 48.85, Z: 35, SpatialRefId: SRIDWGS843D}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, changed := toDriverValue(tt.value)
+			if !changed || converted != tt.driver {
+				t.Fatalf("toDriverValue(%#v) = %#v, want %#v", tt.value, converted, tt.driver)
+			}
+			if got := fromDriverValue(tt.driver); got != tt.value {
+				t.Errorf("fromDriverValue(%#v) = %#v, want %#v", tt.driver, got, tt.value)
+			}
+		})
+	}
+}
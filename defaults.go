@@ -0,0 +1,94 @@
+package neopersist
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// parseDefault parses the value of a `default:` tag component into the type of the field
+// fieldName, so that a malformed default fails when the metadata is parsed. String,
+// boolean and numeric fields, and pointers to them, are supported.
+func parseDefault(fieldName string, typ reflect.Type, raw string) (reflect.Value, error) {
+	value := reflect.New(typ).Elem()
+	target := value
+	if typ.Kind() == reflect.Pointer {
+		value.Set(reflect.New(typ.Elem()))
+		target = value.Elem()
+	}
+
+	var err error
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(raw)
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(raw, 10, target.Type().Bits())
+		target.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(raw, 10, target.Type().Bits())
+		target.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(raw, target.Type().Bits())
+		target.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("field %s has a 'default' tag component, which only string, boolean and numeric fields support, not %s", fieldName, typ)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("field %s has an invalid default '%s' for type %s: %w", fieldName, raw, typ, err)
+	}
+	return value, nil
+}
+
+// createDefaults returns the defaults of the fields of val tagged `default:` that hold their
+// zero value, keyed by property name. They are written only when the node is created.
+func (m *entityMetadata) createDefaults(val reflect.Value) (map[string]interface{}, error) {
+	defaults := make(map[string]interface{}, len(m.Defaults))
+	for fieldName, value := range m.Defaults {
+		if !isZeroField(m.field(val, fieldName)) {
+			continue
+		}
+		prop, err := m.propertyValue(fieldName, value)
+		if err != nil {
+			return nil, err
+		}
+		defaults[m.Mappings[fieldName]] = prop
+	}
+	return defaults, nil
+}
+
+// applyDefaults sets the fields of val tagged `default:` that hold their zero value to their
+// default, so that an entity reflects what was written when its node was created.
+func (m *entityMetadata) applyDefaults(val reflect.Value) {
+	for fieldName, value := range m.Defaults {
+		field := m.field(val, fieldName)
+		if !isZeroField(field) {
+			continue
+		}
+		// Pointer defaults are copied, so that entities never share the parsed value.
+		if value.Kind() == reflect.Pointer {
+			elem := reflect.New(value.Type().Elem())
+			elem.Elem().Set(value.Elem())
+			value = elem
+		}
+		field.Set(value)
+	}
+}
+
+// withCreateDefaults adds `ON CREATE SET n += $createDefaults` after the MERGE clause that
+// starts query, if there are defaults to write.
+func withCreateDefaults(query string, params map[string]interface{}, defaults map[string]interface{}) string {
+	if len(defaults) == 0 {
+		return query
+	}
+	params["createDefaults"] = defaults
+	merge, rest, _ := strings.Cut(query, "\n")
+	return merge + "\nON CREATE SET n += $createDefaults\n" + rest
+}
@@ -0,0 +1,158 @@
+package neopersist_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Subscription has properties with defaults written when its node is created.
+type Subscription struct {
+	ID    string `crud:"pk,property:id"`
+	Plan  string `crud:"property:plan,default:free"`
+	Seats *int64 `crud:"property:seats,default:1"`
+}
+
+func newSubscriptionRepo(t *testing.T) (*neopersist.Repository[Subscription], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Subscription](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	return repo, runner
+}
+
+// created answers a write as having created count nodes, with a node bound to n.
+func created(count int64) func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+	return func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		result := nodeResult(neopersist.Node{Labels: []string{"Subscription"}, Props: map[string]any{"id": "s1"}})
+		result.Counters.NodesCreated = count
+		return result, nil
+	}
+}
+
+// derefed returns a copy of props with pointers replaced by the values they point to, as
+// the driver writes them.
+func derefed(props any) map[string]interface{} {
+	copied := map[string]interface{}{}
+	for key, value := range props.(map[string]interface{}) {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && !v.IsNil() {
+			value = v.Elem().Interface()
+		}
+		copied[key] = value
+	}
+	return copied
+}
+
+func TestInvalidDefaultsFailWhenParsingTags(t *testing.T) {
+	type BadNumber struct {
+		ID    string `crud:"pk,property:id"`
+		Seats int64  `crud:"property:seats,default:many"`
+	}
+	type BadType struct {
+		ID      string    `crud:"pk,property:id"`
+		Renewal time.Time `crud:"property:renewal,default:2024-01-01"`
+	}
+
+	_, err := neopersist.NewRepository[BadNumber](neopersisttest.NewFakeRunner())
+	if err == nil || !strings.Contains(err.Error(), "field Seats has an invalid default 'many' for type int64") {
+		t.Errorf("NewRepository error = %v, want the invalid default", err)
+	}
+	_, err = neopersist.NewRepository[BadType](neopersisttest.NewFakeRunner())
+	if err == nil || !strings.Contains(err.Error(), "only string, boolean and numeric fields support") {
+		t.Errorf("NewRepository error = %v, want the unsupported type", err)
+	}
+}
+
+func TestSaveWritesDefaultsOnlyOnCreate(t *testing.T) {
+	repo, runner := newSubscriptionRepo(t)
+	runner.Respond = created(1)
+
+	subscription := &Subscription{ID: "s1"}
+	if err := repo.Save(context.Background(), subscription); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	call := runner.Calls()[0]
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MERGE (n:Subscription {id: $id}) ON CREATE SET n += $createDefaults RETURN n",
+	})
+	if defaults := derefed(call.Params["createDefaults"]); !reflect.DeepEqual(defaults, map[string]interface{}{"plan": "free", "seats": int64(1)}) {
+		t.Errorf("createDefaults = %v, want every default", defaults)
+	}
+	if strings.Contains(call.Query, "n.plan =") || strings.Contains(call.Query, "n.seats =") {
+		t.Errorf("zero fields with defaults are written on match too: %s", call.Query)
+	}
+	if subscription.Plan != "free" || subscription.Seats == nil || *subscription.Seats != 1 {
+		t.Errorf("entity = %+v, want the defaults written on creation", subscription)
+	}
+}
+
+func TestSaveLeavesDefaultsOfExistingNodesAlone(t *testing.T) {
+	repo, runner := newSubscriptionRepo(t)
+	runner.Respond = created(0)
+
+	seats := int64(5)
+	subscription := &Subscription{ID: "s1", Seats: &seats}
+	if err := repo.Save(context.Background(), subscription); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// Seats is set, so it is written on match too and is no default.
+	if value := setValue(t, runner.Calls()[0], "seats"); value != &seats {
+		t.Errorf("seats saved as %#v, want 5", value)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Params: map[string]any{"createDefaults": map[string]interface{}{"plan": "free"}},
+	})
+	if subscription.Plan != "" {
+		t.Errorf("Plan = %q, want it unchanged since no node was created", subscription.Plan)
+	}
+}
+
+func TestCreateWritesDefaults(t *testing.T) {
+	repo, runner := newSubscriptionRepo(t)
+	runner.Respond = created(1)
+
+	subscription := &Subscription{ID: "s1", Plan: "pro"}
+	if err := repo.Create(context.Background(), subscription); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if props := derefed(runner.Calls()[0].Params["props"]); !reflect.DeepEqual(props, map[string]interface{}{"plan": "pro", "seats": int64(1)}) {
+		t.Errorf("props = %v, want the given plan and the seats default", props)
+	}
+	if subscription.Plan != "pro" || subscription.Seats == nil || *subscription.Seats != 1 {
+		t.Errorf("entity = %+v, want the seats default and the given plan", subscription)
+	}
+}
+
+func TestUpsertAllWritesDefaultsOnlyOnCreate(t *testing.T) {
+	tests := map[neopersist.ConflictStrategy]string{
+		neopersist.Overwrite:  "MERGE (n:Subscription {id: row.pk}) ON CREATE SET n += row.defaults SET n += row.props",
+		neopersist.CreateOnly: "MERGE (n:Subscription {id: row.pk}) ON CREATE SET n += row.props, n += row.defaults",
+		neopersist.UpdateOnly: "MATCH (n:Subscription {id: row.pk}) SET n += row.props",
+	}
+	for strategy, write := range tests {
+		t.Run(strategy.String(), func(t *testing.T) {
+			repo, runner := newSubscriptionRepo(t)
+			if _, _, err := repo.UpsertAll(context.Background(), []*Subscription{{ID: "s1"}}, strategy); err != nil {
+				t.Fatalf("UpsertAll: %v", err)
+			}
+			neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+				Query: "UNWIND $rows AS row " + write + " RETURN count(n) AS total",
+			})
+			row := runner.Calls()[0].Params["rows"].([]map[string]interface{})[0]
+			if len(row["props"].(map[string]interface{})) != 0 {
+				t.Errorf("props = %v, want the zero fields with defaults left out", row["props"])
+			}
+			defaults := derefed(row["defaults"])
+			if defaults["plan"] != "free" || defaults["seats"] != int64(1) {
+				t.Errorf("defaults = %v", defaults)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeleteEstimate reports what a destructive operation would remove, as computed by a
+// dry run. Relationship counts include every relationship attached to a deleted node,
+// since nodes are always removed with DETACH DELETE.
+type DeleteEstimate struct {
+	// Nodes is the total number of nodes that would be deleted.
+	Nodes int64
+	// Relationships is the total number of relationships that would be deleted.
+	Relationships int64
+	// NodesByLabel breaks Nodes down by label. A node with several labels is counted
+	// once under each of them, so the values may add up to more than Nodes.
+	NodesByLabel map[string]int64
+	// RelationshipsByType breaks Relationships down by relationship type.
+	RelationshipsByType map[string]int64
+}
+
+// deletePlan describes the nodes, or relationships, removed by a destructive operation.
+// The same plan renders both the real delete query and its dry-run estimate, so the
+// numbers reported by an estimate cannot drift from what the delete actually touches.
+type deletePlan struct {
+	// match holds the MATCH (and optional WHERE) clauses that bind the elements to delete.
+	match string
+	// alias is the variable bound to the elements to delete.
+	alias string
+	// params are the query parameters referenced by match.
+	params map[string]interface{}
+	// relationships is set when alias is bound to relationships, which are removed with
+	// DELETE and leave their end nodes in place, instead of nodes removed with DETACH DELETE.
+	relationships bool
+}
+
+// deleteClause renders the clause removing the elements bound to the plan's alias.
+func (p *deletePlan) deleteClause() string {
+	if p.relationships {
+		return "DELETE " + p.alias
+	}
+	return "DETACH DELETE " + p.alias
+}
+
+// deleteQuery renders the destructive query for the plan.
+func (p *deletePlan) deleteQuery() string {
+	return fmt.Sprintf("%s\n%s", p.match, p.deleteClause())
+}
+
+// deleted reads the number of elements the plan removed from a result's summary counters.
+func (p *deletePlan) deleted(result *ResultSet) int64 {
+	if p.relationships {
+		if result == nil {
+			return 0
+		}
+		return result.Counters.RelationshipsDeleted
+	}
+	return nodesDeleted(result)
+}
+
+// execute runs the plan's delete query and returns the number of nodes, or relationships,
+// deleted, as reported by the result summary. With a positive batchSize the elements are
+// deleted in repeated statements of at most batchSize elements until none are left.
+//
+// Batching deliberately avoids CALL { ... } IN TRANSACTIONS: Neo4jExecutor runs every
+// query in a managed transaction, where the server rejects it.
+func (p *deletePlan) execute(ctx context.Context, runner DBRunner, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		result, err := runner.Run(ctx, p.deleteQuery(), p.params)
+		if err != nil {
+			return 0, err
+		}
+		return p.deleted(result), nil
+	}
+
+	query := fmt.Sprintf("%s\nWITH %s LIMIT $deleteBatchSize\n%s", p.match, p.alias, p.deleteClause())
+	params := make(map[string]interface{}, len(p.params)+1)
+	for k, v := range p.params {
+		params[k] = v
+	}
+	params["deleteBatchSize"] = batchSize
+
+	var total int64
+	for {
+		result, err := runner.Run(ctx, query, params)
+		if err != nil {
+			return total, fmt.Errorf("could not delete batch after %d element(s): %w", total, err)
+		}
+		deleted := p.deleted(result)
+		total += deleted
+		if deleted < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// nodesDeleted reads the number of deleted nodes from a result's summary counters.
+func nodesDeleted(result *ResultSet) int64 {
+	if result == nil {
+		return 0
+	}
+	return result.Counters.NodesDeleted
+}
+
+// estimate runs read-only counting queries over the plan's pattern instead of deleting.
+func (p *deletePlan) estimate(ctx context.Context, runner DBRunner) (*DeleteEstimate, error) {
+	estimate := &DeleteEstimate{
+		NodesByLabel:        make(map[string]int64),
+		RelationshipsByType: make(map[string]int64),
+	}
+	if p.relationships {
+		if err := p.countRelationships(ctx, runner, fmt.Sprintf("%s\nWITH DISTINCT %s AS rel", p.match, p.alias), estimate); err != nil {
+			return nil, err
+		}
+		return estimate, nil
+	}
+
+	// Group by the full label set so that multi-label nodes are counted once in the total.
+	nodeQuery := fmt.Sprintf("%s\nWITH DISTINCT %s\nRETURN labels(%s) AS labels, count(*) AS count",
+		p.match, p.alias, p.alias)
+	nodeResult, err := runner.Run(ctx, nodeQuery, p.params)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range nodeResult.Records {
+		labels, _ := record.Get("labels")
+		count, _ := record.Get("count")
+		n, _ := count.(int64)
+		estimate.Nodes += n
+		labelList, _ := labels.([]interface{})
+		for _, label := range labelList {
+			if name, ok := label.(string); ok {
+				estimate.NodesByLabel[name] += n
+			}
+		}
+	}
+
+	attached := fmt.Sprintf("%s\nWITH DISTINCT %s\nMATCH (%s)-[rel]-()\nWITH DISTINCT rel", p.match, p.alias, p.alias)
+	if err := p.countRelationships(ctx, runner, attached, estimate); err != nil {
+		return nil, err
+	}
+	return estimate, nil
+}
+
+// countRelationships completes a query binding distinct relationships to rel with a
+// count by type, and adds the counts to estimate.
+func (p *deletePlan) countRelationships(ctx context.Context, runner DBRunner, match string, estimate *DeleteEstimate) error {
+	relResult, err := runner.Run(ctx, match+"\nRETURN type(rel) AS type, count(*) AS count", p.params)
+	if err != nil {
+		return err
+	}
+	for _, record := range relResult.Records {
+		relType, _ := record.Get("type")
+		count, _ := record.Get("count")
+		n, _ := count.(int64)
+		name, _ := relType.(string)
+		estimate.Relationships += n
+		estimate.RelationshipsByType[name] += n
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+func TestDeleteRelationsWhereSharesItsPatternWithTheEstimate(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	ctx := context.Background()
+	conditions := []neopersist.Condition{neopersist.Field("Age").Lt(18)}
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 0 {
+			return &neopersist.ResultSet{
+				Keys:    []string{"type", "count"},
+				Records: []*neopersist.Record{neopersist.NewRecord("type", "FOLLOWS", "count", int64(3))},
+			}, nil
+		}
+		return &neopersist.ResultSet{Counters: neopersist.Counters{RelationshipsDeleted: 3}}, nil
+	}
+
+	estimate, err := repo.EstimateDeleteRelationsWhere(ctx, "FOLLOWS", conditions)
+	if err != nil {
+		t.Fatalf("EstimateDeleteRelationsWhere: %v", err)
+	}
+	deleted, err := repo.DeleteRelationsWhere(ctx, "FOLLOWS", conditions)
+	if err != nil {
+		t.Fatalf("DeleteRelationsWhere: %v", err)
+	}
+
+	match := "MATCH (n:User)-[rel:FOLLOWS]->() WHERE ((n.age < $age))"
+	neopersisttest.AssertCalls(t, runner,
+		neopersisttest.Expect{
+			Query:  match + " WITH DISTINCT rel AS rel RETURN type(rel) AS type, count(*) AS count",
+			Params: map[string]any{"age": 18},
+		},
+		neopersisttest.Expect{
+			Query:  match + " DELETE rel",
+			Params: map[string]any{"age": 18},
+		},
+	)
+	if estimate.Relationships != 3 || estimate.RelationshipsByType["FOLLOWS"] != 3 || estimate.Nodes != 0 {
+		t.Errorf("estimate = %+v, want 3 FOLLOWS relationships and no nodes", estimate)
+	}
+	if deleted != estimate.Relationships {
+		t.Errorf("deleted %d relationships, estimate said %d", deleted, estimate.Relationships)
+	}
+}
+
+func TestDeleteRelationsWhereRejectsInvalidRelationshipTypes(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	if _, err := repo.DeleteRelationsWhere(context.Background(), "FOLLOWS]->() DETACH DELETE n//", nil); err == nil {
+		t.Fatal("expected an error for an invalid relationship type")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestEstimateDeleteAllBreaksCountsDownByLabelAndType(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 0 {
+			return &neopersist.ResultSet{Records: []*neopersist.Record{
+				neopersist.NewRecord("labels", []interface{}{"User"}, "count", int64(4)),
+				neopersist.NewRecord("labels", []interface{}{"User", "Admin"}, "count", int64(1)),
+			}}, nil
+		}
+		return &neopersist.ResultSet{Records: []*neopersist.Record{
+			neopersist.NewRecord("type", "FOLLOWS", "count", int64(6)),
+			neopersist.NewRecord("type", "WROTE", "count", int64(2)),
+		}}, nil
+	}
+
+	estimate, err := repo.EstimateDeleteAll(context.Background())
+	if err != nil {
+		t.Fatalf("EstimateDeleteAll: %v", err)
+	}
+	if estimate.Nodes != 5 || estimate.NodesByLabel["User"] != 5 || estimate.NodesByLabel["Admin"] != 1 {
+		t.Errorf("node counts = %d %v, want 5 nodes, 5 User and 1 Admin", estimate.Nodes, estimate.NodesByLabel)
+	}
+	if estimate.Relationships != 8 || estimate.RelationshipsByType["FOLLOWS"] != 6 || estimate.RelationshipsByType["WROTE"] != 2 {
+		t.Errorf("relationship counts = %d %v, want 8, 6 FOLLOWS and 2 WROTE", estimate.Relationships, estimate.RelationshipsByType)
+	}
+	for _, call := range runner.Calls() {
+		if strings.Contains(call.Query, "DELETE") {
+			t.Errorf("estimate sent a destructive query: %s", call.Query)
+		}
+	}
+}
+
+func TestDeleteWhereWithPropertyFilterNeedsNoConfirmation(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Counters: neopersist.Counters{NodesDeleted: 2}}, nil
+	}
+	qb := gocypher.NewQueryBuilder().Match(gocypher.N("n", "User").WithProperties(map[string]interface{}{"age": 17}))
+
+	deleted, err := repo.DeleteWhere(context.Background(), qb)
+	if err != nil {
+		t.Fatalf("DeleteWhere: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+	neopersisttest.AssertCalls(t, runner, neopersisttest.Expect{
+		Query:  "MATCH (n:User {age: $age}) DETACH DELETE n",
+		Params: map[string]any{"age": 17},
+	})
+}
+
+func TestDeleteWhereRequiresTheExactEntityLabel(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	for _, label := range []string{"UserArchive", "Users", "Admin"} {
+		qb := gocypher.NewQueryBuilder().Match(gocypher.N("n", label).WithProperties(map[string]interface{}{"age": 17}))
+		if _, err := repo.DeleteWhere(context.Background(), qb); err == nil || !strings.Contains(err.Error(), "alias 'n' with label User") {
+			t.Errorf("DeleteWhere on (n:%s): err = %v, want a label error", label, err)
+		}
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+
+	qb := gocypher.NewQueryBuilder().Match(gocypher.N("n", "Admin:User").WithProperties(map[string]interface{}{"age": 17}))
+	if _, err := repo.DeleteWhere(context.Background(), qb); err != nil {
+		t.Errorf("DeleteWhere on (n:Admin:User): %v", err)
+	}
+}
+
+func TestDeleteWhereBatchesBuilderDetachDelete(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 0 {
+			return &neopersist.ResultSet{Counters: neopersist.Counters{NodesDeleted: 10}}, nil
+		}
+		return &neopersist.ResultSet{Counters: neopersist.Counters{NodesDeleted: 4}}, nil
+	}
+	filter := map[string]interface{}{"age": 17}
+	qb := gocypher.NewQueryBuilder().Match(gocypher.N("n", "User").WithProperties(filter)).DetachDelete("n")
+
+	deleted, err := repo.DeleteWhere(context.Background(), qb, neopersist.InBatchesOf(10))
+	if err != nil {
+		t.Fatalf("DeleteWhere: %v", err)
+	}
+	if deleted != 14 {
+		t.Errorf("deleted = %d, want 14", deleted)
+	}
+	batch := neopersisttest.Expect{
+		Query:  "MATCH (n:User {age: $age}) WITH n LIMIT $deleteBatchSize DETACH DELETE n",
+		Params: map[string]any{"age": 17, "deleteBatchSize": 10},
+	}
+	neopersisttest.AssertCalls(t, runner, batch, batch)
+
+	runner.Reset()
+	other := gocypher.NewQueryBuilder().Match(gocypher.N("n", "User").WithProperties(filter)).Delete("n")
+	if _, err := repo.DeleteWhere(context.Background(), other, neopersist.InBatchesOf(10)); err == nil {
+		t.Error("DeleteWhere batched a builder-supplied DELETE it cannot split")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestDeleteReportedReadsTheSummaryCounters(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		nodesDeleted int64
+		want         bool
+	}{
+		{name: "existing entity", nodesDeleted: 1, want: true},
+		{name: "missing entity", nodesDeleted: 0, want: false},
+	} {
+		repo, runner := newUserRepo(t)
+		runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+			return &neopersist.ResultSet{Counters: neopersist.Counters{NodesDeleted: tc.nodesDeleted, RelationshipsDeleted: 3}}, nil
+		}
+		deleted, err := repo.DeleteReported(context.Background(), "u1")
+		if err != nil {
+			t.Fatalf("%s: DeleteReported: %v", tc.name, err)
+		}
+		if deleted != tc.want {
+			t.Errorf("%s: deleted = %v, want %v", tc.name, deleted, tc.want)
+		}
+		neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+			QueryContains: []string{"MATCH (n:User {userId: $userId})", "DETACH DELETE n"},
+			Params:        map[string]any{"userId": "u1"},
+		})
+	}
+}
+
+func TestDeleteByIDsSumsTheDeletedNodesOfEveryChunk(t *testing.T) {
+	repo, runner := newUserRepo(t, neopersist.WithBatchSize(2))
+	runner.Respond = func(index int, _ string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		// The second chunk holds one key that does not exist.
+		return &neopersist.ResultSet{Counters: neopersist.Counters{NodesDeleted: int64(2 - index)}}, nil
+	}
+	deleted, err := repo.DeleteByIDs(context.Background(), []interface{}{"u1", "u2", "u3", "u4"})
+	if err != nil {
+		t.Fatalf("DeleteByIDs: %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("deleted = %d, want 3", deleted)
+	}
+	neopersisttest.AssertCallCount(t, runner, 2)
+}
@@ -0,0 +1,67 @@
+package neopersist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConfirmationRequired is returned, without executing anything, when an unbounded
+// destructive operation (DeleteAll, DropSchema, DeleteWhere with an unfiltered query, or
+// DeleteRelationsWhere without conditions) is called without the ConfirmDestructive
+// option.
+var ErrConfirmationRequired = errors.New("destructive operation requires explicit confirmation")
+
+// ErrDestructiveForbidden is returned, without executing anything, when an unbounded
+// destructive operation is called on a repository whose PersistenceManager was created
+// with AllowDestructive(false).
+var ErrDestructiveForbidden = errors.New("destructive operations are forbidden by the persistence manager")
+
+// ConfirmDestructive returns a QueryOption that confirms the caller really intends to run
+// an unbounded destructive operation, such as deleting every node of a label. Without it
+// those operations fail with ErrConfirmationRequired.
+//
+// Example:
+//
+//	deleted, err := userRepo.DeleteAll(ctx, neopersist.ConfirmDestructive())
+func ConfirmDestructive() QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optConfirmDestructive
+		o.confirmDestructive = true
+	}
+}
+
+// InBatchesOf returns a QueryOption that makes bulk deletes such as DeleteAll remove nodes
+// in separate transactions of at most n nodes each, so that huge labels do not exceed the
+// database's transaction memory limit. Values of zero or less delete everything in a single
+// transaction, which is the default.
+//
+// Batches are committed independently: if one fails, the nodes removed by earlier batches
+// stay deleted.
+func InBatchesOf(n int) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optDeleteBatch
+		o.deleteBatchSize = n
+	}
+}
+
+// AllowDestructive returns a ManagerOption controlling whether repositories obtained from
+// the manager may perform unbounded destructive operations at all. Services that should
+// never mass-delete can pass AllowDestructive(false) when creating their manager, which
+// makes those operations fail with ErrDestructiveForbidden even when confirmed.
+func AllowDestructive(allow bool) ManagerOption {
+	return func(pm *PersistenceManager) {
+		pm.forbidDestructive = !allow
+	}
+}
+
+// checkDestructive guards an unbounded destructive operation, returning an error naming
+// the operation if it is forbidden or was not confirmed.
+func (r *Repository[T]) checkDestructive(operation string, options *queryOptions) error {
+	if r.config.forbidDestructive {
+		return fmt.Errorf("%s on %s: %w", operation, r.meta.Label, ErrDestructiveForbidden)
+	}
+	if options == nil || !options.confirmDestructive {
+		return fmt.Errorf("%s on %s: %w", operation, r.meta.Label, ErrConfirmationRequired)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// unboundedOperations runs each unbounded destructive operation of repo with opts.
+func unboundedOperations(repo *neopersist.Repository[User], opts ...neopersist.QueryOption) map[string]error {
+	ctx := context.Background()
+	_, deleteAll := repo.DeleteAll(ctx, opts...)
+	_, dropSchema := repo.DropSchema(ctx, opts...)
+	_, deleteRelations := repo.DeleteRelationsWhere(ctx, "FOLLOWS", nil, opts...)
+	// gocypher's Where renders nothing, so this builder matches the whole label.
+	unfiltered := gocypher.NewQueryBuilder().Match(gocypher.N("n", "User")).Where("n.age < 18")
+	_, deleteWhere := repo.DeleteWhere(ctx, unfiltered, opts...)
+	ownDelete := gocypher.NewQueryBuilder().Match(gocypher.N("n", "User")).Delete("n")
+	_, deleteWhereOwnClause := repo.DeleteWhere(ctx, ownDelete, opts...)
+	return map[string]error{
+		"DeleteAll":                       deleteAll,
+		"DropSchema":                      dropSchema,
+		"DeleteRelationsWhere":            deleteRelations,
+		"DeleteWhere":                     deleteWhere,
+		"DeleteWhere with its own clause": deleteWhereOwnClause,
+	}
+}
+
+func TestUnboundedDestructiveOperationsRequireConfirmation(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	for operation, err := range unboundedOperations(repo) {
+		if !errors.Is(err, neopersist.ErrConfirmationRequired) {
+			t.Errorf("%s: err = %v, want ErrConfirmationRequired", operation, err)
+		}
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+
+	for operation, err := range unboundedOperations(repo, neopersist.ConfirmDestructive()) {
+		if err != nil {
+			t.Errorf("%s with confirmation: %v", operation, err)
+		}
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: "MATCH (n:User) DETACH DELETE n", Times: 2})
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: "MATCH (n:User) DELETE n"})
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: "DROP CONSTRAINT `User_userId_unique` IF EXISTS"})
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: "MATCH (n:User)-[rel:FOLLOWS]->() DELETE rel"})
+}
+
+func TestManagerCanForbidDestructiveOperations(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner, neopersist.AllowDestructive(false))
+	repo, err := neopersist.RepositoryFor[User](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor: %v", err)
+	}
+	for operation, err := range unboundedOperations(repo, neopersist.ConfirmDestructive()) {
+		if !errors.Is(err, neopersist.ErrDestructiveForbidden) {
+			t.Errorf("%s: err = %v, want ErrDestructiveForbidden", operation, err)
+		}
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+
+	// Bounded deletes stay allowed.
+	if _, err := repo.DeleteRelationsWhere(context.Background(), "FOLLOWS", []neopersist.Condition{neopersist.Field("Age").Lt(18)}); err != nil {
+		t.Errorf("DeleteRelationsWhere with conditions: %v", err)
+	}
+}
+
+func TestDropSchemaReportsTheRulesItRemoved(t *testing.T) {
+	type Ticket struct {
+		ID     string `crud:"pk,property:id"`
+		Email  string `crud:"property:email,unique"`
+		Tenant string `crud:"property:tenant,index:byTenant"`
+	}
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		// The email constraint is already gone.
+		if index == 1 {
+			return &neopersist.ResultSet{}, nil
+		}
+		return &neopersist.ResultSet{Counters: neopersist.Counters{ConstraintsRemoved: 1, IndexesRemoved: 1}}, nil
+	}
+	repo, err := neopersist.NewRepository[Ticket](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	dropped, err := repo.DropSchema(context.Background(), neopersist.ConfirmDestructive())
+	if err != nil {
+		t.Fatalf("DropSchema: %v", err)
+	}
+	neopersisttest.AssertCalls(t, runner,
+		neopersisttest.Expect{Query: "DROP CONSTRAINT `Ticket_id_unique` IF EXISTS"},
+		neopersisttest.Expect{Query: "DROP CONSTRAINT `Ticket_email_unique` IF EXISTS"},
+		neopersisttest.Expect{Query: "DROP INDEX `Ticket_byTenant` IF EXISTS"},
+	)
+	if len(dropped) != 2 || dropped[0] != "Ticket_id_unique" || dropped[1] != "Ticket_byTenant" {
+		t.Errorf("dropped = %v, want [Ticket_id_unique Ticket_byTenant]", dropped)
+	}
+}
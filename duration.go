@@ -0,0 +1,64 @@
+package neopersist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Duration is a Neo4j DURATION value, with its full breakdown: months and days are kept
+// apart from seconds because their length depends on the date they are added to. Runners
+// return DURATION values as Duration, and Duration fields are written back as DURATION
+// values unchanged.
+//
+// time.Duration fields are written as DURATION values too, holding only seconds and
+// nanoseconds. They are loaded from DURATION values without months or days, and still
+// from integers of nanoseconds, as they were stored before.
+type Duration struct {
+	Months  int64
+	Days    int64
+	Seconds int64
+	Nanos   int
+}
+
+// durationType is the reflect.Type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// NewDuration returns the Duration holding d as seconds and nanoseconds.
+func NewDuration(d time.Duration) Duration {
+	return Duration{Seconds: int64(d / time.Second), Nanos: int(d % time.Second)}
+}
+
+// ToDuration converts d into a time.Duration. It fails if d has months or days, whose
+// length is not fixed, or does not fit in a time.Duration.
+func (d Duration) ToDuration() (time.Duration, error) {
+	if d.Months != 0 || d.Days != 0 {
+		return 0, fmt.Errorf("duration of %d months and %d days has no fixed length", d.Months, d.Days)
+	}
+	if d.Seconds > math.MaxInt64/int64(time.Second)-1 || d.Seconds < math.MinInt64/int64(time.Second)+1 {
+		return 0, fmt.Errorf("duration of %d seconds overflows time.Duration", d.Seconds)
+	}
+	return time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanos), nil
+}
+
+// setDurationValue assigns a Duration database value to a time.Duration or
+// *time.Duration field. It reports false, leaving the field untouched, if the value is not
+// a Duration or the field is not a time.Duration, so that Duration fields are assigned as
+// any other value.
+func setDurationValue(field reflect.Value, value any) (bool, error) {
+	duration, ok := value.(Duration)
+	if !ok || (field.Type() != durationType && field.Type() != reflect.PointerTo(durationType)) {
+		return false, nil
+	}
+	d, err := duration.ToDuration()
+	if err != nil {
+		return true, err
+	}
+	if field.Kind() == reflect.Pointer {
+		field.Set(reflect.ValueOf(&d))
+	} else {
+		field.SetInt(int64(d))
+	}
+	return true, nil
+}
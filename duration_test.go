@@ -0,0 +1,130 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Timer has durations of every supported type.
+type Timer struct {
+	ID      string              `crud:"pk,property:id"`
+	Timeout time.Duration       `crud:"property:timeout"`
+	Grace   *time.Duration      `crud:"property:grace"`
+	Period  neopersist.Duration `crud:"property:period"`
+}
+
+func newTimerRepo(t *testing.T, props map[string]any) (*neopersist.Repository[Timer], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Timer](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	props["id"] = "t1"
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{ElementID: "4:test:t1", Labels: []string{"Timer"}, Props: props}), nil
+	}
+	return repo, runner
+}
+
+func TestDurationsAreSavedAsDurationValues(t *testing.T) {
+	repo, runner := newTimerRepo(t, map[string]any{})
+	grace := 1500 * time.Millisecond
+	period := neopersist.Duration{Months: 1, Days: 2, Seconds: 3, Nanos: 4}
+
+	if err := repo.Save(context.Background(), &Timer{ID: "t1", Timeout: 90 * time.Second, Grace: &grace, Period: period}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	call := runner.Calls()[0]
+	if value := setValue(t, call, "timeout"); value != (neopersist.Duration{Seconds: 90}) {
+		t.Errorf("timeout saved as %#v, want 90 seconds", value)
+	}
+	if value := setValue(t, call, "grace"); value != (neopersist.Duration{Seconds: 1, Nanos: 500_000_000}) {
+		t.Errorf("grace saved as %#v, want 1.5 seconds", value)
+	}
+	// Library durations are written unchanged, months and days included.
+	if value := setValue(t, call, "period"); value != period {
+		t.Errorf("period saved as %#v, want %#v", value, period)
+	}
+
+	runner.Reset()
+	if _, err := repo.FindByProperty(context.Background(), "Timeout", 2*time.Minute); err != nil {
+		t.Fatalf("FindByProperty: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Params: map[string]any{"timeout": neopersist.Duration{Seconds: 120}},
+	})
+}
+
+func TestDurationsRoundTrip(t *testing.T) {
+	period := neopersist.Duration{Months: 14, Days: -3, Seconds: 59, Nanos: 999}
+	repo, _ := newTimerRepo(t, map[string]any{
+		"timeout": neopersist.Duration{Seconds: 90, Nanos: 5},
+		"grace":   neopersist.Duration{Seconds: -2},
+		"period":  period,
+	})
+
+	timer, err := repo.FindByID(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if timer.Timeout != 90*time.Second+5 {
+		t.Errorf("Timeout = %v, want 90.000000005s", timer.Timeout)
+	}
+	if timer.Grace == nil || *timer.Grace != -2*time.Second {
+		t.Errorf("Grace = %v, want -2s", timer.Grace)
+	}
+	if timer.Period != period {
+		t.Errorf("Period = %+v, want %+v", timer.Period, period)
+	}
+}
+
+func TestDurationsLoadFromLegacyNanoseconds(t *testing.T) {
+	repo, _ := newTimerRepo(t, map[string]any{"timeout": int64(time.Minute)})
+
+	timer, err := repo.FindByID(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if timer.Timeout != time.Minute {
+		t.Errorf("Timeout = %v, want 1m", timer.Timeout)
+	}
+}
+
+func TestDurationsWithoutFixedLengthFailToLoad(t *testing.T) {
+	tests := map[string]struct {
+		stored neopersist.Duration
+		want   string
+	}{
+		"months":   {stored: neopersist.Duration{Months: 1}, want: "duration of 1 months and 0 days has no fixed length"},
+		"days":     {stored: neopersist.Duration{Days: 2, Seconds: 1}, want: "duration of 0 months and 2 days has no fixed length"},
+		"overflow": {stored: neopersist.Duration{Seconds: 1 << 40}, want: "overflows time.Duration"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo, _ := newTimerRepo(t, map[string]any{"timeout": tt.stored})
+
+			_, err := repo.FindByID(context.Background(), "t1")
+			if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), "Timeout") {
+				t.Fatalf("FindByID error = %v, want %q for field Timeout", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationConversions(t *testing.T) {
+	for _, d := range []time.Duration{0, time.Nanosecond, -1500 * time.Millisecond, 36 * time.Hour} {
+		got, err := neopersist.NewDuration(d).ToDuration()
+		if err != nil || got != d {
+			t.Errorf("NewDuration(%v).ToDuration() = %v, %v", d, got, err)
+		}
+	}
+	if _, err := (neopersist.Duration{Days: 1}).ToDuration(); err == nil {
+		t.Error("ToDuration accepted a duration of one day")
+	}
+}
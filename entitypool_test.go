@@ -0,0 +1,126 @@
+package neopersist_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+)
+
+// benchmarkRecords is the size of the fake result the mapping benchmarks load.
+const benchmarkRecords = 50_000
+
+// userNodes returns a result of n User nodes, as FindAll receives it.
+func userNodes(n int) *neopersist.ResultSet {
+	nodes := make([]neopersist.Node, n)
+	for i := range nodes {
+		id := fmt.Sprintf("u%d", i)
+		nodes[i] = neopersist.Node{
+			ElementID: "4:test:" + id,
+			Labels:    []string{"User"},
+			Props:     map[string]any{"userId": id, "name": "User " + id, "email": id + "@example.com", "age": int64(i % 90)},
+		}
+	}
+	return nodeResult(nodes...)
+}
+
+// userProjections returns a result of n records projecting the User properties, as Find
+// receives it for `RETURN n.userId AS userId, ...`.
+func userProjections(n int) *neopersist.ResultSet {
+	result := &neopersist.ResultSet{Keys: []string{"userId", "name", "email", "age"}}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("u%d", i)
+		result.Records = append(result.Records, neopersist.NewRecord(
+			"userId", id, "name", "User "+id, "email", id+"@example.com", "age", int64(i%90)))
+	}
+	return result
+}
+
+// newUserPool returns a pool of *User for WithEntityPool.
+func newUserPool() *sync.Pool {
+	return &sync.Pool{New: func() any { return new(User) }}
+}
+
+func TestEntityPoolMapsLikeAllocation(t *testing.T) {
+	result := userNodes(100)
+	plain, runner := newUserRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) { return result, nil }
+	pooled, pooledRunner := newUserRepo(t, neopersist.WithEntityPool[User](newUserPool()))
+	pooledRunner.Respond = runner.Respond
+	ctx := context.Background()
+
+	want, err := plain.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	// Dirty entities handed back to the pool must come out zeroed.
+	dirty := make([]*User, 100)
+	for i := range dirty {
+		dirty[i] = &User{Name: "stale", Email: "stale@example.com", Age: 99}
+	}
+	pooled.Release(dirty)
+
+	got, err := pooled.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll with pool: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entities, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("entity %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	sparse := &neopersist.ResultSet{Keys: []string{"n"}, Records: []*neopersist.Record{neopersist.NewRecord("n", userNode("u1", "Ada"))}}
+	pooledRunner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) { return sparse, nil }
+	pooled.Release(got)
+	reloaded, err := pooled.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll after Release: %v", err)
+	}
+	if *reloaded[0] != (User{UserID: "u1", Name: "Ada"}) {
+		t.Errorf("reused entity = %+v, want only the loaded properties", *reloaded[0])
+	}
+}
+
+// benchmarkFindAll measures loading a 50k-record result through FindAll, releasing the
+// entities after each iteration when the repository pools them.
+func benchmarkFindAll(b *testing.B, result *neopersist.ResultSet, opts ...neopersist.RepositoryOption) {
+	repo, runner := newUserRepo(b, opts...)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) { return result, nil }
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		users, err := repo.FindAll(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(users) != benchmarkRecords {
+			b.Fatalf("loaded %d users, want %d", len(users), benchmarkRecords)
+		}
+		repo.Release(users)
+		runner.Reset()
+	}
+}
+
+func BenchmarkFindAllNodes50k(b *testing.B) {
+	benchmarkFindAll(b, userNodes(benchmarkRecords))
+}
+
+func BenchmarkFindAllNodes50kPooled(b *testing.B) {
+	benchmarkFindAll(b, userNodes(benchmarkRecords), neopersist.WithEntityPool[User](newUserPool()))
+}
+
+func BenchmarkFindAllProjections50k(b *testing.B) {
+	benchmarkFindAll(b, userProjections(benchmarkRecords))
+}
+
+func BenchmarkFindAllProjections50kPooled(b *testing.B) {
+	benchmarkFindAll(b, userProjections(benchmarkRecords), neopersist.WithEntityPool[User](newUserPool()))
+}
@@ -0,0 +1,105 @@
+package neopersist
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// stringerType is the reflect.Type of fmt.Stringer, which fields tagged `enum:string` must
+// implement.
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// textUnmarshalerType is the reflect.Type of encoding.TextUnmarshaler, used to parse enums
+// without a registered parser.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// WithEnumParser returns a RepositoryOption registering the parser of the enum type E for
+// the fields of that type tagged `enum:string`. Such fields are written as the result of
+// their String method, and parse reads them back. Enum types whose pointer implements
+// encoding.TextUnmarshaler need no parser.
+//
+// Example:
+//
+//	repo, err := neopersist.NewRepository[models.Order](runner, neopersist.WithEnumParser(ParseStatus))
+func WithEnumParser[E any](parse func(string) (E, error)) RepositoryOption {
+	typ := reflect.TypeOf((*E)(nil)).Elem()
+	return func(c *repositoryConfig) {
+		if c.enumParsers == nil {
+			c.enumParsers = make(map[reflect.Type]func(string) (any, error))
+		}
+		c.enumParsers[typ] = func(s string) (any, error) { return parse(s) }
+	}
+}
+
+// RegisterEnumParser registers the parser of the enum type E on the manager. Repositories
+// created afterwards with RepositoryFor apply it like WithEnumParser.
+func RegisterEnumParser[E any](pm *PersistenceManager, parse func(string) (E, error)) {
+	pm.enumParsers.Store(reflect.TypeOf((*E)(nil)).Elem(), WithEnumParser(parse))
+}
+
+// enumParserOptions returns the WithEnumParser options registered on the manager.
+func (pm *PersistenceManager) enumParserOptions() []RepositoryOption {
+	var opts []RepositoryOption
+	pm.enumParsers.Range(func(_, opt any) bool {
+		opts = append(opts, opt.(RepositoryOption))
+		return true
+	})
+	return opts
+}
+
+// enumConverter is the PropertyConverter of a field tagged `enum:string`.
+type enumConverter struct {
+	typ   reflect.Type
+	parse func(string) (any, error)
+}
+
+// ToProperty returns the enum's String value. Plain strings, as passed to FindByProperty,
+// are taken to be String values already.
+func (c enumConverter) ToProperty(v any) (any, error) {
+	switch v := v.(type) {
+	case fmt.Stringer:
+		return v.String(), nil
+	case string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("value of type %T is not a %s", v, c.typ)
+}
+
+// FromProperty parses a stored string back into the enum.
+func (c enumConverter) FromProperty(p any) (any, error) {
+	s, ok := p.(string)
+	if !ok {
+		return nil, fmt.Errorf("enum %s must be stored as a string, got %T", c.typ, p)
+	}
+	value, err := c.parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("unknown value %q for enum %s: %w", s, c.typ, err)
+	}
+	return value, nil
+}
+
+// resolveEnums installs an enumConverter for every field tagged `enum:string`, using the
+// parser registered for its type or, failing that, its encoding.TextUnmarshaler.
+func (m *entityMetadata) resolveEnums(parsers map[reflect.Type]func(string) (any, error)) error {
+	for fieldName := range m.EnumFields {
+		typ := m.Types[fieldName]
+		parse, ok := parsers[typ]
+		if !ok {
+			if !reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+				return fmt.Errorf("field %s of entity type %s is tagged 'enum:string', but %s has no parser (see WithEnumParser) and does not implement encoding.TextUnmarshaler",
+					fieldName, m.Label, typ)
+			}
+			parse = func(s string) (any, error) {
+				ptr := reflect.New(typ)
+				err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+				return ptr.Elem().Interface(), err
+			}
+		}
+		if m.Converters == nil {
+			m.Converters = make(map[string]PropertyConverter)
+		}
+		m.Converters[fieldName] = enumConverter{typ: typ, parse: parse}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Priority is an enum parsed through its encoding.TextUnmarshaler, without a registered
+// parser.
+type Priority int
+
+const (
+	Low Priority = iota
+	High
+)
+
+func (p Priority) String() string {
+	if p == High {
+		return "high"
+	}
+	return "low"
+}
+
+func (p *Priority) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*p = Low
+	case "high":
+		*p = High
+	default:
+		return fmt.Errorf("no such priority")
+	}
+	return nil
+}
+
+// Chore has an enum field relying on the TextUnmarshaler fallback.
+type Chore struct {
+	ID       string   `crud:"pk,property:id"`
+	Priority Priority `crud:"property:priority,enum:string"`
+}
+
+// shipmentNode returns a Shipment node with the given stored status.
+func shipmentNode(status any) neopersist.Node {
+	return neopersist.Node{
+		ElementID: "4:test:s1",
+		Labels:    []string{"Shipment"},
+		Props:     map[string]any{"id": "s1", "status": status},
+	}
+}
+
+func TestEnumFieldsRoundTripThroughStringAndParser(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+	ctx := context.Background()
+
+	if err := repo.Save(ctx, &Shipment{ID: "s1", Status: Shipped}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	stored := setValue(t, runner.Calls()[0], "status")
+	if stored != "shipped" {
+		t.Errorf("status = %#v, want the String value", stored)
+	}
+
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(shipmentNode(stored)), nil
+	}
+	loaded, err := repo.FindByID(ctx, "s1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if loaded.Status != Shipped {
+		t.Errorf("Status = %v, want %v", loaded.Status, Shipped)
+	}
+}
+
+func TestUnknownEnumValuesNameTheEntityFieldAndValue(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(shipmentNode("lost")), nil
+	}
+
+	_, err := repo.FindByID(context.Background(), "s1")
+	var conversion *neopersist.ConversionError
+	if !errors.As(err, &conversion) {
+		t.Fatalf("FindByID error = %v, want a *ConversionError", err)
+	}
+	if conversion.Label != "Shipment" || conversion.Field != "Status" {
+		t.Errorf("ConversionError names %s.%s, want Shipment.Status", conversion.Label, conversion.Field)
+	}
+	for _, part := range []string{"Shipment", "Status", `"lost"`, "no such status"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("error %q does not mention %s", err, part)
+		}
+	}
+}
+
+func TestEnumValuesMustBeStoredAsStrings(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(shipmentNode(int64(1))), nil
+	}
+
+	_, err := repo.FindByID(context.Background(), "s1")
+	var conversion *neopersist.ConversionError
+	if !errors.As(err, &conversion) || !strings.Contains(err.Error(), "must be stored as a string") {
+		t.Fatalf("FindByID error = %v, want a *ConversionError about the stored type", err)
+	}
+}
+
+func TestEnumsFallBackToTextUnmarshaler(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Chore](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	stored := "high"
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{Labels: []string{"Chore"}, Props: map[string]any{"id": "c1", "priority": stored}}), nil
+	}
+
+	chore, err := repo.FindByID(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if chore.Priority != High {
+		t.Errorf("Priority = %v, want %v", chore.Priority, High)
+	}
+
+	stored = "urgent"
+	_, err = repo.FindByID(context.Background(), "c1")
+	if err == nil || !strings.Contains(err.Error(), `"urgent"`) || !strings.Contains(err.Error(), "no such priority") {
+		t.Errorf("FindByID error = %v, want the unknown value and the UnmarshalText error", err)
+	}
+}
+
+func TestEnumFieldsNeedAParser(t *testing.T) {
+	type Unparsed struct {
+		ID     string         `crud:"pk,property:id"`
+		Status ShipmentStatus `crud:"property:status,enum:string"`
+	}
+	_, err := neopersist.NewRepository[Unparsed](neopersisttest.NewFakeRunner())
+	if err == nil || !strings.Contains(err.Error(), "Status") || !strings.Contains(err.Error(), "WithEnumParser") {
+		t.Fatalf("NewRepository error = %v, want the field without a parser", err)
+	}
+}
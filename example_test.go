@@ -0,0 +1,169 @@
+package neopersist_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Author is the entity used by the examples.
+type Author struct {
+	AuthorID string `crud:"pk,property:authorId"`
+	Name     string `crud:"property:name"`
+	Posts    int64  `crud:"property:posts"`
+}
+
+// authorNode returns an Author node as the database would.
+func authorNode(id, name string, posts int64) neopersist.Node {
+	return neopersist.Node{
+		ElementID: "4:example:" + id,
+		Labels:    []string{"Author"},
+		Props:     map[string]any{"authorId": id, "name": name, "posts": posts},
+	}
+}
+
+// printCalls prints the queries recorded by runner.
+func printCalls(runner *neopersisttest.FakeRunner) {
+	for _, call := range runner.Calls() {
+		fmt.Println(call.Query)
+	}
+}
+
+func ExampleRepository_FindByID() {
+	ctx := context.Background()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(authorNode("a1", "Ada", 3)), nil
+	}
+	authors, err := neopersist.NewRepository[Author](runner)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	author, err := authors.FindByID(ctx, "a1")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s wrote %d posts\n", author.Name, author.Posts)
+	// Output:
+	// Ada wrote 3 posts
+}
+
+func ExampleRepository_Where() {
+	ctx := context.Background()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(authorNode("a2", "Grace", 12), authorNode("a1", "Ada", 3)), nil
+	}
+	authors, _ := neopersist.NewRepository[Author](runner)
+
+	prolific, err := authors.Where(neopersist.Field("Posts").Gt(2)).
+		OrderByDesc("Posts").
+		Limit(10).
+		Find(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	printCalls(runner)
+	for _, author := range prolific {
+		fmt.Println(author.Name)
+	}
+	// Output:
+	// MATCH (n:Author)
+	// WHERE ((n.posts > $posts))
+	// RETURN n
+	// ORDER BY n.posts DESC, n.authorId DESC
+	// LIMIT $queryLimit
+	// Grace
+	// Ada
+}
+
+func ExampleRepository_FindPaged() {
+	ctx := context.Background()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{
+			neopersist.NewRecord("n", authorNode("a1", "Ada", 3), "total", int64(5)),
+			neopersist.NewRecord("n", authorNode("a2", "Grace", 12), "total", int64(5)),
+		}}, nil
+	}
+	authors, _ := neopersist.NewRepository[Author](runner)
+
+	page, err := authors.FindPaged(ctx, neopersist.PageRequest{Limit: 2, Sort: []neopersist.Sort{neopersist.Asc("Name")}})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%d of %d, more: %v\n", len(page.Items), page.TotalCount, page.HasMore)
+	// Output:
+	// 2 of 5, more: true
+}
+
+func ExampleRepository_Save() {
+	ctx := context.Background()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(authorNode("a1", "Ada", 0)), nil
+	}
+	authors, _ := neopersist.NewRepository[Author](runner)
+
+	if err := authors.Save(ctx, &Author{AuthorID: "a1", Name: "Ada"}); err != nil {
+		fmt.Println(err)
+		return
+	}
+	call := runner.Calls()[0]
+	fmt.Println(call.Params["authorId"])
+	// Output:
+	// a1
+}
+
+func ExampleRepository_EstimateDeleteAll() {
+	ctx := context.Background()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 0 {
+			return &neopersist.ResultSet{Records: []*neopersist.Record{
+				neopersist.NewRecord("labels", []interface{}{"Author"}, "count", int64(2)),
+			}}, nil
+		}
+		return &neopersist.ResultSet{Records: []*neopersist.Record{
+			neopersist.NewRecord("type", "WROTE", "count", int64(15)),
+		}}, nil
+	}
+	authors, _ := neopersist.NewRepository[Author](runner)
+
+	estimate, err := authors.EstimateDeleteAll(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("would delete %d nodes and %d WROTE relationships\n", estimate.Nodes, estimate.RelationshipsByType["WROTE"])
+
+	_, err = authors.DeleteAll(ctx)
+	fmt.Println(err)
+	// Output:
+	// would delete 2 nodes and 15 WROTE relationships
+	// DeleteAll on Author: destructive operation requires explicit confirmation
+}
+
+func ExampleNewPersistenceManager() {
+	runner := neopersisttest.NewFakeRunner()
+	manager := neopersist.NewPersistenceManager(runner, neopersist.AllowDestructive(false))
+	authors, err := neopersist.RepositoryFor[Author](manager)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	_, err = authors.DeleteAll(context.Background(), neopersist.ConfirmDestructive())
+	fmt.Println(err)
+	fmt.Println(len(runner.Calls()), "queries sent")
+	// Output:
+	// DeleteAll on Author: destructive operations are forbidden by the persistence manager
+	// 0 queries sent
+}
@@ -42,7 +42,17 @@ func main() {
 		fmt.Println("Relationship created successfully!")
 	}
 
-	// 4. Clean up the created nodes.
+	// 4. Alternatively, relate the entities through the relationship declared on the model.
+	// Post.Author is tagged `crud:"rel:WROTE,direction:in"`, so the relationship type and
+	// direction come from the model instead of a string typed at the call site.
+	fmt.Println("Relating post to its author via the declared 'Author' field...")
+	if err := manager.Relate(ctx, &book, "Author", &author); err != nil {
+		fmt.Printf("Error relating entities: %v\n", err)
+	} else {
+		fmt.Println("Declared relationship ensured successfully!")
+	}
+
+	// 5. Clean up the created nodes.
 	fmt.Println("\nCleaning up...")
 	userRepo.Delete(ctx, author.UserID)
 	postRepo.Delete(ctx, book.PostID)
@@ -0,0 +1,279 @@
+// This example is a small blog application (users, posts, tags and follows) showing how the
+// pieces of neopersist compose: schema bootstrapping, bulk saves, transactions, declared and
+// ad-hoc relationships, loading related entities, pagination, finders, counting and graph
+// queries. Every step checks its result and fails if the library does not behave as
+// documented, and main_test.go runs the application against a disposable database, so the
+// example doubles as an end-to-end test.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// BlogUser is an author or reader of the blog, mapped to a :BlogUser node.
+type BlogUser struct {
+	UserID string `crud:"pk,property:userId"`
+	Name   string `crud:"property:name"`
+}
+
+// BlogPost is an article, mapped to a :BlogPost node.
+type BlogPost struct {
+	PostID string `crud:"pk,property:postId"`
+	Title  string `crud:"property:title"`
+
+	// Author declares the (:BlogUser)-[:WROTE]->(:BlogPost) relationship.
+	Author *BlogUser `crud:"rel:WROTE,direction:in"`
+	// Tags declares the (:BlogPost)-[:TAGGED]->(:BlogTag) relationship.
+	Tags []*BlogTag `crud:"rel:TAGGED,direction:out"`
+}
+
+// BlogTag is a topic posts can be tagged with, mapped to a :BlogTag node.
+type BlogTag struct {
+	Name string `crud:"pk,property:name"`
+}
+
+// check returns an error describing a failed expectation, or nil when cond holds.
+func check(cond bool, format string, args ...any) error {
+	if cond {
+		return nil
+	}
+	return fmt.Errorf("assertion failed: "+format, args...)
+}
+
+// errUntagged is returned by publish for a post without tags.
+var errUntagged = errors.New("a post needs at least one tag")
+
+// publish saves a post and relates it to its author and tags in one transaction, so that
+// a post never exists without them. The tags are checked last, after the post is created,
+// to show that a failing transaction leaves nothing behind.
+func publish(ctx context.Context, manager *neopersist.PersistenceManager, post *BlogPost, author *BlogUser, tags ...*BlogTag) error {
+	return manager.WithTransaction(ctx, func(ctx context.Context, tx *neopersist.PersistenceManager) error {
+		postRepo, err := neopersist.RepositoryFor[BlogPost](tx)
+		if err != nil {
+			return err
+		}
+		if err := postRepo.Create(ctx, post); err != nil {
+			return err
+		}
+		if err := tx.Relate(ctx, post, "Author", author); err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if err := tx.Relate(ctx, post, "Tags", tag); err != nil {
+				return err
+			}
+		}
+		if len(tags) == 0 {
+			return errUntagged
+		}
+		return nil
+	})
+}
+
+func main() {
+	// Point this at a disposable database: the example deletes the data it creates.
+	uri, username, password, dbName := "neo4j://localhost:7687", "neo4j", "your_password", "neo4j"
+	ctx := context.Background()
+
+	dbExecutor, err := neopersist.NewNeo4jExecutor(uri, username, password, dbName,
+		neopersist.WithMaxBufferedRecords(10_000))
+	if err != nil {
+		log.Fatalf("Fatal: Could not create executor: %v", err)
+	}
+	defer dbExecutor.Driver.Close(ctx)
+	if err := dbExecutor.Verify(ctx); err != nil {
+		log.Fatalf("Fatal: Could not connect to database: %v", err)
+	}
+
+	if err := run(ctx, dbExecutor); err != nil {
+		log.Fatalf("Blog flows failed: %v", err)
+	}
+	fmt.Println("All blog flows completed successfully.")
+}
+
+// run executes every flow of the application against the database behind runner,
+// returning the first failure.
+func run(ctx context.Context, runner neopersist.DBRunner) error {
+	manager := neopersist.NewPersistenceManager(runner)
+	userRepo, err := neopersist.RepositoryFor[BlogUser](manager)
+	if err != nil {
+		return fmt.Errorf("could not create user repository: %w", err)
+	}
+	postRepo, err := neopersist.RepositoryFor[BlogPost](manager)
+	if err != nil {
+		return fmt.Errorf("could not create post repository: %w", err)
+	}
+	tagRepo, err := neopersist.RepositoryFor[BlogTag](manager)
+	if err != nil {
+		return fmt.Errorf("could not create tag repository: %w", err)
+	}
+
+	// --- 1. Schema ---
+	fmt.Println("--- Ensuring the schema ---")
+	// Idempotent, so it can run at every startup.
+	schema, err := manager.EnsureSchema(ctx, []any{BlogUser{}, BlogPost{}, BlogTag{}})
+	if err != nil {
+		return fmt.Errorf("EnsureSchema failed: %w", err)
+	}
+	if err := check(len(schema.Created)+len(schema.Existing) >= 3,
+		"expected a key constraint per entity type, got %+v", schema); err != nil {
+		return err
+	}
+
+	// --- 2. Seed Data in Bulk ---
+	fmt.Println("\n--- Seeding users and tags ---")
+	alice := &BlogUser{UserID: "blog-alice", Name: "Alice"}
+	bob := &BlogUser{UserID: "blog-bob", Name: "Bob"}
+	goTag := &BlogTag{Name: "go"}
+	graphsTag := &BlogTag{Name: "graphs"}
+	posts := []*BlogPost{
+		{PostID: "blog-post-1", Title: "Hello, graphs"},
+		{PostID: "blog-post-2", Title: "Generics in Go"},
+		{PostID: "blog-post-3", Title: "Modeling follows"},
+	}
+	defer cleanup(ctx, userRepo, postRepo, tagRepo)
+
+	if err := userRepo.SaveAll(ctx, []*BlogUser{alice, bob}); err != nil {
+		return fmt.Errorf("failed to save users: %w", err)
+	}
+	if err := tagRepo.SaveAll(ctx, []*BlogTag{goTag, graphsTag}); err != nil {
+		return fmt.Errorf("failed to save tags: %w", err)
+	}
+	userCount, err := userRepo.CountWithQuery(ctx, gocypher.NewQueryBuilder().
+		Match(gocypher.N("u", "BlogUser")).
+		Return("count(u) AS count"))
+	if err := check(err == nil && userCount == 2, "expected 2 users, got %d (err: %v)", userCount, err); err != nil {
+		return err
+	}
+
+	// --- 3. Publishing in Transactions ---
+	fmt.Println("\n--- Publishing posts ---")
+	if err := publish(ctx, manager, posts[0], alice, graphsTag); err != nil {
+		return fmt.Errorf("publishing %s: %w", posts[0].PostID, err)
+	}
+	if err := publish(ctx, manager, posts[1], alice, goTag); err != nil {
+		return fmt.Errorf("publishing %s: %w", posts[1].PostID, err)
+	}
+	if err := publish(ctx, manager, posts[2], bob, graphsTag, goTag); err != nil {
+		return fmt.Errorf("publishing %s: %w", posts[2].PostID, err)
+	}
+
+	// Publishing a post whose key is taken fails before anything is written.
+	err = publish(ctx, manager, &BlogPost{PostID: "blog-post-1", Title: "Hello again"}, bob, goTag)
+	if err := check(errors.Is(err, neopersist.ErrAlreadyExists), "expected ErrAlreadyExists, got %v", err); err != nil {
+		return err
+	}
+	// An untagged post is created and related to its author before publish fails, so the
+	// transaction is rolled back and neither the post nor its WROTE relationship remain.
+	err = publish(ctx, manager, &BlogPost{PostID: "blog-draft", Title: "Untitled"}, bob)
+	if err := check(errors.Is(err, errUntagged), "expected errUntagged, got %v", err); err != nil {
+		return err
+	}
+	exists, err := postRepo.ExistsByID(ctx, "blog-draft")
+	if err := check(err == nil && !exists, "the rolled back post exists: %v (err: %v)", exists, err); err != nil {
+		return err
+	}
+
+	// A declared relationship refuses targets of the wrong type.
+	err = manager.Relate(ctx, posts[0], "Author", goTag)
+	if err := check(err != nil, "relating a post's author to a tag should fail"); err != nil {
+		return err
+	}
+
+	// Ad-hoc relationships can still be created with a free-form type.
+	err = manager.CreateRelation(ctx, bob, alice, "FOLLOWS", map[string]interface{}{"since": 2024})
+	if err := check(err == nil, "creating FOLLOWS relationship: %v", err); err != nil {
+		return err
+	}
+
+	// --- 4. Reading ---
+	fmt.Println("\n--- Reading data back ---")
+	// FindByIDWith loads the declared relationships named in the call.
+	found, err := postRepo.FindByIDWith(ctx, "blog-post-3", []string{"Author", "Tags"})
+	if err != nil {
+		return fmt.Errorf("FindByIDWith failed: %w", err)
+	}
+	if err := check(found.Title == "Modeling follows" && found.Author != nil && found.Author.Name == "Bob" && len(found.Tags) == 2,
+		"FindByIDWith returned %+v", found); err != nil {
+		return err
+	}
+
+	_, err = postRepo.FindByID(ctx, "blog-post-missing")
+	if err := check(errors.Is(err, neopersist.ErrNotFound), "expected ErrNotFound, got %v", err); err != nil {
+		return err
+	}
+
+	byTitle, err := postRepo.FindByProperty(ctx, "title", "Hello, graphs")
+	if err := check(err == nil && len(byTitle) == 1, "FindByProperty found %d posts (err: %v)", len(byTitle), err); err != nil {
+		return err
+	}
+
+	// --- 5. Pagination ---
+	fmt.Println("\n--- Listing posts page by page ---")
+	request := neopersist.PageRequest{Limit: 2, Sort: []neopersist.Sort{neopersist.Asc("Title")}}
+	first, err := postRepo.FindPaged(ctx, request)
+	if err != nil {
+		return fmt.Errorf("FindPaged failed: %w", err)
+	}
+	if err := check(len(first.Items) == 2 && first.TotalCount == 3 && first.HasMore && first.Items[0].Title == "Generics in Go",
+		"unexpected first page %+v", first); err != nil {
+		return err
+	}
+	request.Offset = 2
+	second, err := postRepo.FindPaged(ctx, request)
+	if err != nil {
+		return fmt.Errorf("FindPaged failed: %w", err)
+	}
+	if err := check(len(second.Items) == 1 && !second.HasMore && second.Items[0].Title == "Modeling follows",
+		"unexpected second page %+v", second); err != nil {
+		return err
+	}
+
+	// --- 6. A Feed as a Graph ---
+	fmt.Println("\n--- Building Bob's feed as a graph ---")
+	feed, err := manager.FindGraph(ctx, gocypher.NewQueryBuilder().
+		Match(gocypher.N("me", "BlogUser").WithProperties(map[string]interface{}{"userId": bob.UserID})).
+		Match(gocypher.NRef("me"), gocypher.R("f", "FOLLOWS").To(), gocypher.N("author", "BlogUser")).
+		Match(gocypher.NRef("author"), gocypher.R("w", "WROTE").To(), gocypher.N("post", "BlogPost")).
+		Return("author", "w", "post"))
+	if err != nil {
+		return fmt.Errorf("FindGraph failed: %w", err)
+	}
+	// Alice and her two posts, connected by two WROTE edges.
+	if err := check(len(feed.Nodes) == 3 && len(feed.Edges) == 2,
+		"expected 3 nodes and 2 edges in the feed, got %d and %d", len(feed.Nodes), len(feed.Edges)); err != nil {
+		return err
+	}
+	fmt.Printf("Feed contains %d nodes and %d edges.\n", len(feed.Nodes), len(feed.Edges))
+
+	// --- 7. Estimating a Delete ---
+	estimate, err := userRepo.EstimateDelete(ctx, alice.UserID)
+	return check(err == nil && estimate.Nodes == 1 && estimate.RelationshipsByType["WROTE"] == 2,
+		"unexpected delete estimate %+v (err: %v)", estimate, err)
+}
+
+// cleanup deletes the data created by run. The schema rules are kept.
+func cleanup(ctx context.Context, userRepo *neopersist.Repository[BlogUser], postRepo *neopersist.Repository[BlogPost], tagRepo *neopersist.Repository[BlogTag]) {
+	fmt.Println("\n--- Cleaning up ---")
+	for _, id := range []string{"blog-post-1", "blog-post-2", "blog-post-3", "blog-draft"} {
+		if err := postRepo.Delete(ctx, id); err != nil {
+			log.Printf("deleting %s: %v", id, err)
+		}
+	}
+	for _, name := range []string{"go", "graphs"} {
+		if err := tagRepo.Delete(ctx, name); err != nil {
+			log.Printf("deleting tag %s: %v", name, err)
+		}
+	}
+	for _, id := range []string{"blog-alice", "blog-bob"} {
+		if err := userRepo.Delete(ctx, id); err != nil {
+			log.Printf("deleting %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+)
+
+// TestBlogApp runs every flow of the application against the database named by the
+// NEO4J_TEST_URI environment variable, like the integration tests of the neopersist
+// package, and skips when it is not set. The database must be disposable.
+func TestBlogApp(t *testing.T) {
+	uri := os.Getenv("NEO4J_TEST_URI")
+	if uri == "" {
+		t.Skip("NEO4J_TEST_URI is not set; skipping integration test")
+	}
+	user, password := os.Getenv("NEO4J_TEST_USER"), os.Getenv("NEO4J_TEST_PASSWORD")
+	if user == "" {
+		user = "neo4j"
+	}
+	database := os.Getenv("NEO4J_TEST_DATABASE")
+	if database == "" {
+		database = "neo4j"
+	}
+	executor, err := neopersist.NewNeo4jExecutor(uri, user, password, database)
+	if err != nil {
+		t.Fatalf("NewNeo4jExecutor: %v", err)
+	}
+	ctx := context.Background()
+	t.Cleanup(func() { _ = executor.Driver.Close(ctx) })
+	if err := executor.Verify(ctx); err != nil {
+		t.Fatalf("could not connect to %s: %v", uri, err)
+	}
+
+	if err := run(ctx, executor); err != nil {
+		t.Fatal(err)
+	}
+
+	// Running the flows again checks that they clean up after themselves and that schema
+	// bootstrapping is idempotent.
+	if err := run(ctx, executor); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	posts, err := neopersist.NewRepository[BlogPost](executor)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	if left, err := posts.Count(ctx); err != nil || left != 0 {
+		t.Errorf("%d posts left after the run (err: %v)", left, err)
+	}
+}
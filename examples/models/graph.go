@@ -3,6 +3,15 @@
 // making them ideal for serializing query results to JSON for frontend clients or other services.
 package models
 
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
 // GraphNode represents a generic node from a Neo4j graph.
 // It is a domain-agnostic representation, capturing the essential components of any node:
 // its unique internal ID, its labels, and its properties. This struct is designed to be
@@ -36,6 +45,12 @@ type Edge struct {
 
 	// Properties is a map containing the key-value properties of the relationship.
 	Properties map[string]interface{} `json:"properties"`
+
+	// TraversedReverse reports that the query traversed this relationship against its
+	// stored direction, i.e. from Target to Source. Frontends can use it to draw arrows in
+	// the order the user asked for. It is only derivable for relationships returned as
+	// part of a path; bare relationship returns always report false.
+	TraversedReverse bool `json:"traversedReverse,omitempty"`
 }
 
 // GraphResult is a top-level container for a generic graph query result.
@@ -48,3 +63,153 @@ type GraphResult struct {
 	// Edges contains all the unique relationships retrieved by the query.
 	Edges []*Edge `json:"edges"`
 }
+
+// GraphJSONOptions customizes the JSON produced by GraphResult.MarshalWith and
+// GraphResult.EncodeWith, for frontends whose graph libraries expect a different shape
+// than the default MarshalJSON output. Empty field names fall back to the defaults.
+type GraphJSONOptions struct {
+	// NodeIDField overrides the name of the node ID field (default "id").
+	NodeIDField string
+	// NodeLabelsField overrides the name of the node labels field (default "labels").
+	NodeLabelsField string
+	// EdgeIDField overrides the name of the edge ID field (default "id").
+	EdgeIDField string
+	// EdgeSourceField overrides the name of the edge source field (default "source").
+	EdgeSourceField string
+	// EdgeTargetField overrides the name of the edge target field (default "target").
+	EdgeTargetField string
+	// EdgeTypeField overrides the name of the edge type field (default "type").
+	EdgeTypeField string
+	// PropertiesField overrides the name of the properties field of nodes and edges
+	// (default "properties").
+	PropertiesField string
+
+	// JoinLabels renders node labels as a single string joined with LabelSeparator
+	// instead of an array (e.g., ["User", "Admin"] becomes "User:Admin").
+	JoinLabels bool
+	// LabelSeparator is the separator used when JoinLabels is set (default ":").
+	LabelSeparator string
+
+	// PropertyWhitelist, when non-empty, restricts the serialized properties of nodes and
+	// edges to the listed keys. Properties not present on an element are omitted.
+	PropertyWhitelist []string
+}
+
+// withDefaults returns a copy of the options with every empty field name defaulted.
+func (o GraphJSONOptions) withDefaults() GraphJSONOptions {
+	defaultString := func(s *string, def string) {
+		if *s == "" {
+			*s = def
+		}
+	}
+	defaultString(&o.NodeIDField, "id")
+	defaultString(&o.NodeLabelsField, "labels")
+	defaultString(&o.EdgeIDField, "id")
+	defaultString(&o.EdgeSourceField, "source")
+	defaultString(&o.EdgeTargetField, "target")
+	defaultString(&o.EdgeTypeField, "type")
+	defaultString(&o.PropertiesField, "properties")
+	defaultString(&o.LabelSeparator, ":")
+	return o
+}
+
+// filterProperties applies the property whitelist, if any.
+func (o GraphJSONOptions) filterProperties(props map[string]interface{}) map[string]interface{} {
+	if len(o.PropertyWhitelist) == 0 {
+		return props
+	}
+	filtered := make(map[string]interface{}, len(o.PropertyWhitelist))
+	for _, key := range o.PropertyWhitelist {
+		if value, ok := props[key]; ok {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// MarshalWith serializes the graph to JSON using the given options. The default
+// MarshalJSON output is unaffected; use this method when a frontend contract requires
+// different field names or label formatting.
+//
+// Example:
+//
+//	data, err := graph.MarshalWith(models.GraphJSONOptions{NodeIDField: "key", NodeLabelsField: "type", JoinLabels: true})
+func (g *GraphResult) MarshalWith(opts GraphJSONOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := g.EncodeWith(&buf, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeWith is the streaming variant of MarshalWith. It writes the graph to w one element
+// at a time, so large graphs are not held in memory twice as a single JSON document.
+func (g *GraphResult) EncodeWith(w io.Writer, opts GraphJSONOptions) error {
+	opts = opts.withDefaults()
+	bw := bufio.NewWriter(w)
+
+	writeElements := func(name string, n int, element func(i int) map[string]interface{}) error {
+		if _, err := fmt.Fprintf(bw, "%q:[", name); err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				if err := bw.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			data, err := json.Marshal(element(i))
+			if err != nil {
+				return err
+			}
+			if _, err := bw.Write(data); err != nil {
+				return err
+			}
+		}
+		_, err := bw.WriteString("]")
+		return err
+	}
+
+	if err := bw.WriteByte('{'); err != nil {
+		return err
+	}
+	err := writeElements("nodes", len(g.Nodes), func(i int) map[string]interface{} {
+		node := g.Nodes[i]
+		var labels interface{} = node.Labels
+		if opts.JoinLabels {
+			labels = strings.Join(node.Labels, opts.LabelSeparator)
+		}
+		return map[string]interface{}{
+			opts.NodeIDField:     node.ID,
+			opts.NodeLabelsField: labels,
+			opts.PropertiesField: opts.filterProperties(node.Properties),
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if err := bw.WriteByte(','); err != nil {
+		return err
+	}
+	err = writeElements("edges", len(g.Edges), func(i int) map[string]interface{} {
+		edge := g.Edges[i]
+		element := map[string]interface{}{
+			opts.EdgeIDField:     edge.ID,
+			opts.EdgeSourceField: edge.Source,
+			opts.EdgeTargetField: edge.Target,
+			opts.EdgeTypeField:   edge.Type,
+			opts.PropertiesField: opts.filterProperties(edge.Properties),
+		}
+		if edge.TraversedReverse {
+			element["traversedReverse"] = true
+		}
+		return element
+	})
+	if err != nil {
+		return err
+	}
+	if err := bw.WriteByte('}'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// sampleGraph is the graph every golden file is rendered from.
+func sampleGraph() *GraphResult {
+	return &GraphResult{
+		Nodes: []*GraphNode{
+			{ID: "4:db:1", Labels: []string{"User", "Admin"}, Properties: map[string]interface{}{"name": "Ada", "email": "ada@example.com"}},
+			{ID: "4:db:2", Labels: []string{"Post"}, Properties: map[string]interface{}{"title": "Hello"}},
+		},
+		Edges: []*Edge{
+			{ID: "5:db:1", Source: "4:db:1", Target: "4:db:2", Type: "WROTE", Properties: map[string]interface{}{"since": int64(2020)}},
+			{ID: "5:db:2", Source: "4:db:2", Target: "4:db:1", Type: "MENTIONS", Properties: map[string]interface{}{}, TraversedReverse: true},
+		},
+	}
+}
+
+// assertGolden compares got with testdata/<name>.golden, rewriting the file with -update.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, got, "", "  "); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, got)
+	}
+	indented.WriteByte('\n')
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, indented.Bytes(), 0o644); err != nil {
+			t.Fatalf("could not update %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read %s (run with -update to create it): %v", path, err)
+	}
+	if !bytes.Equal(indented.Bytes(), want) {
+		t.Errorf("output differs from %s:\ngot:\n%s\nwant:\n%s", path, indented.Bytes(), want)
+	}
+}
+
+func TestGraphJSONGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		opts GraphJSONOptions
+	}{
+		{name: "defaults", opts: GraphJSONOptions{}},
+		{name: "key_and_joined_type", opts: GraphJSONOptions{NodeIDField: "key", NodeLabelsField: "type", JoinLabels: true}},
+		{name: "renamed_edges_whitelist", opts: GraphJSONOptions{
+			EdgeSourceField:   "from",
+			EdgeTargetField:   "to",
+			PropertiesField:   "data",
+			JoinLabels:        true,
+			LabelSeparator:    ",",
+			PropertyWhitelist: []string{"name", "since"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sampleGraph().MarshalWith(tt.opts)
+			if err != nil {
+				t.Fatalf("MarshalWith: %v", err)
+			}
+			assertGolden(t, tt.name, got)
+
+			var streamed bytes.Buffer
+			if err := sampleGraph().EncodeWith(&streamed, tt.opts); err != nil {
+				t.Fatalf("EncodeWith: %v", err)
+			}
+			if !bytes.Equal(streamed.Bytes(), got) {
+				t.Errorf("EncodeWith and MarshalWith disagree:\n%s\n%s", streamed.Bytes(), got)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONIsUnaffectedByOptions(t *testing.T) {
+	got, err := json.Marshal(sampleGraph())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	assertGolden(t, "marshal_json", got)
+}
@@ -28,4 +28,9 @@ type Post struct {
 	// Title is the title of the post.
 	// The `property:title` tag maps this field to the 'title' property in the database node.
 	Title string `crud:"property:title"`
+
+	// Author is the user who wrote the post.
+	// The `rel:WROTE,direction:in` tag declares the (:User)-[:WROTE]->(:Post) relationship,
+	// which PersistenceManager.Relate uses instead of a free-form relationship type.
+	Author *User `crud:"rel:WROTE,direction:in"`
 }
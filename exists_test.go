@@ -0,0 +1,59 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+func TestExistsByID(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *neopersist.ResultSet
+		want   bool
+	}{
+		{name: "found", result: &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("exists", true)}}, want: true},
+		{name: "not found", result: &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("exists", false)}}, want: false},
+		{name: "no records", result: &neopersist.ResultSet{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, runner := newUserRepo(t)
+			runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+				return tt.result, nil
+			}
+
+			exists, err := repo.ExistsByID(context.Background(), "u1")
+			if err != nil {
+				t.Fatalf("ExistsByID: %v", err)
+			}
+			if exists != tt.want {
+				t.Errorf("exists = %v, want %v", exists, tt.want)
+			}
+			neopersisttest.AssertCalls(t, runner, neopersisttest.Expect{
+				Query:       "MATCH (n:User {userId: $userId}) RETURN count(n) > 0 AS exists",
+				Params:      map[string]any{"userId": "u1"},
+				ExactParams: true,
+			})
+		})
+	}
+}
+
+func TestExistsByIDPropagatesQueryErrors(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	failure := errors.New("connection reset")
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nil, failure
+	}
+
+	exists, err := repo.ExistsByID(context.Background(), "u1")
+	if !errors.Is(err, failure) || exists {
+		t.Errorf("ExistsByID = %v, %v; want false and the query error", exists, err)
+	}
+	if errors.Is(err, neopersist.ErrNotFound) {
+		t.Error("a failed query was reported as ErrNotFound")
+	}
+}
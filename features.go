@@ -0,0 +1,43 @@
+package neopersist
+
+import (
+	"context"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// ServerInfo describes the Neo4j server a Neo4jExecutor is connected to.
+type ServerInfo struct {
+	// Address is the address of the server that answered the request.
+	Address string
+	// Agent is the server agent string (e.g., "Neo4j/5.13.0").
+	Agent string
+	// Version is the parsed server version.
+	Version features.Version
+	// Edition is the server edition (e.g., "community" or "enterprise").
+	Edition string
+	// ProtocolVersion is the negotiated Bolt protocol version (e.g., "5.4").
+	ProtocolVersion string
+}
+
+// Supports reports whether the server described by info supports the given feature.
+// Unknown features are reported as unsupported.
+func (info *ServerInfo) Supports(f features.Feature) bool {
+	return features.Supported(f, info.Version)
+}
+
+// featureChecker is implemented by runners that can detect the server version, such as
+// Neo4jExecutor. Runners that do not implement it are assumed to support every feature.
+type featureChecker interface {
+	RequireFeature(ctx context.Context, f features.Feature) error
+}
+
+// requireFeature checks that the runner's server supports f, when the runner is able to
+// tell. Every version-dependent statement issued by the library is gated through it, so
+// that older servers fail with a *features.UnsupportedError rather than a syntax error.
+func requireFeature(ctx context.Context, runner DBRunner, f features.Feature) error {
+	if checker, ok := runner.(featureChecker); ok {
+		return checker.RequireFeature(ctx, f)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package features maps the capabilities of Neo4j that are only available from a given
+// server version to that version, so that code talking to servers of different versions
+// can branch on what the connected server supports instead of failing with an opaque
+// Cypher syntax error:
+//
+//	info, err := executor.ServerInfo(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	if info.Supports(features.VectorIndex) {
+//	    // ...
+//	}
+package features
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Feature identifies a server capability that is only available from a given Neo4j version.
+type Feature string
+
+const (
+	// CallInTransactions is the `CALL { ... } IN TRANSACTIONS` subquery syntax.
+	CallInTransactions Feature = "CALL IN TRANSACTIONS"
+	// SchemaCommands are the `CREATE CONSTRAINT ... IF NOT EXISTS FOR ... REQUIRE` and
+	// `CREATE INDEX ... IF NOT EXISTS FOR ... ON` schema commands and SHOW CONSTRAINTS.
+	SchemaCommands Feature = "schema commands"
+	// ElementID is the elementId() function and string element IDs, which replace legacy integer IDs.
+	ElementID Feature = "element IDs"
+	// RelationshipConstraints is support for uniqueness constraints on relationship properties.
+	RelationshipConstraints Feature = "relationship constraints"
+	// PointDistance is the point.distance() function, which replaces distance().
+	PointDistance Feature = "point.distance()"
+	// VectorIndex is the `CREATE VECTOR INDEX` syntax and vector similarity search.
+	VectorIndex Feature = "vector indexes"
+	// DynamicLabels is the `n:$(expression)` syntax setting or removing labels computed at runtime.
+	DynamicLabels Feature = "dynamic labels"
+)
+
+// minVersions maps each known Feature to the first server version supporting it.
+var minVersions = map[Feature]Version{
+	CallInTransactions:      {Major: 4, Minor: 4},
+	PointDistance:           {Major: 4, Minor: 3},
+	SchemaCommands:          {Major: 4, Minor: 4},
+	ElementID:               {Major: 5, Minor: 0},
+	RelationshipConstraints: {Major: 5, Minor: 7},
+	VectorIndex:             {Major: 5, Minor: 15},
+	DynamicLabels:           {Major: 5, Minor: 26},
+}
+
+// MinimumVersion returns the first Neo4j version that supports the given feature.
+// The boolean is false if the feature is unknown.
+func MinimumVersion(f Feature) (Version, bool) {
+	v, ok := minVersions[f]
+	return v, ok
+}
+
+// Supported reports whether a server of version v supports the given feature. Unknown
+// features are reported as unsupported.
+func Supported(f Feature, v Version) bool {
+	required, ok := minVersions[f]
+	return ok && v.AtLeast(required)
+}
+
+// Require returns an *UnsupportedError if a server of version v does not support the
+// given feature, and nil otherwise.
+func Require(f Feature, v Version) error {
+	if Supported(f, v) {
+		return nil
+	}
+	required, _ := MinimumVersion(f)
+	return &UnsupportedError{Feature: f, Detected: v, Required: required}
+}
+
+// Version is a Neo4j server version. Both semantic (5.13.0) and calendar (2025.01.0)
+// versions are supported, since calendar versions compare higher than every 5.x release.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a server version string such as "5.13.0", "4.4" or "5.26-aura".
+// Any non-numeric suffix after the last numeric component is ignored.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	parts := strings.SplitN(s, ".", 3)
+	targets := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		// Strip suffixes like "-aura" or "-drop01".
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			if i == 0 {
+				return Version{}, fmt.Errorf("invalid server version '%s'", s)
+			}
+			break
+		}
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid server version '%s': %w", s, err)
+		}
+		*targets[i] = n
+		if end != len(part) {
+			break
+		}
+	}
+	return v, nil
+}
+
+// String returns the version in "major.minor.patch" form.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the same as or newer than other.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// ErrUnsupportedServerVersion is a sentinel error returned when an operation requires a
+// feature the connected server does not support. The concrete error returned is an
+// *UnsupportedError naming the capability and the detected version.
+var ErrUnsupportedServerVersion = errors.New("unsupported server version")
+
+// UnsupportedError reports that a feature is not available on the connected server.
+// It matches ErrUnsupportedServerVersion via errors.Is.
+type UnsupportedError struct {
+	// Feature is the capability that was requested.
+	Feature Feature
+	// Detected is the version of the connected server.
+	Detected Version
+	// Required is the first version supporting the feature.
+	Required Version
+}
+
+// Error implements the error interface.
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s: %s requires Neo4j %s or later, but the server runs %s",
+		ErrUnsupportedServerVersion, e.Feature, e.Required, e.Detected)
+}
+
+// Unwrap allows errors.Is(err, ErrUnsupportedServerVersion) to match.
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupportedServerVersion
+}
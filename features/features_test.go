@@ -0,0 +1,44 @@
+package features_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want features.Version
+	}{
+		{"5.13.0", features.Version{Major: 5, Minor: 13}},
+		{"4.4", features.Version{Major: 4, Minor: 4}},
+		{"5.26-aura", features.Version{Major: 5, Minor: 26}},
+		{"2025.01.0", features.Version{Major: 2025, Minor: 1}},
+	}
+	for _, tt := range tests {
+		got, err := features.ParseVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := features.ParseVersion("aura"); err == nil {
+		t.Error("ParseVersion accepted a version without digits")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	if err := features.Require(features.VectorIndex, features.Version{Major: 2025, Minor: 1}); err != nil {
+		t.Errorf("calendar versions should support vector indexes: %v", err)
+	}
+
+	err := features.Require(features.VectorIndex, features.Version{Major: 5, Minor: 13})
+	var unsupported *features.UnsupportedError
+	if !errors.Is(err, features.ErrUnsupportedServerVersion) || !errors.As(err, &unsupported) {
+		t.Fatalf("expected an *UnsupportedError, got %v", err)
+	}
+	if unsupported.Required != (features.Version{Major: 5, Minor: 15}) {
+		t.Errorf("Required = %v, want 5.15.0", unsupported.Required)
+	}
+}
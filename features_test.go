@@ -0,0 +1,54 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+func TestSchemaCommandsAreGatedOnOldServers(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.ServerVersion = "4.3"
+
+	if _, err := repo.EnsureConstraints(context.Background()); !errors.Is(err, features.ErrUnsupportedServerVersion) {
+		t.Fatalf("EnsureConstraints: expected ErrUnsupportedServerVersion, got %v", err)
+	}
+	if _, err := repo.EnsureIndexes(context.Background()); !errors.Is(err, features.ErrUnsupportedServerVersion) {
+		t.Fatalf("EnsureIndexes: expected ErrUnsupportedServerVersion, got %v", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+// Passage has an embedding backed by a vector index.
+type Passage struct {
+	ID        string    `crud:"pk,property:id"`
+	Text      string    `crud:"property:text,index"`
+	Embedding []float32 `crud:"property:embedding,vector:dim=3,similarity=cosine"`
+}
+
+func TestVectorIndexesAreGatedOnOldServers(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.ServerVersion = "5.14"
+	repo, err := neopersist.NewRepository[Passage](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	if _, err := repo.EnsureIndexes(context.Background()); !errors.Is(err, features.ErrUnsupportedServerVersion) {
+		t.Fatalf("EnsureIndexes: expected ErrUnsupportedServerVersion, got %v", err)
+	}
+	if _, err := repo.SimilaritySearch(context.Background(), []float32{1, 0, 0}, 2); !errors.Is(err, features.ErrUnsupportedServerVersion) {
+		t.Fatalf("SimilaritySearch: expected ErrUnsupportedServerVersion, got %v", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+
+	runner.ServerVersion = "5.15"
+	if _, err := repo.SimilaritySearch(context.Background(), []float32{1, 0, 0}, 2); err != nil {
+		t.Fatalf("SimilaritySearch on 5.15: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{QueryContains: []string{"db.index.vector.queryNodes"}})
+}
@@ -0,0 +1,88 @@
+package neopersist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// findOrCreateQuery is the MERGE sent by FindOrCreate: existing nodes are only read, since
+// the properties are set ON CREATE and there is neither ON MATCH nor a plain SET.
+const findOrCreateQuery = "MERGE (n:User {userId: $pk}) ON CREATE SET n += $props RETURN n"
+
+// mergedNode answers a FindOrCreate with node, as created when created is true.
+func mergedNode(node neopersist.Node, created bool) func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+	return func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		result := nodeResult(node)
+		if created {
+			result.Counters.NodesCreated = 1
+		}
+		return result, nil
+	}
+}
+
+func TestFindOrCreateCreatesTheNode(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = mergedNode(neopersist.Node{
+		Labels: []string{"User"},
+		Props:  map[string]any{"userId": "u1", "name": "Ada", "email": "ada@example.com", "age": int64(36)},
+	}, true)
+	user := &User{UserID: "u1", Name: "Ada", Email: "ada@example.com", Age: 36}
+
+	created, err := repo.FindOrCreate(context.Background(), user)
+	if err != nil {
+		t.Fatalf("FindOrCreate: %v", err)
+	}
+	if !created {
+		t.Error("created = false, want true when the MERGE created a node")
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: findOrCreateQuery,
+		Params: map[string]any{
+			"pk":    "u1",
+			"props": map[string]interface{}{"name": "Ada", "email": "ada@example.com", "age": int64(36)},
+		},
+		ExactParams: true,
+	})
+}
+
+func TestFindOrCreateLoadsAnExistingNode(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	// The stored node has no email, and the same name as the entity: only the counters
+	// tell whether it was created.
+	runner.Respond = mergedNode(neopersist.Node{
+		Labels: []string{"User"},
+		Props:  map[string]any{"userId": "u1", "name": "Ada", "age": int64(40)},
+	}, false)
+	user := &User{UserID: "u1", Name: "Ada", Email: "new@example.com", Age: 36}
+
+	created, err := repo.FindOrCreate(context.Background(), user)
+	if err != nil {
+		t.Fatalf("FindOrCreate: %v", err)
+	}
+	if created {
+		t.Error("created = true, want false when the MERGE matched a node")
+	}
+	want := User{UserID: "u1", Name: "Ada", Email: "new@example.com", Age: 40}
+	if *user != want {
+		t.Errorf("entity = %+v, want the stored state %+v with the absent email kept", *user, want)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: findOrCreateQuery})
+}
+
+func TestFindOrCreateRejectsAZeroKey(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	if _, err := repo.FindOrCreate(context.Background(), &User{Name: "Ada"}); err == nil {
+		t.Fatal("FindOrCreate accepted a zero-value primary key")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestFindOrCreateFailsWithoutANode(t *testing.T) {
+	repo, _ := newUserRepo(t)
+	if _, err := repo.FindOrCreate(context.Background(), &User{UserID: "u1"}); err == nil {
+		t.Fatal("FindOrCreate succeeded although the MERGE returned no node")
+	}
+}
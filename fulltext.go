@@ -0,0 +1,130 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ScoredResult is an entity returned by Search or SimilaritySearch, with its score.
+type ScoredResult[T any] struct {
+	// Entity is the entity mapped from the matching node.
+	Entity *T `json:"entity"`
+	// Score is the relevance of the match computed by the index; higher is better.
+	Score float64 `json:"score"`
+}
+
+// addFullTextIndex records the field fieldName, of type typ, as indexed by the full-text
+// index name. Full-text indexes only index strings.
+func (m *entityMetadata) addFullTextIndex(name, fieldName string, typ reflect.Type) error {
+	if typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.String {
+		return fmt.Errorf("field %s tagged 'fulltext' must be a string, a *string or a []string", fieldName)
+	}
+	for _, index := range m.FullTextIndexes {
+		if index.Name == name {
+			index.Fields = append(index.Fields, fieldName)
+			return nil
+		}
+	}
+	m.FullTextIndexes = append(m.FullTextIndexes, &indexMetadata{Name: name, Fields: []string{fieldName}})
+	return nil
+}
+
+// fullTextIndexName returns the database name of the full-text index declared with
+// `fulltext:<name>`.
+func (m *entityMetadata) fullTextIndexName(name string) string {
+	return m.Label + "_" + name + "_fulltext"
+}
+
+// fullTextIndexQuery renders the statement creating a full-text index.
+func (m *entityMetadata) fullTextIndexQuery(index *indexMetadata) string {
+	props := make([]string, len(index.Fields))
+	for i, fieldName := range index.Fields {
+		props[i] = "n." + m.Mappings[fieldName]
+	}
+	return fmt.Sprintf("CREATE FULLTEXT INDEX %s IF NOT EXISTS\nFOR (n:%s)\nON EACH [%s]",
+		quoteIdentifier(m.fullTextIndexName(index.Name)), m.Label, strings.Join(props, ", "))
+}
+
+// Search runs a full-text query against an index declared on the entity type with the
+// `fulltext:<indexName>` tag component, and returns the matching entities with their
+// score, best match first. Fields tagged with the same index name share one index, which
+// EnsureIndexes creates as "<Label>_<indexName>_fulltext".
+//
+// The query uses the Lucene syntax of db.index.fulltext.queryNodes, such as
+// `graph AND database` or `datab*`; it is passed as a parameter.
+//
+// Example:
+//
+//	type Article struct {
+//	    ID    string `crud:"pk,property:id"`
+//	    Title string `crud:"property:title,fulltext:content"`
+//	    Body  string `crud:"property:body,fulltext:content"`
+//	}
+//	results, err := articleRepo.Search(ctx, "content", "graph database", 10)
+//	for _, result := range results {
+//	    fmt.Println(result.Entity.Title, result.Score)
+//	}
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - indexName: The index name used in the `fulltext:` tag component.
+//   - query: The full-text query.
+//   - limit: The maximum number of results; must be positive.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The matching entities and their scores, or an error if the index is not declared on
+//	the entity type, limit is not positive or the query fails.
+func (r *Repository[T]) Search(ctx context.Context, indexName, query string, limit int, opts ...FindOption) ([]ScoredResult[T], error) {
+	options, err := parseOptions("Search", opts, procedureOptions)
+	if err != nil {
+		return nil, err
+	}
+	declared := false
+	for _, index := range r.meta.FullTextIndexes {
+		declared = declared || index.Name == indexName
+	}
+	if !declared {
+		return nil, fmt.Errorf("full-text index '%s' is not declared for entity type %s", indexName, r.meta.Label)
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	cypher := "CALL db.index.fulltext.queryNodes($index, $search) YIELD node, score\nRETURN node, score\nLIMIT $limit"
+	params := map[string]interface{}{
+		"index":  r.meta.fullTextIndexName(indexName),
+		"search": query,
+		"limit":  int64(limit),
+	}
+	eagerResult, err := r.run(ctx, cypher, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.scoredResults(ctx, eagerResult.Records)
+}
+
+// scoredResults maps the records of an index query, holding the matching node and its
+// score, into scored entities.
+func (r *Repository[T]) scoredResults(ctx context.Context, records []*Record) ([]ScoredResult[T], error) {
+	results := make([]ScoredResult[T], len(records))
+	for i, record := range records {
+		entity := new(T)
+		if err := mapRecordToStruct(record, entity, r.meta); err != nil {
+			return nil, err
+		}
+		if err := r.afterLoad(ctx, entity, i); err != nil {
+			return nil, err
+		}
+		score, _ := record.Get("score")
+		results[i].Entity = entity
+		results[i].Score, _ = score.(float64)
+	}
+	return results, nil
+}
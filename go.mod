@@ -3,6 +3,6 @@ module github.com/saulfrancisco-ruizacevedo/go-neopersist
 go 1.24.4
 
 require (
-	github.com/neo4j/neo4j-go-driver/v5 v5.28.3 // indirect
-	github.com/saulfrancisco-ruizacevedo/gocypher v1.0.0 // indirect
+	github.com/neo4j/neo4j-go-driver/v5 v5.28.3
+	github.com/saulfrancisco-ruizacevedo/gocypher v1.0.0
 )
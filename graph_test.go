@@ -0,0 +1,90 @@
+package neopersist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/examples/models"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// follows returns a FOLLOWS relationship from one user node to another.
+func follows(id string, from, to neopersist.Node) neopersist.Relationship {
+	return neopersist.Relationship{ElementID: id, StartElementID: from.ElementID, EndElementID: to.ElementID, Type: "FOLLOWS"}
+}
+
+// findGraph runs FindGraph on a FakeRunner answering with the given records.
+func findGraph(t *testing.T, records ...*neopersist.Record) *models.GraphResult {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: records}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+	qb := gocypher.NewQueryBuilder().Match(gocypher.N("u", "User")).Return("u")
+	graph, err := pm.FindGraph(context.Background(), qb)
+	if err != nil {
+		t.Fatalf("FindGraph: %v", err)
+	}
+	return graph
+}
+
+// edgeDirections returns TraversedReverse by edge ID.
+func edgeDirections(graph *models.GraphResult) map[string]bool {
+	directions := make(map[string]bool, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		directions[edge.ID] = edge.TraversedReverse
+	}
+	return directions
+}
+
+func TestFindGraphDerivesTraversalDirectionFromPaths(t *testing.T) {
+	a, b, c := userNode("a", "Ada"), userNode("b", "Bob"), userNode("c", "Cy")
+	ab := follows("r1", a, b) // walked forwards from a
+	cb := follows("r2", c, b) // walked backwards from b to c
+	path := neopersist.Path{Nodes: []neopersist.Node{a, b, c}, Relationships: []neopersist.Relationship{ab, cb}}
+
+	graph := findGraph(t, neopersist.NewRecord("p", path))
+
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 {
+		t.Fatalf("got %d nodes and %d edges, want 3 and 2", len(graph.Nodes), len(graph.Edges))
+	}
+	directions := edgeDirections(graph)
+	if directions["r1"] || !directions["r2"] {
+		t.Errorf("TraversedReverse = %v, want r1 false and r2 true", directions)
+	}
+	for _, edge := range graph.Edges {
+		if edge.ID == "r2" && (edge.Source != c.ElementID || edge.Target != b.ElementID) {
+			t.Errorf("r2 = %s->%s, want the stored direction %s->%s", edge.Source, edge.Target, c.ElementID, b.ElementID)
+		}
+	}
+}
+
+func TestFindGraphReportsBareRelationshipsInStoredDirection(t *testing.T) {
+	a, b := userNode("a", "Ada"), userNode("b", "Bob")
+	graph := findGraph(t, neopersist.NewRecord("a", a, "r", follows("r1", b, a), "b", b))
+
+	if directions := edgeDirections(graph); len(directions) != 1 || directions["r1"] {
+		t.Errorf("TraversedReverse = %v, want r1 false", directions)
+	}
+}
+
+func TestFindGraphKeysTraversalDirectionByRelationship(t *testing.T) {
+	a, b := userNode("a", "Ada"), userNode("b", "Bob")
+	forward := follows("r1", a, b)
+	backward := follows("r2", b, a)
+	// Both relationships connect the same nodes but are walked in opposite directions, and
+	// r2 is first seen as a bare relationship without direction information.
+	graph := findGraph(t,
+		neopersist.NewRecord("r", backward),
+		neopersist.NewRecord("p", neopersist.Path{Nodes: []neopersist.Node{a, b}, Relationships: []neopersist.Relationship{forward}}),
+		neopersist.NewRecord("p", neopersist.Path{Nodes: []neopersist.Node{a, b}, Relationships: []neopersist.Relationship{backward}}),
+	)
+
+	directions := edgeDirections(graph)
+	if len(directions) != 2 || directions["r1"] || !directions["r2"] {
+		t.Errorf("TraversedReverse = %v, want r1 false and r2 true", directions)
+	}
+}
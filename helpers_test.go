@@ -0,0 +1,57 @@
+package neopersist_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// User is the entity most tests run against.
+type User struct {
+	UserID string `crud:"pk,property:userId"`
+	Name   string `crud:"property:name"`
+	Email  string `crud:"property:email"`
+	Age    int64  `crud:"property:age"`
+}
+
+// newUserRepo returns a repository of User backed by a new FakeRunner.
+func newUserRepo(t testing.TB, opts ...neopersist.RepositoryOption) (*neopersist.Repository[User], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[User](runner, opts...)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	return repo, runner
+}
+
+// nodeResult returns a result holding one record per node, each under the key "n".
+func nodeResult(nodes ...neopersist.Node) *neopersist.ResultSet {
+	result := &neopersist.ResultSet{Keys: []string{"n"}}
+	for _, node := range nodes {
+		result.Records = append(result.Records, neopersist.NewRecord("n", node))
+	}
+	return result
+}
+
+// userNode returns a User node with the given key and name.
+func userNode(id, name string) neopersist.Node {
+	return neopersist.Node{
+		ElementID: "4:test:" + id,
+		Labels:    []string{"User"},
+		Props:     map[string]any{"userId": id, "name": name},
+	}
+}
+
+// setValue returns the value a recorded query SETs on n.<prop>. The builder numbers the
+// parameters of a SET clause in map order, so they are looked up through the query.
+func setValue(t testing.TB, call neopersisttest.Call, prop string) any {
+	t.Helper()
+	match := regexp.MustCompile(`n\.` + prop + ` = \$(\w+)`).FindStringSubmatch(call.Query)
+	if match == nil {
+		t.Fatalf("query does not set n.%s: %s", prop, call.Query)
+	}
+	return call.Params[match[1]]
+}
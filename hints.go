@@ -0,0 +1,127 @@
+package neopersist
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IndexHint describes a `USING INDEX` planner hint for a label and property.
+type IndexHint struct {
+	// Label is the node label the index was created for (e.g., "User").
+	Label string
+	// Property is the indexed property name (e.g., "email").
+	Property string
+}
+
+// UseIndex returns a QueryOption that instructs the Cypher planner to use the index on
+// the given label and property, by injecting `USING INDEX x:Label(property)` right after
+// the first top-level MATCH clause binding a node x with that label. It is accepted by
+// every finder that matches nodes, including Find and criteria queries whose builder uses
+// an alias other than n, and is useful when the planner picks a poor index on its own.
+// The label and property must be plain identifiers.
+//
+// Example:
+//
+//	users, err := userRepo.FindByProperty(ctx, "email", "a@b.c", neopersist.UseIndex("User", "email"))
+func UseIndex(label, property string) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optIndexHint
+		o.indexHints = append(o.indexHints, IndexHint{Label: label, Property: property})
+	}
+}
+
+// clause renders the hint as a Cypher `USING INDEX` clause for the given node alias.
+func (h IndexHint) clause(alias string) string {
+	return fmt.Sprintf("USING INDEX %s:%s(%s)", alias, h.Label, h.Property)
+}
+
+// String renders the hint as Label(property), for error messages.
+func (h IndexHint) String() string {
+	return fmt.Sprintf("%s(%s)", h.Label, h.Property)
+}
+
+// validate checks that the hint's label and property can be rendered into Cypher, since
+// hints cannot be passed as parameters.
+func (h IndexHint) validate() error {
+	if !identifierPattern.MatchString(h.Label) {
+		return fmt.Errorf("invalid index hint %s: '%s' is not a valid label", h, h.Label)
+	}
+	if !identifierPattern.MatchString(h.Property) {
+		return fmt.Errorf("invalid index hint %s: '%s' is not a valid property name", h, h.Property)
+	}
+	return nil
+}
+
+// applyIndexHints injects the given hints into a built query. Each hint is placed directly
+// after the first MATCH clause, outside any subquery, that binds a node with the hint's
+// label, and uses that node's alias. gocypher has no notion of planner hints, so the built
+// query string is post-processed instead; it renders each clause on its own line, which
+// makes the insertion point unambiguous.
+func applyIndexHints(query string, hints []IndexHint) (string, error) {
+	if len(hints) == 0 {
+		return query, nil
+	}
+
+	lines := strings.Split(query, "\n")
+	inserts := make(map[int][]string, len(hints))
+	for _, hint := range hints {
+		if err := hint.validate(); err != nil {
+			return "", err
+		}
+		line, alias, ok := hintTarget(lines, hint.Label)
+		if !ok {
+			return "", fmt.Errorf("cannot apply index hint %s: no MATCH clause binds a node labeled %s", hint, hint.Label)
+		}
+		inserts[line] = append(inserts[line], hint.clause(alias))
+	}
+
+	result := make([]string, 0, len(lines)+len(hints))
+	for i, line := range lines {
+		result = append(result, line)
+		result = append(result, inserts[i]...)
+	}
+	return strings.Join(result, "\n"), nil
+}
+
+// hintTarget finds the first top-level MATCH line binding a node labeled label, returning
+// its index and the node's alias. Lines inside braces (subqueries, maps spanning several
+// lines) are skipped, since a hint there would not affect the main query.
+func hintTarget(lines []string, label string) (int, string, bool) {
+	pattern := regexp.MustCompile(`\(([A-Za-z_][A-Za-z0-9_]*):` + regexp.QuoteMeta(label) + `[\s:{)]`)
+	depth := 0
+	for i, line := range lines {
+		isMatch := strings.HasPrefix(line, "MATCH ") || strings.HasPrefix(line, "OPTIONAL MATCH ")
+		if depth == 0 && isMatch {
+			if m := pattern.FindStringSubmatch(line); m != nil {
+				return i, m[1], true
+			}
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+	}
+	return 0, "", false
+}
+
+// indexHintRewriter is the QueryRewriter injecting the hints of a call into its query.
+type indexHintRewriter struct {
+	hints []IndexHint
+}
+
+// Rewrite implements QueryRewriter.
+func (rw *indexHintRewriter) Rewrite(query string, params map[string]any) (string, map[string]any, error) {
+	query, err := applyIndexHints(query, rw.hints)
+	return query, params, err
+}
+
+// annotateHintError adds the hints that were in effect to an error returned by the
+// database, so that a rejected hint can be told apart from other failures.
+func annotateHintError(err error, hints []IndexHint) error {
+	if err == nil || len(hints) == 0 {
+		return err
+	}
+	rendered := make([]string, len(hints))
+	for i, hint := range hints {
+		rendered[i] = "USING INDEX " + hint.String()
+	}
+	return fmt.Errorf("query with index hint [%s] failed: %w", strings.Join(rendered, ", "), err)
+}
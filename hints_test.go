@@ -0,0 +1,58 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+func TestUseIndexFollowsTheBuilderAlias(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	qb := gocypher.NewQueryBuilder().Match(gocypher.N("u", "User")).Return("u")
+
+	if _, err := repo.Find(context.Background(), qb, neopersist.UseIndex("User", "email")); err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (u:User)\nUSING INDEX u:User(email)\nRETURN u",
+	})
+}
+
+func TestUseIndexAppliesToPagedFinders(t *testing.T) {
+	repo, runner := newUserRepo(t)
+
+	if _, err := repo.FindPaged(context.Background(), neopersist.PageRequest{Limit: 10}, neopersist.UseIndex("User", "name")); err != nil {
+		t.Fatalf("FindPaged: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"MATCH (n:User)\nUSING INDEX n:User(name)"},
+	})
+}
+
+func TestUseIndexRejectsInvalidIdentifiers(t *testing.T) {
+	repo, runner := newUserRepo(t)
+
+	_, err := repo.FindAll(context.Background(), neopersist.UseIndex("User", "email) MATCH (x"))
+	if err == nil || !strings.Contains(err.Error(), "not a valid property name") {
+		t.Fatalf("expected an invalid hint error, got %v", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestUseIndexAnnotatesServerErrors(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	serverErr := errors.New("no such index")
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nil, serverErr
+	}
+
+	_, err := repo.FindByProperty(context.Background(), "email", "a@b.c", neopersist.UseIndex("User", "email"))
+	if !errors.Is(err, serverErr) || !strings.Contains(err.Error(), "USING INDEX User(email)") {
+		t.Fatalf("expected the server error annotated with the hint, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// BeforeSaver is implemented by entities that need to run logic before being written,
+// such as normalizing an email address. Save, SaveReturning, Create, Update, FindOrCreate
+// and SaveAll call BeforeSave on the entity pointer first; an error aborts the write
+// before anything is sent to the database.
+type BeforeSaver interface {
+	BeforeSave(ctx context.Context) error
+}
+
+// AfterLoader is implemented by entities that need to run logic after being loaded, such
+// as computing derived fields. The finders call AfterLoad on every entity they map; an
+// error is returned together with the loaded entities.
+type AfterLoader interface {
+	AfterLoad(ctx context.Context) error
+}
+
+// HookError reports a failed BeforeSave or AfterLoad hook.
+type HookError struct {
+	// Hook is the name of the failing hook ("BeforeSave" or "AfterLoad").
+	Hook string
+	// Label is the label of the entity type.
+	Label string
+	// Index is the position of the failing entity in a bulk operation or slice result,
+	// or -1 for single-entity operations.
+	Index int
+	// Err is the error returned by the hook.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *HookError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("%s hook of %s failed: %v", e.Hook, e.Label, e.Err)
+	}
+	return fmt.Sprintf("%s hook of %s at index %d failed: %v", e.Hook, e.Label, e.Index, e.Err)
+}
+
+// Unwrap returns the error returned by the hook.
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// entityHooks records which hook interfaces *T implements, determined once per repository
+// so that entities without hooks pay nothing per call.
+type entityHooks struct {
+	beforeSave bool
+	afterLoad  bool
+	validate   bool
+}
+
+var (
+	beforeSaverType = reflect.TypeOf((*BeforeSaver)(nil)).Elem()
+	afterLoaderType = reflect.TypeOf((*AfterLoader)(nil)).Elem()
+)
+
+// hooksFor inspects the hook interfaces implemented by *T.
+func hooksFor[T any]() entityHooks {
+	ptr := reflect.TypeOf((*T)(nil))
+	return entityHooks{
+		beforeSave: ptr.Implements(beforeSaverType),
+		afterLoad:  ptr.Implements(afterLoaderType),
+		validate:   ptr.Implements(validatorType),
+	}
+}
+
+// beforeSave runs the entity's BeforeSave hook, if it has one. index is the position of
+// the entity in a bulk operation, or -1.
+func (r *Repository[T]) beforeSave(ctx context.Context, entity *T, index int) error {
+	if !r.hooks.beforeSave || entity == nil {
+		return nil
+	}
+	if err := any(entity).(BeforeSaver).BeforeSave(ctx); err != nil {
+		return &HookError{Hook: "BeforeSave", Label: r.meta.Label, Index: index, Err: err}
+	}
+	return nil
+}
+
+// afterLoad runs the entity's AfterLoad hook, if it has one. index is the position of the
+// entity in a slice result, or -1.
+func (r *Repository[T]) afterLoad(ctx context.Context, entity *T, index int) error {
+	if !r.hooks.afterLoad || entity == nil {
+		return nil
+	}
+	if err := any(entity).(AfterLoader).AfterLoad(ctx); err != nil {
+		return &HookError{Hook: "AfterLoad", Label: r.meta.Label, Index: index, Err: err}
+	}
+	return nil
+}
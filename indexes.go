@@ -0,0 +1,232 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// indexMetadata is an index declared with the `index` tag component. Fields tagged
+// `index:<name>` with the same name share a composite index, in declaration order.
+type indexMetadata struct {
+	// Name is the name given with `index:<name>`, or empty for a single-property index.
+	Name string
+	// Fields are the names of the indexed fields.
+	Fields []string
+}
+
+// addIndex records the field fieldName as part of the index name, or as a single-property
+// index if name is empty.
+func (m *entityMetadata) addIndex(name, fieldName string) {
+	if name != "" {
+		for _, index := range m.Indexes {
+			if index.Name == name {
+				index.Fields = append(index.Fields, fieldName)
+				return
+			}
+		}
+	}
+	m.Indexes = append(m.Indexes, &indexMetadata{Name: name, Fields: []string{fieldName}})
+}
+
+// EnsureIndexes creates the range indexes declared on the entity type with the `index`
+// tag component, so that lookups such as FindByProperty do not scan the whole label. A
+// field tagged `index` gets its own index, named "<Label>_<property>_index"; fields tagged
+// `index:<name>` with the same name share a composite index over their properties, in
+// declaration order, named "<Label>_<name>". The full-text indexes declared with the
+// `fulltext:` tag component and the vector index of the field tagged `vector:` are created
+// too; see Search and SimilaritySearch. It is idempotent, so it can run at every startup.
+//
+// Primary keys and `unique` fields are indexed by their constraint (see
+// EnsureConstraints), so `index` on its own is ignored on them. Indexes are created with
+// `CREATE INDEX ... IF NOT EXISTS FOR (n:Label) ON (n.prop)`, which requires Neo4j 4.4
+// or later; a vector index requires Neo4j 5.15 or later.
+//
+// Example:
+//
+//	type Ticket struct {
+//	    ID     string `crud:"pk,property:id"`
+//	    Email  string `crud:"property:email,index"`
+//	    Tenant string `crud:"property:tenant,index:byTenantAndStatus"`
+//	    Status string `crud:"property:status,index:byTenantAndStatus"`
+//	}
+//	changes, err := ticketRepo.EnsureIndexes(ctx)
+//	log.Printf("created %v, already present %v", changes.Created, changes.Existing)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The indexes created and those that already existed, or an error if the repository
+//	is read-only or an index cannot be created.
+func (r *Repository[T]) EnsureIndexes(ctx context.Context, opts ...WriteOption) (*SchemaChanges, error) {
+	options, err := r.parseWriteOptions("EnsureIndexes", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	changes := &SchemaChanges{}
+	if err := ensureIndexes(ctx, r.runnerWith(options), r.meta, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// EnsureIndexes runs Repository.EnsureIndexes for each given entity type and aggregates
+// the outcome into a single report.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entityTypes: Values of, or pointers to, the entity structs to index.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The indexes created and those that already existed, or an error if a type has
+//	invalid tags or an index cannot be created.
+func (pm *PersistenceManager) EnsureIndexes(ctx context.Context, entityTypes []any, opts ...WriteOption) (*SchemaChanges, error) {
+	options, err := parseOptions("EnsureIndexes", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	changes := &SchemaChanges{}
+	for _, entityType := range entityTypes {
+		meta, err := pm.metadataFor(reflect.TypeOf(entityType))
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureIndexes(ctx, pm.runnerWith(options), meta, changes); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// ensureIndexes creates the declared indexes of one entity, recording them in changes.
+func ensureIndexes(ctx context.Context, runner DBRunner, meta *entityMetadata, changes *SchemaChanges) error {
+	if err := requireFeature(ctx, runner, features.SchemaCommands); err != nil {
+		return err
+	}
+	// The vector index is checked up front, so that no index is created if it cannot be.
+	if meta.Vector != nil {
+		if err := requireFeature(ctx, runner, features.VectorIndex); err != nil {
+			return err
+		}
+	}
+	for _, index := range meta.Indexes {
+		props := make([]string, len(index.Fields))
+		for i, fieldName := range index.Fields {
+			props[i] = "n." + meta.Mappings[fieldName]
+		}
+		name := meta.indexName(index)
+		query := fmt.Sprintf("CREATE INDEX %s IF NOT EXISTS\nFOR (n:%s)\nON (%s)", quoteIdentifier(name), meta.Label, strings.Join(props, ", "))
+		if err := ensureIndex(ctx, runner, name, query, changes); err != nil {
+			return err
+		}
+	}
+	for _, index := range meta.FullTextIndexes {
+		if err := ensureIndex(ctx, runner, meta.fullTextIndexName(index.Name), meta.fullTextIndexQuery(index), changes); err != nil {
+			return err
+		}
+	}
+	if meta.Vector != nil {
+		return ensureIndex(ctx, runner, meta.vectorIndexName(), meta.vectorIndexQuery(), changes)
+	}
+	return nil
+}
+
+// indexName returns the name of an index declared with the `index` tag component.
+func (m *entityMetadata) indexName(index *indexMetadata) string {
+	if index.Name == "" {
+		return m.Label + "_" + m.Mappings[index.Fields[0]] + "_index"
+	}
+	return m.Label + "_" + index.Name
+}
+
+// ensureIndex runs the statement creating the index name, recording it in changes.
+func ensureIndex(ctx context.Context, runner DBRunner, name, query string, changes *SchemaChanges) error {
+	result, err := runner.Run(ctx, query, nil)
+	switch {
+	case isSchemaAlreadyExists(err):
+		changes.Existing = append(changes.Existing, name)
+	case err != nil:
+		return fmt.Errorf("could not create index %s: %w", name, err)
+	case result.Counters.IndexesAdded > 0:
+		changes.Created = append(changes.Created, name)
+	default:
+		changes.Existing = append(changes.Existing, name)
+	}
+	return nil
+}
+
+// DropSchema drops the constraints and indexes EnsureConstraints and EnsureIndexes create
+// for the entity type, for example to rebuild them after a tag change or to clean up a
+// test database. Data is left untouched, but lookups fall back to label scans and
+// uniqueness is no longer enforced until the schema is created again.
+//
+// Because it removes every schema rule of the label, DropSchema must be confirmed with
+// ConfirmDestructive and fails with ErrDestructiveForbidden on repositories whose
+// manager disallows destructive operations.
+//
+// Example:
+//
+//	dropped, err := userRepo.DropSchema(ctx, neopersist.ConfirmDestructive())
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - opts: Per-call settings; ConfirmDestructive is required.
+//
+// Returns:
+//
+//	The names of the constraints and indexes that existed and were dropped, or an error
+//	if the operation was not allowed or a statement fails.
+func (r *Repository[T]) DropSchema(ctx context.Context, opts ...WriteOption) ([]string, error) {
+	options, err := r.parseWriteOptions("DropSchema", opts, writeOptions|optConfirmDestructive)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkDestructive("DropSchema", options); err != nil {
+		return nil, err
+	}
+	runner := r.runnerWith(options)
+	if err := requireFeature(ctx, runner, features.SchemaCommands); err != nil {
+		return nil, err
+	}
+
+	var statements, names []string
+	for _, prop := range r.meta.uniqueProperties() {
+		name := r.meta.constraintName(prop)
+		statements = append(statements, "DROP CONSTRAINT "+quoteIdentifier(name)+" IF EXISTS")
+		names = append(names, name)
+	}
+	indexNames := make([]string, 0, len(r.meta.Indexes)+len(r.meta.FullTextIndexes)+1)
+	for _, index := range r.meta.Indexes {
+		indexNames = append(indexNames, r.meta.indexName(index))
+	}
+	for _, index := range r.meta.FullTextIndexes {
+		indexNames = append(indexNames, r.meta.fullTextIndexName(index.Name))
+	}
+	if r.meta.Vector != nil {
+		indexNames = append(indexNames, r.meta.vectorIndexName())
+	}
+	for _, name := range indexNames {
+		statements = append(statements, "DROP INDEX "+quoteIdentifier(name)+" IF EXISTS")
+		names = append(names, name)
+	}
+
+	var dropped []string
+	for i, statement := range statements {
+		result, err := runner.Run(ctx, statement, nil)
+		if err != nil {
+			return dropped, fmt.Errorf("could not drop %s: %w", names[i], err)
+		}
+		if result.Counters.ConstraintsRemoved > 0 || result.Counters.IndexesRemoved > 0 {
+			dropped = append(dropped, names[i])
+		}
+	}
+	return dropped, nil
+}
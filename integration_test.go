@@ -0,0 +1,56 @@
+package neopersist_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+)
+
+// integrationExecutor connects to the database named by the NEO4J_TEST_URI environment
+// variable, with the credentials in NEO4J_TEST_USER and NEO4J_TEST_PASSWORD, and skips the
+// test when it is not set. Integration tests delete the data of the labels they use, so
+// the database must be disposable, such as a test container.
+func integrationExecutor(t *testing.T) *neopersist.Neo4jExecutor {
+	t.Helper()
+	uri := os.Getenv("NEO4J_TEST_URI")
+	if uri == "" {
+		t.Skip("NEO4J_TEST_URI is not set; skipping integration test")
+	}
+	user, password := os.Getenv("NEO4J_TEST_USER"), os.Getenv("NEO4J_TEST_PASSWORD")
+	if user == "" {
+		user = "neo4j"
+	}
+	database := os.Getenv("NEO4J_TEST_DATABASE")
+	if database == "" {
+		database = "neo4j"
+	}
+
+	executor, err := neopersist.NewNeo4jExecutor(uri, user, password, database)
+	if err != nil {
+		t.Fatalf("NewNeo4jExecutor: %v", err)
+	}
+	ctx := context.Background()
+	t.Cleanup(func() { _ = executor.Driver.Close(ctx) })
+	if err := executor.Verify(ctx); err != nil {
+		t.Fatalf("could not connect to %s: %v", uri, err)
+	}
+	return executor
+}
+
+// integrationRepo returns a repository of T on the integration database, emptied of T's
+// label before and after the test.
+func integrationRepo[T any](t *testing.T) *neopersist.Repository[T] {
+	t.Helper()
+	repo, err := neopersist.NewRepository[T](integrationExecutor(t))
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := repo.DeleteAll(ctx, neopersist.ConfirmDestructive()); err != nil {
+		t.Fatalf("DeleteAll: %v", err)
+	}
+	t.Cleanup(func() { _, _ = repo.DeleteAll(ctx, neopersist.ConfirmDestructive()) })
+	return repo
+}
@@ -0,0 +1,212 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// Iterator yields the entities of a streaming query one at a time, so that large labels
+// can be processed in constant memory. It must be closed once the caller is done, which
+// releases the underlying database session.
+type Iterator[T any] struct {
+	ctx     context.Context
+	repo    *Repository[T]
+	records RecordStream
+	err     error
+}
+
+// Next returns the next entity, or io.EOF once every entity has been returned. Any other
+// error, including the cancellation of the context the iterator was created with, closes
+// the iterator and is returned again by later calls.
+func (it *Iterator[T]) Next() (*T, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if err := it.ctx.Err(); err != nil {
+		return nil, it.fail(err)
+	}
+	record, err := it.records.Next(it.ctx)
+	if err != nil {
+		return nil, it.fail(err)
+	}
+	entity := new(T)
+	if err := mapRecordToStruct(record, entity, it.repo.meta); err != nil {
+		return nil, it.fail(err)
+	}
+	return entity, it.repo.afterLoad(it.ctx, entity, -1)
+}
+
+// Close releases the iterator's resources. It is safe to call repeatedly, and after Next
+// has returned an error.
+func (it *Iterator[T]) Close() error {
+	if it.err == nil {
+		it.err = io.EOF
+	}
+	return it.records.Close(context.WithoutCancel(it.ctx))
+}
+
+// fail records err as the terminal state of the iterator and closes the stream.
+func (it *Iterator[T]) fail(err error) error {
+	it.err = err
+	if closeErr := it.records.Close(context.WithoutCancel(it.ctx)); closeErr != nil && errors.Is(err, io.EOF) {
+		it.err = closeErr
+	}
+	return it.err
+}
+
+// FindAllIter is the streaming counterpart of FindAll: it returns an Iterator over all
+// entities of type T, fetched from the database in batches as the iterator advances
+// instead of being buffered, so it can walk labels with millions of nodes.
+//
+// Streaming requires a runner implementing StreamRunner, such as Neo4jExecutor; other
+// runners fall back to buffering the result, which keeps test fakes working. Per-call
+// settings keep the call streamed: WithTimeout bounds the iteration up to its last
+// entity, and OnDatabase and ReadOnly need a ConfigurableStreamRunner.
+//
+// Example:
+//
+//	it, err := userRepo.FindAllIter(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	defer it.Close()
+//	for {
+//	    user, err := it.Next()
+//	    if errors.Is(err, io.EOF) {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(user)
+//	}
+//
+// Parameters:
+//   - ctx: The context for the whole iteration; cancelling it stops the iterator.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	An Iterator over the entities, or an error if the query cannot be started.
+func (r *Repository[T]) FindAllIter(ctx context.Context, opts ...FindOption) (*Iterator[T], error) {
+	options, err := parseOptions("FindAllIter", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label)).
+		Return("n").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := r.stream(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return &Iterator[T]{ctx: ctx, repo: r, records: records}, nil
+}
+
+// ForEachChunk calls fn with successive chunks of at most chunkSize entities of type T,
+// until every entity has been processed, so that ETL jobs can walk a label without holding
+// it in memory. Chunks are read with keyset pagination in primary key order (see
+// FindAfter), so each chunk costs the same regardless of its position, and entities
+// created behind the current position during the walk are not visited.
+//
+// If fn returns an error, the walk stops and the error is returned wrapped with the index
+// of the failing chunk.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - chunkSize: The maximum number of entities per chunk; must be positive.
+//   - fn: The callback processing each chunk. It is never called with an empty chunk.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	nil once every chunk was processed, or the first error from a query or from fn.
+func (r *Repository[T]) ForEachChunk(ctx context.Context, chunkSize int, fn func([]*T) error, opts ...FindOption) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+	var cursor Cursor
+	for index := 0; ; index++ {
+		entities, next, err := r.FindAfter(ctx, cursor, int64(chunkSize), opts...)
+		if err != nil {
+			return fmt.Errorf("could not load chunk %d of %s: %w", index, r.meta.Label, err)
+		}
+		if len(entities) > 0 {
+			if err := fn(entities); err != nil {
+				return fmt.Errorf("chunk %d of %s: %w", index, r.meta.Label, err)
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// WithPageSize returns a QueryOption setting how many entities FindAllStream loads per
+// query. Values of zero or less keep the repository's batch size (see WithBatchSize).
+func WithPageSize(n int) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optPageSize
+		o.pageSize = n
+	}
+}
+
+// FindAllStream calls fn with every entity of type T, one at a time, loading them page by
+// page so that memory stays flat however large the label is, without needing a streaming
+// runner. Pages are read with keyset pagination on the primary key (see FindAfter), so
+// nodes created or deleted during the scan never cause duplicates; nodes created behind
+// the current position are not visited.
+//
+// Example:
+//
+//	err := userRepo.FindAllStream(ctx, func(u *models.User) error {
+//	    return encoder.Encode(u)
+//	}, neopersist.WithPageSize(500))
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - fn: The callback processing each entity.
+//   - opts: Optional per-call settings, such as WithPageSize or UseIndex.
+//
+// Returns:
+//
+//	nil once every entity was processed, the first error returned by fn, unwrapped, or an
+//	error if a query fails.
+func (r *Repository[T]) FindAllStream(ctx context.Context, fn func(*T) error, opts ...FindOption) error {
+	options, err := parseOptions("FindAllStream", opts, pageOptions|optPageSize)
+	if err != nil {
+		return err
+	}
+	pageSize := options.pageSize
+	if pageSize <= 0 {
+		pageSize = r.config.batchSizeOrDefault()
+	}
+	pageOpts := withoutOptions(opts, optPageSize)
+
+	var cursor Cursor
+	for {
+		entities, next, err := r.FindAfter(ctx, cursor, int64(pageSize), pageOpts...)
+		if err != nil {
+			return fmt.Errorf("could not load %s entities: %w", r.meta.Label, err)
+		}
+		for _, entity := range entities {
+			if err := fn(entity); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
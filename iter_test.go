@@ -0,0 +1,212 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// streamRunner is a FakeRunner that can stream: it answers Stream with a cursor over the
+// result Respond produces, and records how many queries were streamed.
+type streamRunner struct {
+	*neopersisttest.FakeRunner
+
+	mu       sync.Mutex
+	streamed int
+}
+
+func (s *streamRunner) Stream(ctx context.Context, query string, params map[string]interface{}) (neopersist.RecordStream, error) {
+	result, err := s.FakeRunner.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.streamed++
+	s.mu.Unlock()
+	return &sliceStream{records: result.Records}, nil
+}
+
+// configStreamRunner is a streamRunner that also honors per-call settings, recording them.
+type configStreamRunner struct {
+	streamRunner
+	configs []neopersist.CallConfig
+}
+
+func (s *configStreamRunner) RunWithConfig(ctx context.Context, query string, params map[string]interface{}, config neopersist.CallConfig) (*neopersist.ResultSet, error) {
+	return s.FakeRunner.Run(ctx, query, params)
+}
+
+func (s *configStreamRunner) StreamWithConfig(ctx context.Context, query string, params map[string]interface{}, config neopersist.CallConfig) (neopersist.RecordStream, error) {
+	s.configs = append(s.configs, config)
+	return s.Stream(ctx, query, params)
+}
+
+// sliceStream is a RecordStream over records that honors the context of each Next call.
+type sliceStream struct {
+	records []*neopersist.Record
+}
+
+func (s *sliceStream) Next(ctx context.Context) (*neopersist.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.records) == 0 {
+		return nil, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+func (s *sliceStream) Close(context.Context) error { return nil }
+
+func newStreamRunner() *streamRunner {
+	runner := &streamRunner{FakeRunner: neopersisttest.NewFakeRunner()}
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(userNode("u1", "Ada"), userNode("u2", "Grace")), nil
+	}
+	return runner
+}
+
+// drain reads every entity of it.
+func drain(t *testing.T, it *neopersist.Iterator[User]) []*User {
+	t.Helper()
+	defer it.Close()
+	var users []*User
+	for {
+		user, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return users
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		users = append(users, user)
+	}
+}
+
+func TestFindAllIterStreamsWithPerCallSettings(t *testing.T) {
+	runner := &configStreamRunner{streamRunner: *newStreamRunner()}
+	repo, err := neopersist.NewRepository[User](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	it, err := repo.FindAllIter(context.Background(), neopersist.OnDatabase("tenant_42"), neopersist.WithTimeout(time.Minute), neopersist.ReadOnly())
+	if err != nil {
+		t.Fatalf("FindAllIter: %v", err)
+	}
+	if users := drain(t, it); len(users) != 2 {
+		t.Errorf("iterated %d users, want 2", len(users))
+	}
+	want := neopersist.CallConfig{Database: "tenant_42", Timeout: time.Minute, ReadOnly: true}
+	if len(runner.configs) != 1 || runner.configs[0] != want {
+		t.Errorf("streamed with %+v, want one stream with %+v", runner.configs, want)
+	}
+}
+
+func TestFindAllIterKeepsStreamingWithATimeout(t *testing.T) {
+	runner := newStreamRunner()
+	repo, err := neopersist.NewRepository[User](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	it, err := repo.FindAllIter(context.Background(), neopersist.WithTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("FindAllIter: %v", err)
+	}
+	defer it.Close()
+	if runner.streamed != 1 {
+		t.Fatalf("streamed %d queries, want the timeout to keep the call streamed", runner.streamed)
+	}
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := it.Next(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Next after the timeout: err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFindAllIterRejectsSettingsTheStreamRunnerCannotApply(t *testing.T) {
+	runner := newStreamRunner()
+	repo, err := neopersist.NewRepository[User](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	if _, err := repo.FindAllIter(context.Background(), neopersist.OnDatabase("tenant_42")); err == nil {
+		t.Fatal("FindAllIter applied OnDatabase through a runner that cannot honor it")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+// pagedUsers answers keyset page queries with the given pages of user IDs, in order.
+func pagedUsers(pages ...[]string) func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+	return func(index int, _ string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		result := &neopersist.ResultSet{Keys: []string{"n", "cursorValue", "cursorId"}}
+		if index >= len(pages) {
+			return result, nil
+		}
+		for _, id := range pages[index] {
+			result.Records = append(result.Records, neopersist.NewRecord("n", userNode(id, id), "cursorValue", id, "cursorId", id))
+		}
+		return result, nil
+	}
+}
+
+func TestForEachChunkWalksEveryPage(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = pagedUsers([]string{"u1", "u2"}, []string{"u3", "u4"}, []string{"u5"})
+
+	var chunks [][]string
+	err := repo.ForEachChunk(context.Background(), 2, func(users []*User) error {
+		var ids []string
+		for _, user := range users {
+			ids = append(ids, user.UserID)
+		}
+		chunks = append(chunks, ids)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachChunk: %v", err)
+	}
+	if len(chunks) != 3 || len(chunks[0]) != 2 || len(chunks[2]) != 1 || chunks[2][0] != "u5" {
+		t.Errorf("chunks = %v, want [[u1 u2] [u3 u4] [u5]]", chunks)
+	}
+	neopersisttest.AssertCallCount(t, runner, 3)
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"WHERE", "ORDER BY n.userId", "LIMIT $pageLimit"},
+		Params:        map[string]any{"cursorId": "u2", "pageLimit": int64(2)},
+	})
+}
+
+func TestForEachChunkStopsAtTheFirstCallbackError(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = pagedUsers([]string{"u1", "u2"}, []string{"u3", "u4"}, []string{"u5"})
+	errStop := errors.New("stop")
+
+	calls := 0
+	err := repo.ForEachChunk(context.Background(), 2, func([]*User) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("err = %v, want it to wrap the callback error", err)
+	}
+	if !strings.Contains(err.Error(), "chunk 1 ") {
+		t.Errorf("err = %q, want it to name chunk 1", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 2)
+}
@@ -0,0 +1,64 @@
+package neopersist
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Neo4j properties cannot hold maps or nested structures, so fields tagged with the `json`
+// tag component, such as `crud:"property:metadata,json"` on a map[string]interface{}, are
+// stored as a JSON string property instead: encoded with encoding/json when saving and
+// decoded when loading. json.RawMessage fields are stored verbatim. A nil map, slice,
+// pointer or interface is written as null, which removes the property, so it loads back as
+// nil, while an empty map or slice is stored as "{}" or "[]" and loads back empty.
+
+// rawMessageType is the reflect.Type of json.RawMessage.
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// jsonPropertyValue encodes a field tagged `json` into the string written to the database.
+func jsonPropertyValue(field reflect.Value) (interface{}, error) {
+	switch field.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		if field.IsNil() {
+			return nil, nil
+		}
+	}
+	if field.Type() == rawMessageType {
+		if !json.Valid(field.Bytes()) {
+			return nil, fmt.Errorf("json.RawMessage holds invalid JSON")
+		}
+		return string(field.Bytes()), nil
+	}
+	data, err := json.Marshal(field.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// setJSONValue decodes a JSON string property into a field tagged `json`, replacing its
+// previous value. A null property sets the zero value, the inverse of jsonPropertyValue.
+func setJSONValue(field reflect.Value, value any) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("expected a JSON string, got %T", value)
+	}
+	if !json.Valid([]byte(text)) {
+		return fmt.Errorf("malformed JSON")
+	}
+	if field.Type() == rawMessageType {
+		field.SetBytes([]byte(text))
+		return nil
+	}
+	decoded := reflect.New(field.Type())
+	if err := json.Unmarshal([]byte(text), decoded.Interface()); err != nil {
+		return err
+	}
+	field.Set(decoded.Elem())
+	return nil
+}
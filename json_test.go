@@ -0,0 +1,177 @@
+package neopersist_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Layout is a nested structure stored as JSON.
+type Layout struct {
+	Columns int      `json:"columns"`
+	Widgets []string `json:"widgets"`
+}
+
+// Dashboard has properties of every kind stored as JSON.
+type Dashboard struct {
+	ID       string          `crud:"pk,property:id"`
+	Settings map[string]any  `crud:"property:settings,json"`
+	Layout   *Layout         `crud:"property:layout,json"`
+	Raw      json.RawMessage `crud:"property:raw,json"`
+}
+
+func newDashboardRepo(t *testing.T) (*neopersist.Repository[Dashboard], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Dashboard](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	return repo, runner
+}
+
+// dashboardNode returns a Dashboard node with the given properties.
+func dashboardNode(props map[string]any) neopersist.Node {
+	props["id"] = "d1"
+	return neopersist.Node{ElementID: "4:test:d1", Labels: []string{"Dashboard"}, Props: props}
+}
+
+func TestJSONFieldsKeepNilAndEmptyApart(t *testing.T) {
+	repo, runner := newDashboardRepo(t)
+	ctx := context.Background()
+
+	if err := repo.Save(ctx, &Dashboard{ID: "d1"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	call := runner.Calls()[0]
+	for _, prop := range []string{"settings", "layout", "raw"} {
+		if value := setValue(t, call, prop); value != nil {
+			t.Errorf("nil %s saved as %#v, want null", prop, value)
+		}
+	}
+
+	runner.Reset()
+	if err := repo.Save(ctx, &Dashboard{ID: "d1", Settings: map[string]any{}, Layout: &Layout{}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	call = runner.Calls()[0]
+	if value := setValue(t, call, "settings"); value != "{}" {
+		t.Errorf("empty settings saved as %#v, want {}", value)
+	}
+	if value := setValue(t, call, "layout"); value != `{"columns":0,"widgets":null}` {
+		t.Errorf("empty layout saved as %#v", value)
+	}
+
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(dashboardNode(map[string]any{"settings": "{}"})), nil
+	}
+	loaded, err := repo.FindByID(ctx, "d1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if loaded.Settings == nil || len(loaded.Settings) != 0 {
+		t.Errorf("Settings = %#v, want an empty map", loaded.Settings)
+	}
+	if loaded.Layout != nil || loaded.Raw != nil {
+		t.Errorf("absent properties loaded as %+v and %q, want nil", loaded.Layout, loaded.Raw)
+	}
+}
+
+func TestNullJSONPropertiesSetTheZeroValue(t *testing.T) {
+	repo, runner := newDashboardRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(dashboardNode(map[string]any{"settings": nil, "layout": nil, "raw": nil})), nil
+	}
+	dashboard := &Dashboard{
+		ID:       "d1",
+		Settings: map[string]any{"theme": "dark"},
+		Layout:   &Layout{Columns: 2},
+		Raw:      json.RawMessage(`[1]`),
+	}
+
+	if err := repo.Refresh(context.Background(), dashboard); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if dashboard.Settings != nil || dashboard.Layout != nil || dashboard.Raw != nil {
+		t.Errorf("dashboard = %+v, want null properties to clear the fields", dashboard)
+	}
+}
+
+func TestJSONFieldsRoundTrip(t *testing.T) {
+	repo, runner := newDashboardRepo(t)
+	ctx := context.Background()
+	raw := json.RawMessage(`{"b": [1, 2],  "a": null}`)
+	saved := &Dashboard{
+		ID:       "d1",
+		Settings: map[string]any{"theme": "dark", "zoom": 1.5},
+		Layout:   &Layout{Columns: 3, Widgets: []string{"clock"}},
+		Raw:      raw,
+	}
+
+	if err := repo.Save(ctx, saved); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	call := runner.Calls()[0]
+	// A json.RawMessage is stored verbatim, without being re-encoded.
+	if value := setValue(t, call, "raw"); value != string(raw) {
+		t.Errorf("raw saved as %#v, want %q", value, raw)
+	}
+
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(dashboardNode(map[string]any{
+			"settings": setValue(t, call, "settings"),
+			"layout":   setValue(t, call, "layout"),
+			"raw":      setValue(t, call, "raw"),
+		})), nil
+	}
+	loaded, err := repo.FindByID(ctx, "d1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, saved) {
+		t.Errorf("loaded %+v, want %+v", loaded, saved)
+	}
+}
+
+func TestMalformedJSON(t *testing.T) {
+	t.Run("load", func(t *testing.T) {
+		repo, runner := newDashboardRepo(t)
+		runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+			return nodeResult(dashboardNode(map[string]any{"raw": `{"unterminated": `})), nil
+		}
+
+		_, err := repo.FindByID(context.Background(), "d1")
+		var mapping *neopersist.MappingError
+		if !errors.As(err, &mapping) || mapping.Field != "Raw" || !strings.Contains(err.Error(), "malformed JSON") {
+			t.Fatalf("FindByID error = %v, want a *MappingError for Raw reporting malformed JSON", err)
+		}
+	})
+
+	t.Run("not a string", func(t *testing.T) {
+		repo, runner := newDashboardRepo(t)
+		runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+			return nodeResult(dashboardNode(map[string]any{"settings": int64(1)})), nil
+		}
+
+		if _, err := repo.FindByID(context.Background(), "d1"); err == nil || !strings.Contains(err.Error(), "expected a JSON string, got int64") {
+			t.Fatalf("FindByID error = %v, want the stored type", err)
+		}
+	})
+
+	t.Run("save", func(t *testing.T) {
+		repo, runner := newDashboardRepo(t)
+
+		err := repo.Save(context.Background(), &Dashboard{ID: "d1", Raw: json.RawMessage(`{`)})
+		var conversion *neopersist.ConversionError
+		if !errors.As(err, &conversion) || conversion.Field != "Raw" {
+			t.Fatalf("Save error = %v, want a *ConversionError for Raw", err)
+		}
+		neopersisttest.AssertCallCount(t, runner, 0)
+	})
+}
@@ -0,0 +1,88 @@
+package neopersist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// checkPKType reports an error unless typ, the type of the primary key field fieldName, is
+// a string or integer type, the kinds of values that identify a node reliably. Named types
+// such as `type UserID string` are accepted.
+func checkPKType(fieldName string, typ reflect.Type) error {
+	if !isStringKind(typ.Kind()) && !isIntegerKind(typ.Kind()) {
+		return fmt.Errorf("primary key field %s must be of a string or integer type, got %s", fieldName, typ)
+	}
+	return nil
+}
+
+// isStringKind reports whether k is reflect.String.
+func isStringKind(k reflect.Kind) bool {
+	return k == reflect.String
+}
+
+// isIntegerKind reports whether k is a signed or unsigned integer kind.
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// pkParam converts an ID passed to a lookup, such as FindByID or Delete, into the value of
+// the primary key property, so that it matches the stored keys whatever Go type the caller
+// used: integers of any width are accepted for integer keys, as are decimal strings, and
+// named string types for string keys. IDs that cannot be converted without loss, such as
+// an int for a string key or 300 for a uint8 key, are rejected.
+func (m *entityMetadata) pkParam(id interface{}) (interface{}, error) {
+	typ := m.Types[m.PKField]
+	if id == nil {
+		return nil, fmt.Errorf("primary key of entity type %s must not be nil", m.Label)
+	}
+	value := id
+	if s, ok := id.(string); ok && isIntegerKind(typ.Kind()) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot use id %q as primary key %s of entity type %s: %w", s, m.PKField, m.Label, err)
+		}
+		value = n
+	}
+	key := reflect.New(typ).Elem()
+	if err := setCoercedValue(key, value); err != nil {
+		return nil, fmt.Errorf("cannot use id %v of type %T as primary key %s of entity type %s: %w", id, id, m.PKField, m.Label, err)
+	}
+	return pkPropertyValue(key)
+}
+
+// pkPropertyValue returns the value of a primary key field as sent to the database: a
+// string or an int64, whatever the field's named or sized type.
+func pkPropertyValue(key reflect.Value) (interface{}, error) {
+	switch {
+	case isStringKind(key.Kind()):
+		return key.String(), nil
+	case key.CanInt():
+		return key.Int(), nil
+	case key.CanUint():
+		if key.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("primary key %d exceeds the range of Neo4j integers", key.Uint())
+		}
+		return int64(key.Uint()), nil
+	}
+	return key.Interface(), nil
+}
+
+// pkParams converts the IDs passed to a bulk lookup with pkParam.
+func (m *entityMetadata) pkParams(ids []interface{}) ([]interface{}, error) {
+	params := make([]interface{}, len(ids))
+	for i, id := range ids {
+		param, err := m.pkParam(id)
+		if err != nil {
+			return nil, fmt.Errorf("id at index %d: %w", i, err)
+		}
+		params[i] = param
+	}
+	return params, nil
+}
@@ -0,0 +1,209 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Invoice is keyed by an integer.
+type Invoice struct {
+	Number int64   `crud:"pk,property:number"`
+	Total  float64 `crud:"property:total"`
+}
+
+// SessionID is a UUID kept as a string.
+type SessionID string
+
+// Session is keyed by a UUID kept in a named string type.
+type Session struct {
+	ID     SessionID `crud:"pk,property:id"`
+	UserID string    `crud:"property:userId"`
+}
+
+const sessionUUID = "6f1c2a0e-8b3d-4f5e-9a7b-1c2d3e4f5a6b"
+
+// newKeyRepo returns a repository of T backed by a FakeRunner answering every query with
+// the given node.
+func newKeyRepo[T any](t *testing.T, node neopersist.Node) (*neopersist.Repository[T], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(node), nil
+	}
+	repo, err := neopersist.NewRepository[T](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	return repo, runner
+}
+
+func TestFindByIDConvertsIDsToTheKeyType(t *testing.T) {
+	ctx := context.Background()
+	invoiceNode := neopersist.Node{Labels: []string{"Invoice"}, Props: map[string]any{"number": int64(42), "total": 9.5}}
+	sessionNode := neopersist.Node{Labels: []string{"Session"}, Props: map[string]any{"id": sessionUUID, "userId": "u1"}}
+	tests := []struct {
+		name string
+		find func(t *testing.T) (*neopersisttest.FakeRunner, any, error)
+		want any
+	}{
+		{name: "string key", want: "u1", find: func(t *testing.T) (*neopersisttest.FakeRunner, any, error) {
+			repo, runner := newKeyRepo[User](t, userNode("u1", "Ada"))
+			user, err := repo.FindByID(ctx, "u1")
+			return runner, user, err
+		}},
+		{name: "int64 key from int", want: int64(42), find: func(t *testing.T) (*neopersisttest.FakeRunner, any, error) {
+			repo, runner := newKeyRepo[Invoice](t, invoiceNode)
+			invoice, err := repo.FindByID(ctx, 42)
+			return runner, invoice, err
+		}},
+		{name: "int64 key from uint8", want: int64(42), find: func(t *testing.T) (*neopersisttest.FakeRunner, any, error) {
+			repo, runner := newKeyRepo[Invoice](t, invoiceNode)
+			invoice, err := repo.FindByID(ctx, uint8(42))
+			return runner, invoice, err
+		}},
+		{name: "int64 key from decimal string", want: int64(42), find: func(t *testing.T) (*neopersisttest.FakeRunner, any, error) {
+			repo, runner := newKeyRepo[Invoice](t, invoiceNode)
+			invoice, err := repo.FindByID(ctx, "42")
+			return runner, invoice, err
+		}},
+		{name: "uuid key from string", want: sessionUUID, find: func(t *testing.T) (*neopersisttest.FakeRunner, any, error) {
+			repo, runner := newKeyRepo[Session](t, sessionNode)
+			session, err := repo.FindByID(ctx, sessionUUID)
+			return runner, session, err
+		}},
+		{name: "uuid key from named type", want: sessionUUID, find: func(t *testing.T) (*neopersisttest.FakeRunner, any, error) {
+			repo, runner := newKeyRepo[Session](t, sessionNode)
+			session, err := repo.FindByID(ctx, SessionID(sessionUUID))
+			return runner, session, err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, entity, err := tt.find(t)
+			if err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			calls := runner.Calls()
+			if len(calls) != 1 || !containsValue(calls[0].Params, tt.want) {
+				t.Fatalf("calls = %+v, want one lookup with the key %#v", calls, tt.want)
+			}
+			switch e := entity.(type) {
+			case *User:
+				if e.UserID != "u1" {
+					t.Errorf("UserID = %q, want u1", e.UserID)
+				}
+			case *Invoice:
+				if e.Number != 42 {
+					t.Errorf("Number = %d, want 42", e.Number)
+				}
+			case *Session:
+				if e.ID != SessionID(sessionUUID) {
+					t.Errorf("ID = %q, want %s", e.ID, sessionUUID)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyLookupsRejectIDsOfTheWrongType(t *testing.T) {
+	ctx := context.Background()
+	users, userRunner := newKeyRepo[User](t, userNode("u1", "Ada"))
+	invoices, invoiceRunner := newKeyRepo[Invoice](t, neopersist.Node{})
+	sessions, sessionRunner := newKeyRepo[Session](t, neopersist.Node{})
+
+	errs := map[string]error{}
+	_, errs["FindByID(int) on a string key"] = users.FindByID(ctx, 42)
+	_, errs["FindByID(nil)"] = users.FindByID(ctx, nil)
+	_, errs["FindByID(non-decimal string) on an int64 key"] = invoices.FindByID(ctx, "INV-42")
+	_, errs["FindByID(float64) on an int64 key"] = invoices.FindByID(ctx, 4.2)
+	errs["Delete(int) on a uuid key"] = sessions.Delete(ctx, 42)
+	_, errs["DeleteByIDs with a bad id"] = invoices.DeleteByIDs(ctx, []interface{}{int64(1), "two"})
+	for lookup, err := range errs {
+		if err == nil {
+			t.Errorf("%s: expected an error", lookup)
+		}
+	}
+	for _, runner := range []*neopersisttest.FakeRunner{userRunner, invoiceRunner, sessionRunner} {
+		neopersisttest.AssertCallCount(t, runner, 0)
+	}
+}
+
+func TestDeletesConvertIDsToTheKeyType(t *testing.T) {
+	ctx := context.Background()
+	invoices, invoiceRunner := newKeyRepo[Invoice](t, neopersist.Node{})
+	sessions, sessionRunner := newKeyRepo[Session](t, neopersist.Node{})
+
+	if err := invoices.Delete(ctx, "42"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := invoices.DeleteByIDs(ctx, []interface{}{1, int32(2), "3"}); err != nil {
+		t.Fatalf("DeleteByIDs: %v", err)
+	}
+	if err := sessions.Delete(ctx, SessionID(sessionUUID)); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	neopersisttest.AssertQuery(t, invoiceRunner, neopersisttest.Expect{
+		Query:  "MATCH (n:Invoice {number: $number}) DETACH DELETE n",
+		Params: map[string]any{"number": int64(42)},
+	})
+	neopersisttest.AssertQuery(t, invoiceRunner, neopersisttest.Expect{
+		QueryContains: []string{"n.number IN $ids"},
+		Params:        map[string]any{"ids": []interface{}{int64(1), int64(2), int64(3)}},
+	})
+	neopersisttest.AssertQuery(t, sessionRunner, neopersisttest.Expect{
+		Query:  "MATCH (n:Session {id: $id}) DETACH DELETE n",
+		Params: map[string]any{"id": sessionUUID},
+	})
+}
+
+func TestProjectionsMapKeysBackToTheKeyType(t *testing.T) {
+	ctx := context.Background()
+	invoiceRunner := neopersisttest.NewFakeRunner()
+	invoiceRunner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("number", int64(42), "total", 9.5)}}, nil
+	}
+	invoices, err := neopersist.NewRepository[Invoice](invoiceRunner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	sessionRunner := neopersisttest.NewFakeRunner()
+	sessionRunner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("id", sessionUUID, "userId", "u1")}}, nil
+	}
+	sessions, err := neopersist.NewRepository[Session](sessionRunner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	found, err := invoices.FindRaw(ctx, "MATCH (n:Invoice) RETURN n.number AS number, n.total AS total", nil)
+	if err != nil || len(found) != 1 || found[0].Number != 42 {
+		t.Errorf("FindRaw on Invoice = %+v, %v; want invoice 42", found, err)
+	}
+	opened, err := sessions.FindRaw(ctx, "MATCH (n:Session) RETURN n.id AS id, n.userId AS userId", nil)
+	if err != nil || len(opened) != 1 || opened[0].ID != SessionID(sessionUUID) {
+		t.Errorf("FindRaw on Session = %+v, %v; want session %s", opened, err, sessionUUID)
+	}
+}
+
+func TestPrimaryKeysMustBeStringsOrIntegers(t *testing.T) {
+	type Measurement struct {
+		Value float64 `crud:"pk,property:value"`
+	}
+	if _, err := neopersist.NewRepository[Measurement](neopersisttest.NewFakeRunner()); !errors.Is(err, neopersist.ErrInvalidTags) {
+		t.Fatalf("NewRepository: err = %v, want ErrInvalidTags", err)
+	}
+}
+
+// containsValue reports whether params holds want under any name.
+func containsValue(params map[string]interface{}, want any) bool {
+	for _, value := range params {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Document is an entity whose extra labels are kept in sync with a field.
+type Document struct {
+	ID     string   `crud:"pk,property:id"`
+	Labels []string `crud:"labels:sync"`
+}
+
+func TestSaveSyncingLabelsRequiresDynamicLabels(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.ServerVersion = "5.25"
+	repo, err := neopersist.NewRepository[Document](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	err = repo.Save(context.Background(), &Document{ID: "d1", Labels: []string{"Draft"}})
+	if !errors.Is(err, features.ErrUnsupportedServerVersion) {
+		t.Fatalf("Save on 5.25: err = %v, want ErrUnsupportedServerVersion", err)
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+
+	runner.ServerVersion = "5.26"
+	if err := repo.Save(context.Background(), &Document{ID: "d1", Labels: []string{"Draft"}}); err != nil {
+		t.Fatalf("Save on 5.26: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"REMOVE n:$(staleLabels)"},
+		Params:        map[string]any{"primaryLabel": "Document", "keepLabels": []string{"Draft"}},
+	})
+}
@@ -0,0 +1,125 @@
+package neopersist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrResultTruncated is matched, via errors.Is, by the *ResultTruncatedError returned
+// when a finder hits the cap set with WithMaxResults.
+var ErrResultTruncated = errors.New("result truncated")
+
+// ResultTruncatedError reports that a finder returned only the first Limit entities
+// because more matched than the repository's WithMaxResults cap allows. The finder
+// returns the truncated entities alongside it.
+type ResultTruncatedError struct {
+	// Label is the label of the queried entities.
+	Label string
+	// Limit is the cap that was hit.
+	Limit int64
+}
+
+// Error implements the error interface.
+func (e *ResultTruncatedError) Error() string {
+	return fmt.Sprintf("%s: more than %d %s entities matched", ErrResultTruncated, e.Limit, e.Label)
+}
+
+// Unwrap allows errors.Is(err, ErrResultTruncated) to match a *ResultTruncatedError.
+func (e *ResultTruncatedError) Unwrap() error {
+	return ErrResultTruncated
+}
+
+// WithMaxResults returns a RepositoryOption capping the number of entities the slice
+// finders (FindAll, FindByProperty, FindByProperties, Find, Where(...).Find and the like)
+// load per call, as a central guard against unbounded queries exhausting memory.
+//
+// Queries that do not end with a LIMIT get one appended, set one above the cap so that
+// overflow can be detected. When more than n entities match, the finder returns the first
+// n together with a *ResultTruncatedError, which callers can treat as a warning:
+//
+//	users, err := userRepo.FindAll(ctx)
+//	if errors.Is(err, neopersist.ErrResultTruncated) {
+//	    log.Printf("showing the first %d users only", len(users))
+//	} else if err != nil {
+//	    return err
+//	}
+//
+// Queries that already end with a LIMIT are sent unchanged, but their results are still
+// capped. A value of zero or less disables the guard, which is the default.
+func WithMaxResults(n int64) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.maxResults = n
+	}
+}
+
+// parseListOptions parses the options of a slice finder and applies the repository's
+// result cap to the call.
+func (r *Repository[T]) parseListOptions(operation string, opts []QueryOption) (*queryOptions, error) {
+	options, err := parseOptions(operation, opts, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	if r.config.maxResults > 0 {
+		options.resultCap = r.config.maxResults
+	}
+	return options, nil
+}
+
+// queryClauseKeywords are the keywords starting a clause, any of which following a LIMIT
+// means that the LIMIT does not end the query.
+var queryClauseKeywords = map[string]bool{
+	"MATCH": true, "OPTIONAL": true, "WITH": true, "RETURN": true, "UNWIND": true,
+	"CALL": true, "UNION": true, "CREATE": true, "MERGE": true, "SET": true,
+	"DELETE": true, "DETACH": true, "REMOVE": true, "FOREACH": true, "ORDER": true,
+	"SKIP": true, "OFFSET": true, "LOAD": true, "USE": true, "FINISH": true, "YIELD": true,
+	"WHERE": true,
+}
+
+// limitKeyword matches the LIMIT keyword in hasTrailingLimit.
+var limitKeyword = map[string]bool{"LIMIT": true}
+
+// hasTrailingLimit reports whether the query ends with a top-level LIMIT clause. A LIMIT
+// inside a subquery or a WITH, a property named limit and the word in a string literal do
+// not bound the query's result, and do not count.
+func hasTrailingLimit(query string) bool {
+	tokens, err := scanCypher(query)
+	if err != nil {
+		return false
+	}
+	last := -1
+	for i, token := range tokens {
+		if token.depth == 0 && token.isKeyword(limitKeyword) {
+			last = i
+		}
+	}
+	if last < 0 {
+		return false
+	}
+	for _, token := range tokens[last+1:] {
+		if token.depth == 0 && token.isKeyword(queryClauseKeywords) {
+			return false
+		}
+	}
+	return true
+}
+
+// resultCapRewriter appends a LIMIT to queries that do not end with one, fetching one
+// record more than the cap so that truncation can be detected. It runs after every other
+// rewriter, so that it lands after any ORDER BY they add.
+type resultCapRewriter struct {
+	limit int64
+}
+
+// Rewrite implements QueryRewriter. The limit is passed as $maxResults, or under a fresh
+// name if the query already uses that parameter.
+func (rw *resultCapRewriter) Rewrite(query string, params map[string]any) (string, map[string]any, error) {
+	if hasTrailingLimit(query) {
+		return query, params, nil
+	}
+	capped := &paramSet{values: make(map[string]interface{}, len(params)+1)}
+	for k, v := range params {
+		capped.values[k] = v
+	}
+	name := capped.add("maxResults", rw.limit+1)
+	return query + "\nLIMIT $" + name, capped.values, nil
+}
@@ -0,0 +1,51 @@
+package neopersist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+func TestMaxResultsOnlyTrustsATrailingTopLevelLimit(t *testing.T) {
+	for _, tc := range []struct {
+		query  string
+		capped bool
+	}{
+		{query: "MATCH (n:User) RETURN n", capped: true},
+		{query: "MATCH (n:User) RETURN n LIMIT 5", capped: false},
+		{query: "MATCH (n:User) RETURN n ORDER BY n.name limit $top", capped: false},
+		{query: "MATCH (n:User) WHERE n.limit > 3 RETURN n", capped: true},
+		{query: "MATCH (n:User) WHERE n.note = 'no LIMIT here' RETURN n", capped: true},
+		{query: "MATCH (n:User) WITH n LIMIT 10 RETURN n", capped: true},
+		{query: "MATCH (n:User) CALL { WITH n MATCH (n)-[:WROTE]->(p) RETURN p LIMIT 1 } RETURN n", capped: true},
+	} {
+		repo, runner := newUserRepo(t, neopersist.WithMaxResults(100))
+		if _, err := repo.FindRaw(context.Background(), tc.query, map[string]interface{}{"top": 5}); err != nil {
+			t.Fatalf("FindRaw(%q): %v", tc.query, err)
+		}
+		want := tc.query
+		if tc.capped {
+			want += " LIMIT $maxResults"
+		}
+		neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: want})
+	}
+}
+
+func TestMaxResultsKeepsACallerParameterNamedMaxResults(t *testing.T) {
+	repo, runner := newUserRepo(t, neopersist.WithMaxResults(100))
+	params := map[string]interface{}{"maxResults": 3}
+
+	if _, err := repo.FindRaw(context.Background(), "MATCH (n:User) WHERE n.rank <= $maxResults RETURN n", params); err != nil {
+		t.Fatalf("FindRaw: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query:       "MATCH (n:User) WHERE n.rank <= $maxResults RETURN n LIMIT $maxResults_1",
+		Params:      map[string]any{"maxResults": 3, "maxResults_1": int64(101)},
+		ExactParams: true,
+	})
+	if len(params) != 1 {
+		t.Errorf("caller's parameters were modified: %v", params)
+	}
+}
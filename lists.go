@@ -0,0 +1,79 @@
+package neopersist
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Neo4j list properties are homogeneous lists of primitive values. A slice field, such as
+// []string or []int64, is written as such a list and read back from the []any the runner
+// returns for it, converting every element to the slice's element type. A nil slice is
+// written as null, which removes the property, so it loads back as nil; an empty slice is
+// stored as an empty list and loads back as an empty, non-nil slice.
+
+// checkListType reports an error if typ, the type of the mapped field fieldName, is a
+// slice whose elements Neo4j cannot store in a list property. Slices of interfaces are
+// accepted here and checked element by element when saving; see checkListValues.
+func checkListType(fieldName string, typ reflect.Type) error {
+	if typ.Kind() != reflect.Slice {
+		return nil
+	}
+	if elem := typ.Elem(); elem.Kind() != reflect.Interface && !isListElemType(elem) {
+		return fmt.Errorf("field %s is a slice of %s, but list properties can only hold strings, booleans, numbers, times and points", fieldName, elem)
+	}
+	return nil
+}
+
+// isListElemType reports whether values of typ can be stored as list property elements.
+func isListElemType(typ reflect.Type) bool {
+	if typ == timeType || typ == pointType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// checkListValues checks the elements of the entity's mapped slice-of-interface fields,
+// whose element types are only known at run time, before they are saved.
+func (m *entityMetadata) checkListValues(val reflect.Value) error {
+	for fieldName, typ := range m.Types {
+		if typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Interface || m.JSONFields[fieldName] {
+			continue
+		}
+		field := m.field(val, fieldName)
+		if !field.IsValid() {
+			continue
+		}
+		for i := 0; i < field.Len(); i++ {
+			elem := field.Index(i)
+			if elem.IsNil() || !isListElemType(elem.Elem().Type()) {
+				return fmt.Errorf("field %s of entity type %s holds a %T at index %d, which cannot be stored in a list property",
+					fieldName, m.Label, elem.Interface(), i)
+			}
+		}
+	}
+	return nil
+}
+
+// setListValue assigns a list property to a slice field, converting each element to the
+// slice's element type as setPropertyValue would, including numeric widths and times.
+func (m *entityMetadata) setListValue(field reflect.Value, list []any) error {
+	slice := reflect.MakeSlice(field.Type(), len(list), len(list))
+	for i, item := range list {
+		elem := slice.Index(i)
+		if m.setTimeValue(elem, item) {
+			continue
+		}
+		if err := setCoercedValue(elem, item); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	field.Set(slice)
+	return nil
+}
@@ -0,0 +1,242 @@
+package neopersist
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// lockLabel is the label of the nodes backing advisory locks.
+const lockLabel = "NeopersistLock"
+
+// ErrLockLost is reported by AdvisoryLock.Err when the lock expired and was taken over by
+// another owner, or its node was removed, before it was released.
+var ErrLockLost = errors.New("advisory lock lost")
+
+// AdvisoryLock is a cluster-wide mutual exclusion lock stored as a node in the database,
+// used to serialize work such as schema bootstrapping across application replicas. The
+// holder keeps it alive with a background heartbeat; if the holder dies, the lock expires
+// after its TTL and can be taken over by another instance.
+//
+// Expiry is evaluated with the database clock, so replicas with skewed clocks agree on it.
+// For the lock to be exclusive even when it is acquired for the first time by several
+// replicas at once, create a uniqueness constraint on :NeopersistLock(name).
+type AdvisoryLock struct {
+	runner DBRunner
+	name   string
+	owner  string
+	ttl    time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// AcquireLock blocks until the named advisory lock is acquired or ctx is done. An existing
+// lock whose holder stopped sending heartbeats for longer than ttl is taken over.
+//
+// Example:
+//
+//	lock, err := manager.AcquireLock(ctx, "schema-bootstrap", 30*time.Second)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lock.Release(context.Background())
+//
+// Parameters:
+//   - ctx: The context bounding the wait; it does not bound how long the lock is held.
+//   - name: The name identifying the lock across instances.
+//   - ttl: How long the lock survives without a heartbeat. Heartbeats are sent every ttl/3.
+//   - opts: Optional settings, such as OnDatabase or WithTimeout, applied to every query
+//     on the lock, including heartbeats and the release.
+//
+// Returns:
+//
+//	The held lock, or an error if ctx is done first or a query fails.
+func (pm *PersistenceManager) AcquireLock(ctx context.Context, name string, ttl time.Duration, opts ...WriteOption) (*AdvisoryLock, error) {
+	options, err := parseOptions("AcquireLock", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	return pm.acquireLock(ctx, name, ttl, options)
+}
+
+// acquireLock implements AcquireLock, running the lock's queries with options.
+func (pm *PersistenceManager) acquireLock(ctx context.Context, name string, ttl time.Duration, options *queryOptions) (*AdvisoryLock, error) {
+	if name == "" {
+		return nil, fmt.Errorf("advisory lock name must not be empty")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("advisory lock ttl must be positive, got %s", ttl)
+	}
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, err
+	}
+	lock := &AdvisoryLock{runner: pm.runnerWith(options), name: name, owner: owner, ttl: ttl}
+
+	retry := time.NewTicker(lockRetryInterval(ttl))
+	defer retry.Stop()
+	for {
+		acquired, err := lock.tryAcquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("could not acquire advisory lock '%s': %w", name, ctx.Err())
+		case <-retry.C:
+		}
+	}
+
+	heartbeatCtx, cancel := context.WithCancel(context.Background())
+	lock.cancel = cancel
+	lock.done = make(chan struct{})
+	go lock.heartbeat(heartbeatCtx)
+	return lock, nil
+}
+
+// tryAcquire takes the lock if it is free, expired, or already held by this owner.
+// Setting a property first takes the node's write lock, so concurrent attempts on the
+// same lock node are serialized and only one of them sees it as free.
+func (l *AdvisoryLock) tryAcquire(ctx context.Context) (bool, error) {
+	query := fmt.Sprintf(
+		"MERGE (l:%s {name: $name})\n"+
+			"SET l.claiming = true\n"+
+			"WITH l, (l.owner IS NULL OR l.owner = $owner OR l.expiresAt < timestamp()) AS acquired\n"+
+			"FOREACH (_ IN CASE WHEN acquired THEN [1] ELSE [] END |\n"+
+			"  SET l.owner = $owner, l.expiresAt = timestamp() + $ttlMillis)\n"+
+			"REMOVE l.claiming\n"+
+			"RETURN acquired",
+		lockLabel,
+	)
+	result, err := l.runner.Run(ctx, query, l.params())
+	if err != nil {
+		return false, fmt.Errorf("could not acquire advisory lock '%s': %w", l.name, err)
+	}
+	if len(result.Records) == 0 {
+		return false, nil
+	}
+	acquired, _ := result.Records[0].Get("acquired")
+	ok, _ := acquired.(bool)
+	return ok, nil
+}
+
+// heartbeat extends the lock's expiry every ttl/3 until the lock is released or lost.
+func (l *AdvisoryLock) heartbeat(ctx context.Context) {
+	defer close(l.done)
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	query := fmt.Sprintf(
+		"MATCH (l:%s {name: $name, owner: $owner})\n"+
+			"SET l.expiresAt = timestamp() + $ttlMillis\n"+
+			"RETURN l.name AS name",
+		lockLabel,
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		result, err := l.runner.Run(ctx, query, l.params())
+		if err != nil {
+			// A failed heartbeat is retried on the next tick; the TTL covers transient errors.
+			continue
+		}
+		if len(result.Records) == 0 {
+			l.setErr(fmt.Errorf("lock '%s': %w", l.name, ErrLockLost))
+			return
+		}
+	}
+}
+
+// Done returns a channel that is closed once the heartbeat stops, either because the lock
+// was released or because it was lost. Long-running work should stop when it is closed
+// and Err returns ErrLockLost.
+func (l *AdvisoryLock) Done() <-chan struct{} {
+	return l.done
+}
+
+// Err returns an error wrapping ErrLockLost if the lock was taken over before being released.
+func (l *AdvisoryLock) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Release stops the heartbeat and deletes the lock node if this instance still owns it.
+// It returns an error wrapping ErrLockLost if the lock had already been taken over.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	l.cancel()
+	<-l.done
+	if err := l.Err(); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("MATCH (l:%s {name: $name, owner: $owner})\nDELETE l", lockLabel)
+	_, err := l.runner.Run(ctx, query, l.params())
+	if err != nil {
+		return fmt.Errorf("could not release advisory lock '%s': %w", l.name, err)
+	}
+	return nil
+}
+
+func (l *AdvisoryLock) params() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      l.name,
+		"owner":     l.owner,
+		"ttlMillis": l.ttl.Milliseconds(),
+	}
+}
+
+func (l *AdvisoryLock) setErr(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.err = err
+}
+
+// lockRetryInterval is how often a waiting AcquireLock call retries: a tenth of the TTL,
+// bounded so that short TTLs do not hammer the database and long ones do not stall.
+func lockRetryInterval(ttl time.Duration) time.Duration {
+	interval := ttl / 10
+	if interval < 100*time.Millisecond {
+		return 100 * time.Millisecond
+	}
+	if interval > 5*time.Second {
+		return 5 * time.Second
+	}
+	return interval
+}
+
+// newLockOwner returns a random identifier for a lock holder.
+func newLockOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate advisory lock owner: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// isSchemaAlreadyExists reports whether err was raised because an equivalent index or
+// constraint already exists, which schema bootstrapping run concurrently by several
+// replicas treats as success.
+func isSchemaAlreadyExists(err error) bool {
+	switch dbErrorCode(err) {
+	case "Neo.ClientError.Schema.EquivalentSchemaRuleAlreadyExists",
+		"Neo.ClientError.Schema.IndexAlreadyExists",
+		"Neo.ClientError.Schema.ConstraintAlreadyExists":
+		return true
+	}
+	return false
+}
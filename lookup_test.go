@@ -0,0 +1,57 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// uniqueLookups runs each unique lookup of repo and returns its error.
+func uniqueLookups(repo *neopersist.Repository[User]) map[string]error {
+	ctx := context.Background()
+	_, findByID := repo.FindByID(ctx, "u1")
+	_, findOne := repo.FindOne(ctx, gocypher.NewQueryBuilder().Match(gocypher.N("n", "User")).Return("n"))
+	_, queryFindOne := repo.Where(neopersist.Field("Name").Eq("Ada")).FindOne(ctx)
+	return map[string]error{
+		"FindByID":      findByID,
+		"FindOne":       findOne,
+		"Query.FindOne": queryFindOne,
+	}
+}
+
+func TestUniqueLookupsReportMultipleFound(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(userNode("u1", "Ada"), userNode("u1", "Ada"), userNode("u1", "Ada")), nil
+	}
+
+	for lookup, err := range uniqueLookups(repo) {
+		if !errors.Is(err, neopersist.ErrMultipleFound) {
+			t.Errorf("%s: err = %v, want ErrMultipleFound", lookup, err)
+			continue
+		}
+		var multiple *neopersist.MultipleFoundError
+		if !errors.As(err, &multiple) || multiple.Count != 3 || multiple.Label != "User" {
+			t.Errorf("%s: err = %#v, want a *MultipleFoundError for 3 User records", lookup, err)
+		}
+		if !strings.Contains(err.Error(), "expected 1 record but found 3") {
+			t.Errorf("%s: message %q lost the record count", lookup, err)
+		}
+		if errors.Is(err, neopersist.ErrNotFound) {
+			t.Errorf("%s: err = %v also matches ErrNotFound", lookup, err)
+		}
+	}
+}
+
+func TestUniqueLookupsReportNotFound(t *testing.T) {
+	repo, _ := newUserRepo(t)
+	for lookup, err := range uniqueLookups(repo) {
+		if !errors.Is(err, neopersist.ErrNotFound) || errors.Is(err, neopersist.ErrMultipleFound) {
+			t.Errorf("%s: err = %v, want ErrNotFound only", lookup, err)
+		}
+	}
+}
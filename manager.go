@@ -2,12 +2,15 @@ package neopersist
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
+	"time"
 
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/saulfrancisco-ruizacevedo/go-neopersist/examples/models"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
 	"github.com/saulfrancisco-ruizacevedo/gocypher"
 )
 
@@ -18,22 +21,68 @@ type PersistenceManager struct {
 	runner DBRunner
 	// metaCache stores parsed entityMetadata to avoid costly reflection on every call.
 	metaCache sync.Map
+	// forbidDestructive is inherited by repositories; see AllowDestructive.
+	forbidDestructive bool
+	// provenance stamps created relationships; see WithRelationshipProvenance.
+	provenance *relationshipProvenance
+	// countersMu guards counters, the specs registered with MaintainCounter.
+	countersMu sync.RWMutex
+	counters   []CounterSpec
+	// validators maps *T types to the RepositoryOption registered with RegisterValidator.
+	validators sync.Map
+	// converters maps field types to the PropertyConverter registered with RegisterConverter.
+	converters sync.Map
+	// enumParsers maps enum types to the RepositoryOption registered with RegisterEnumParser.
+	enumParsers sync.Map
+	// lockTTL is the TTL of the migration lock; see WithMigrationLockTTL.
+	lockTTL time.Duration
 }
 
+// ManagerOption configures a PersistenceManager when it is created.
+type ManagerOption func(*PersistenceManager)
+
 // NewPersistenceManager creates a new instance of the PersistenceManager.
-func NewPersistenceManager(runner DBRunner) *PersistenceManager {
-	return &PersistenceManager{runner: runner}
+// Optional ManagerOption values, such as AllowDestructive, configure manager-wide policies.
+func NewPersistenceManager(runner DBRunner, opts ...ManagerOption) *PersistenceManager {
+	pm := &PersistenceManager{runner: runner}
+	for _, opt := range opts {
+		opt(pm)
+	}
+	return pm
 }
 
 // RepositoryFor is a generic function that creates and returns a repository
 // for a specific struct type T, managed by the given PersistenceManager.
-func RepositoryFor[T any](pm *PersistenceManager) (*Repository[T], error) {
-	return NewRepository[T](pm.runner)
+// The repository inherits the manager's policies, the validator registered for T with
+// RegisterValidator, and the converters and enum parsers registered with RegisterConverter
+// and RegisterEnumParser; optional
+// RepositoryOption values are passed through to NewRepository and take precedence.
+// The tags of T are parsed once per manager, on first use or by RegisterEntities, and
+// the cached metadata is copied for each repository.
+func RepositoryFor[T any](pm *PersistenceManager, opts ...RepositoryOption) (*Repository[T], error) {
+	meta, err := pm.metadataFor(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	inherited := []RepositoryOption{func(c *repositoryConfig) {
+		c.forbidDestructive = pm.forbidDestructive
+	}}
+	if validator := pm.validatorOption(reflect.TypeOf((*T)(nil))); validator != nil {
+		inherited = append(inherited, validator)
+	}
+	inherited = append(inherited, pm.converterOptions()...)
+	inherited = append(inherited, pm.enumParserOptions()...)
+	return newRepository[T](pm.runner, meta.clone(), append(inherited, opts...))
 }
 
 // CreateRelation creates a directed relationship between two existing entities in the database.
 // It uses reflection to find the entities' primary keys and labels to build the query.
-func (pm *PersistenceManager) CreateRelation(ctx context.Context, fromEntity any, toEntity any, relType string, relProps map[string]interface{}) error {
+// Optional WriteOption values, such as OnDatabase or WithTimeout, apply to the call.
+func (pm *PersistenceManager) CreateRelation(ctx context.Context, fromEntity any, toEntity any, relType string, relProps map[string]interface{}, opts ...WriteOption) error {
+	options, err := parseOptions("CreateRelation", opts, writeOptions)
+	if err != nil {
+		return err
+	}
 	fromMeta, fromPKVal, err := pm.getEntityMetaAndPK(fromEntity)
 	if err != nil {
 		return err
@@ -43,27 +92,163 @@ func (pm *PersistenceManager) CreateRelation(ctx context.Context, fromEntity any
 		return err
 	}
 
-	qb := gocypher.NewQueryBuilder().
-		Match(gocypher.N("a", fromMeta.Label).WithProperties(map[string]interface{}{fromMeta.PKProp: fromPKVal})).
-		Match(gocypher.N("b", toMeta.Label).WithProperties(map[string]interface{}{toMeta.PKProp: toPKVal})).
+	// Provenance properties never override the caller's own values.
+	if provenance := pm.provenanceProperties(ctx); provenance != nil {
+		merged := make(map[string]interface{}, len(relProps)+len(provenance))
+		for k, v := range provenance {
+			merged[k] = v
+		}
+		for k, v := range relProps {
+			merged[k] = v
+		}
+		relProps = merged
+	}
+
+	// The endpoints are matched by hand, like in Relate, so that entities of the same
+	// type (or sharing a key property name) do not compete for one parameter name.
+	create, params, err := gocypher.NewQueryBuilder().
 		Create(
 			gocypher.N("a", ""), // Reference the 'a' alias without its label
 			gocypher.R("r", relType).To().WithProperties(relProps),
 			gocypher.N("b", ""), // Reference the 'b' alias without its label
-		)
-
-	query, params, err := qb.Build()
+		).
+		Build()
 	if err != nil {
 		return err
 	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	for _, name := range []string{"fromId", "toId"} {
+		if _, clash := params[name]; clash {
+			return fmt.Errorf("relationship property '%s' clashes with a query parameter of CreateRelation", name)
+		}
+	}
+	params["fromId"], params["toId"] = fromPKVal, toPKVal
+	query := fmt.Sprintf("MATCH (a:%s {%s: $fromId})\nMATCH (b:%s {%s: $toId})\n%s",
+		fromMeta.Label, fromMeta.PKProp, toMeta.Label, toMeta.PKProp, create)
+	if updates := pm.counterUpdates(fromMeta.Label, "a", toMeta.Label, "b", relType, "1"); len(updates) > 0 {
+		query += "\nSET " + strings.Join(updates, ", ")
+	}
 
-	_, err = pm.runner.Run(ctx, query, params)
+	_, err = pm.run(ctx, query, params, options)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Relate creates a relationship between two existing entities as declared by a relationship
+// field on the source entity, instead of a free-form relationship type string. The
+// relationship type and direction are read from the field's tag, e.g.
+// `crud:"rel:WROTE,direction:in"` on Post.Author, and the target entity's type must match
+// the field's type. The relationship is MERGEd, so relating the same pair twice is a no-op.
+//
+// Example:
+//
+//	err := manager.Relate(ctx, &post, "Author", &user) // (user)-[:WROTE]->(post)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - fromEntity: A pointer to the entity declaring the relationship field.
+//   - field: The name of the relationship field on fromEntity's struct.
+//   - toEntity: A pointer to the related entity.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	An error if the field is not a declared relationship, the target type does not match,
+//	or the query fails.
+func (pm *PersistenceManager) Relate(ctx context.Context, fromEntity any, field string, toEntity any, opts ...WriteOption) error {
+	options, err := parseOptions("Relate", opts, writeOptions)
+	if err != nil {
+		return err
+	}
+	fromMeta, fromPKVal, err := pm.getEntityMetaAndPK(fromEntity)
+	if err != nil {
+		return err
+	}
+	rel, ok := fromMeta.Relations[field]
+	if !ok {
+		return fmt.Errorf("field '%s' is not a declared relationship on entity type %s", field, fromMeta.Label)
+	}
+	if toType := reflect.TypeOf(toEntity); toType == nil || toType.Kind() != reflect.Ptr || toType.Elem() != rel.TargetType {
+		return fmt.Errorf("relationship field '%s' on %s expects a *%s, got %v", field, fromMeta.Label, rel.TargetType.Name(), toType)
+	}
+	toMeta, toPKVal, err := pm.getEntityMetaAndPK(toEntity)
+	if err != nil {
+		return err
+	}
+
+	pattern := "(a)-[r:%s]->(b)"
+	if rel.Direction == Incoming {
+		pattern = "(a)<-[r:%s]-(b)"
+	}
+	query := fmt.Sprintf(
+		"MATCH (a:%s {%s: $fromId})\n"+
+			"MATCH (b:%s {%s: $toId})\n"+
+			"MERGE "+pattern,
+		fromMeta.Label, fromMeta.PKProp,
+		toMeta.Label, toMeta.PKProp,
+		rel.Type,
+	)
+	params := map[string]interface{}{"fromId": fromPKVal, "toId": toPKVal}
+
+	// Provenance and counters are only updated when the MERGE creates the relationship.
+	var onCreate []string
+	if provenance := pm.provenanceProperties(ctx); len(provenance) > 0 {
+		onCreate = append(onCreate, "r += $provenance")
+		params["provenance"] = provenance
+	}
+	if rel.Direction == Incoming {
+		onCreate = append(onCreate, pm.counterUpdates(toMeta.Label, "b", fromMeta.Label, "a", rel.Type, "1")...)
+	} else {
+		onCreate = append(onCreate, pm.counterUpdates(fromMeta.Label, "a", toMeta.Label, "b", rel.Type, "1")...)
+	}
+	if len(onCreate) > 0 {
+		query += "\nON CREATE SET " + strings.Join(onCreate, ", ")
+	}
+
+	_, err = pm.run(ctx, query, params, options)
+	return err
+}
+
+// run executes a query issued by the manager with the per-call options given to it: the
+// RewriteQuery rewriters, then the OnDatabase, WithTimeout and ReadOnly settings.
+func (pm *PersistenceManager) run(ctx context.Context, query string, params map[string]interface{}, options *queryOptions) (*ResultSet, error) {
+	if options == nil {
+		options = newQueryOptions(nil)
+	}
+	query, params, err := applyRewriters(query, params, options.rewriters)
+	if err != nil {
+		return nil, err
+	}
+	return execute(ctx, pm.runner, query, params, options.call)
+}
+
+// runnerWith returns a DBRunner that routes queries through the manager's run with the
+// given options, for helpers that accept a DBRunner.
+func (pm *PersistenceManager) runnerWith(options *queryOptions) DBRunner {
+	return &managerRunner{pm: pm, options: options}
+}
+
+// managerRunner is the DBRunner returned by PersistenceManager.runnerWith. It forwards
+// feature checks to the manager's runner.
+type managerRunner struct {
+	pm      *PersistenceManager
+	options *queryOptions
+}
+
+// Run executes the query through the manager's run.
+func (mr *managerRunner) Run(ctx context.Context, query string, params map[string]interface{}) (*ResultSet, error) {
+	return mr.pm.run(ctx, query, params, mr.options)
+}
+
+// RequireFeature checks the feature against the manager's runner.
+func (mr *managerRunner) RequireFeature(ctx context.Context, f features.Feature) error {
+	return requireFeature(ctx, mr.pm.runner, f)
+}
+
 // getEntityMetaAndPK is an internal helper that retrieves an entity's metadata and primary key value.
 // It uses a cache to optimize performance by avoiding repeated reflection.
 func (pm *PersistenceManager) getEntityMetaAndPK(entity any) (*entityMetadata, any, error) {
@@ -72,25 +257,130 @@ func (pm *PersistenceManager) getEntityMetaAndPK(entity any) (*entityMetadata, a
 		return nil, nil, fmt.Errorf("entity must be a non-nil pointer")
 	}
 
-	typ := val.Elem().Type()
+	meta, err := pm.metadataFor(val.Elem().Type())
+	if err != nil {
+		return nil, nil, err
+	}
+	pkValue := val.Elem().FieldByName(meta.PKField).Interface()
+	return meta, pkValue, nil
+}
+
+// RegisterEntities parses and caches the metadata of the given entity types, so that
+// misconfigured models fail the service at startup rather than at first use, and later
+// calls need no reflection. Every type is checked, even after a failure.
+//
+// Example:
+//
+//	if err := manager.RegisterEntities(models.User{}, models.Post{}); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Parameters:
+//   - entityTypes: Values of, or pointers to, the entity structs to register.
+//
+// Returns:
+//
+//	nil if every type is valid, or the *TagError of each invalid type, joined.
+func (pm *PersistenceManager) RegisterEntities(entityTypes ...any) error {
+	var errs []error
+	for _, entityType := range entityTypes {
+		if _, err := pm.metadataFor(reflect.TypeOf(entityType)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// metadataFor returns the parsed metadata of a struct type (or pointer to one).
+// It uses a cache to optimize performance by avoiding repeated reflection.
+func (pm *PersistenceManager) metadataFor(typ reflect.Type) (*entityMetadata, error) {
+	if typ == nil {
+		return nil, fmt.Errorf("entity type must not be nil")
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
 
 	// First, attempt to load metadata from the cache for performance.
 	if cached, ok := pm.metaCache.Load(typ); ok {
-		meta := cached.(*entityMetadata)
-		pkValue := val.Elem().FieldByName(meta.PKField).Interface()
-		return meta, pkValue, nil
+		return cached.(*entityMetadata), nil
 	}
 
 	// If not found in cache, parse the tags using reflection.
 	meta, err := parseTagsFromType(typ)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	// Store the newly parsed metadata in the cache for future use.
 	pm.metaCache.Store(typ, meta)
+	return meta, nil
+}
 
-	pkValue := val.Elem().FieldByName(meta.PKField).Interface()
-	return meta, pkValue, nil
+// BackfillAliases migrates legacy property names declared with the `aliases:` tag
+// component into their primary property. For every alias, in tag order, it copies the
+// alias value into the primary property unless the node already has one, then removes the
+// alias key. Nodes are processed in batches of 1000 so that large labels do not exceed the
+// transaction memory limit. Running it again after it has completed is a no-op.
+//
+// Example:
+//
+//	n, err := manager.BackfillAliases(ctx, models.User{})
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entityType: A value of, or pointer to, the entity struct whose aliases are migrated.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout, applied to
+//     every batch.
+//
+// Returns:
+//
+//	The number of legacy properties removed, or an error if a batch fails. On error the
+//	count reflects the batches that completed.
+func (pm *PersistenceManager) BackfillAliases(ctx context.Context, entityType any, opts ...WriteOption) (int64, error) {
+	options, err := parseOptions("BackfillAliases", opts, writeOptions)
+	if err != nil {
+		return 0, err
+	}
+	meta, err := pm.metadataFor(reflect.TypeOf(entityType))
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for fieldName, aliases := range meta.Aliases {
+		propName := meta.Mappings[fieldName]
+		for _, alias := range aliases {
+			query := fmt.Sprintf(
+				"MATCH (n:%s) WHERE n.%s IS NOT NULL\n"+
+					"WITH n LIMIT $batchSize\n"+
+					"SET n.%s = coalesce(n.%s, n.%s)\n"+
+					"REMOVE n.%s\n"+
+					"RETURN count(n) AS migrated",
+				meta.Label, alias,
+				propName, propName, alias,
+				alias,
+			)
+			params := map[string]interface{}{"batchSize": defaultBatchSize}
+
+			for {
+				result, err := pm.run(ctx, query, params, options)
+				if err != nil {
+					return total, fmt.Errorf("could not backfill alias '%s' of %s.%s: %w", alias, meta.Label, fieldName, err)
+				}
+				var migrated int64
+				if len(result.Records) > 0 {
+					if v, ok := result.Records[0].Get("migrated"); ok {
+						migrated, _ = v.(int64)
+					}
+				}
+				total += migrated
+				if migrated < defaultBatchSize {
+					break
+				}
+			}
+		}
+	}
+	return total, nil
 }
 
 // FindGraph executes a graph query defined by a gocypher.QueryBuilder and maps the result
@@ -102,28 +392,42 @@ func (pm *PersistenceManager) getEntityMetaAndPK(entity any) (*entityMetadata, a
 //
 // The caller is responsible for constructing a valid query via the QueryBuilder, including
 // a RETURN clause that specifies which nodes and relationships should be included in the
-// final graph. For example, `RETURN u, r, p`.
+// final graph. For example, `RETURN u, r, p`. Returned paths are expanded into their nodes
+// and relationships, and edges walked against their stored direction are flagged with
+// Edge.TraversedReverse, per relationship. Bare relationship returns do not carry the
+// traversal direction and report TraversedReverse as false, unless the same relationship
+// is also part of a returned path; return the path to get it. A relationship walked in
+// both directions by different paths reports the direction of the first path.
 //
 // The function intelligently de-duplicates nodes and relationships, ensuring that even if a
 // graph element is returned in multiple rows of the result set, it will only appear
 // once in the final GraphResult.
 //
+// Note that de-duplication happens after the records are buffered, so the executor's
+// MaxBufferedRecords guard counts result rows, not unique graph elements. Large traversals
+// may need a per-call override via WithRecordLimit.
+//
 // Parameters:
 //   - ctx: The context for the query execution.
 //   - qb: A pointer to a configured gocypher.QueryBuilder instance that defines the graph to retrieve.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or ReadOnly.
 //
 // Returns:
 //   - A pointer to a models.GraphResult containing the de-duplicated nodes and edges from the query.
 //   - An ErrNotFound error if the query executes successfully but returns zero records.
 //   - Any other error encountered during query building or execution.
-func (pm *PersistenceManager) FindGraph(ctx context.Context, qb *gocypher.QueryBuilder) (*models.GraphResult, error) {
+func (pm *PersistenceManager) FindGraph(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (*models.GraphResult, error) {
+	options, err := parseOptions("FindGraph", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
 	// 1. Build and execute the query provided by the client.
-	query, params, err := qb.Build()
+	query, params, err := buildQuery("FindGraph", "", qb)
 	if err != nil {
-		return nil, fmt.Errorf("could not build query: %w", err)
+		return nil, err
 	}
 
-	eagerResult, err := pm.runner.Run(ctx, query, params)
+	eagerResult, err := pm.run(ctx, query, params, options)
 	if err != nil {
 		return nil, err
 	}
@@ -138,37 +442,66 @@ func (pm *PersistenceManager) FindGraph(ctx context.Context, qb *gocypher.QueryB
 		Edges: make([]*models.Edge, 0),
 	}
 	seenNodeIDs := make(map[string]bool)
-	seenEdgeIDs := make(map[string]bool)
+	seenEdges := make(map[string]*models.Edge)
+	// directed records the relationships whose traversal direction came from a path.
+	directed := make(map[string]bool)
+
+	addNode := func(v Node) {
+		// If this node has not been seen yet, process and add it.
+		if !seenNodeIDs[v.ElementID] {
+			graph.Nodes = append(graph.Nodes, &models.GraphNode{
+				ID:         v.ElementID,
+				Labels:     v.Labels,
+				Properties: v.Props,
+			})
+			seenNodeIDs[v.ElementID] = true
+		}
+	}
+	addEdge := func(v Relationship) *models.Edge {
+		// If this relationship has not been seen yet, process and add it.
+		edge, seen := seenEdges[v.ElementID]
+		if !seen {
+			edge = &models.Edge{
+				ID:         v.ElementID,
+				Source:     v.StartElementID,
+				Target:     v.EndElementID,
+				Type:       v.Type,
+				Properties: v.Props,
+			}
+			graph.Edges = append(graph.Edges, edge)
+			seenEdges[v.ElementID] = edge
+		}
+		return edge
+	}
 
 	// 3. Iterate over the records and their values to populate the graph.
 	for _, record := range eagerResult.Records {
 		// Iterate over each value in the result row (e.g., the returned u, r, p).
 		for _, value := range record.Values {
 
-			// Use a type switch to process nodes and relationships from the result.
+			// Use a type switch to process nodes, relationships and paths from the result.
 			switch v := value.(type) {
-			case neo4j.Node:
-				// If this node has not been seen yet, process and add it.
-				if !seenNodeIDs[v.ElementId] {
-					graph.Nodes = append(graph.Nodes, &models.GraphNode{
-						ID:         v.ElementId,
-						Labels:     v.Labels,
-						Properties: v.Props,
-					})
-					seenNodeIDs[v.ElementId] = true
-				}
+			case Node:
+				addNode(v)
 
-			case neo4j.Relationship:
-				// If this relationship has not been seen yet, process and add it.
-				if !seenEdgeIDs[v.ElementId] {
-					graph.Edges = append(graph.Edges, &models.Edge{
-						ID:         v.ElementId,
-						Source:     v.StartElementId,
-						Target:     v.EndElementId,
-						Type:       v.Type,
-						Properties: v.Props,
-					})
-					seenEdgeIDs[v.ElementId] = true
+			case Relationship:
+				// A bare relationship carries no traversal information, so it is reported
+				// in its stored direction unless a path says otherwise.
+				addEdge(v)
+
+			case Path:
+				// Segment i of a path goes from Nodes[i] to Nodes[i+1]; if the relationship
+				// does not start at Nodes[i], the query walked it backwards. The direction
+				// is taken from the first path containing each relationship.
+				for _, node := range v.Nodes {
+					addNode(node)
+				}
+				for i, rel := range v.Relationships {
+					edge := addEdge(rel)
+					if !directed[rel.ElementID] {
+						edge.TraversedReverse = rel.StartElementID != v.Nodes[i].ElementID
+						directed[rel.ElementID] = true
+					}
 				}
 			}
 		}
@@ -176,3 +509,43 @@ func (pm *PersistenceManager) FindGraph(ctx context.Context, qb *gocypher.QueryB
 
 	return graph, nil
 }
+
+// QueryDescription describes the output of a query without its data.
+type QueryDescription struct {
+	// Keys are the names of the columns the query returns, in order.
+	Keys []string
+}
+
+// DescribeQuery reports the output columns of a query without executing it, by running it
+// prefixed with EXPLAIN so that only the plan is computed. Report and BI integrations can
+// use it to build column pickers. EXPLAIN does not expose value types, so only the column
+// names are reported.
+//
+// The builder is only built, never modified, so the caller can reuse it afterwards.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - qb: A configured gocypher.QueryBuilder instance that defines the query.
+//   - opts: Optional per-call settings: OnDatabase, WithTimeout or ReadOnly.
+//
+// Returns:
+//
+//	A QueryDescription with the ordered column names, or an error if the query cannot be
+//	built or planned.
+func (pm *PersistenceManager) DescribeQuery(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (*QueryDescription, error) {
+	options, err := parseOptions("DescribeQuery", opts, readCallOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("DescribeQuery", "", qb)
+	if err != nil {
+		return nil, err
+	}
+
+	eagerResult, err := pm.run(ctx, "EXPLAIN "+query, params, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryDescription{Keys: resultKeys(eagerResult)}, nil
+}
@@ -0,0 +1,65 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+func TestRepositoriesOfAManagerKeepTheirOwnSettings(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{Labels: []string{"User"}, Props: map[string]any{"userId": "u1", "NAME": "Ada"}}), nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+	if err := pm.RegisterEntities(User{}); err != nil {
+		t.Fatalf("RegisterEntities: %v", err)
+	}
+
+	lenient, err := neopersist.RepositoryFor[User](pm, neopersist.LenientPropertyMatching())
+	if err != nil {
+		t.Fatalf("RepositoryFor: %v", err)
+	}
+	strict, err := neopersist.RepositoryFor[User](pm)
+	if err != nil {
+		t.Fatalf("RepositoryFor: %v", err)
+	}
+
+	if user, err := lenient.FindByID(context.Background(), "u1"); err != nil || user.Name != "Ada" {
+		t.Errorf("lenient FindByID = %+v, %v; want the NAME property matched", user, err)
+	}
+	if user, err := strict.FindByID(context.Background(), "u1"); err != nil || user.Name != "" {
+		t.Errorf("strict FindByID = %+v, %v; want NAME left unmatched", user, err)
+	}
+}
+
+func TestRepositoryForReportsInvalidTags(t *testing.T) {
+	type Unkeyed struct {
+		Name string `crud:"property:name"`
+	}
+	pm := neopersist.NewPersistenceManager(neopersisttest.NewFakeRunner())
+	for range 2 {
+		if _, err := neopersist.RepositoryFor[Unkeyed](pm); !errors.Is(err, neopersist.ErrInvalidTags) {
+			t.Fatalf("RepositoryFor: err = %v, want ErrInvalidTags", err)
+		}
+	}
+}
+
+func TestCreateRelationBetweenEntitiesOfTheSameType(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+
+	err := pm.CreateRelation(context.Background(), &User{UserID: "u2"}, &User{UserID: "u1"}, "FOLLOWS",
+		map[string]interface{}{"since": int64(2024)})
+	if err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query:       "MATCH (a:User {userId: $fromId}) MATCH (b:User {userId: $toId}) CREATE (a)-[r:FOLLOWS {since: $since}]->(b)",
+		Params:      map[string]any{"fromId": "u2", "toId": "u1", "since": int64(2024)},
+		ExactParams: true,
+	})
+}
@@ -0,0 +1,446 @@
+package neopersist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MappingError describes a value that could not be mapped onto an entity field.
+type MappingError struct {
+	// ElementID is the ElementId of the node being mapped, or empty for projections.
+	ElementID string
+	// Key is the primary key value of the node being mapped, or nil if unknown.
+	Key any
+	// Field is the name of the struct field that could not be set.
+	Field string
+	// Property is the database property or result column the value was read from, or
+	// empty if unknown.
+	Property string
+	// Err is the underlying cause.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *MappingError) Error() string {
+	subject := "field " + e.Field
+	if e.Property != "" {
+		subject = fmt.Sprintf("property %s to field %s", e.Property, e.Field)
+	}
+	switch {
+	case e.ElementID != "" && e.Key != nil:
+		return fmt.Sprintf("could not map %s of node %s (key %v): %v", subject, e.ElementID, e.Key, e.Err)
+	case e.ElementID != "":
+		return fmt.Sprintf("could not map %s of node %s: %v", subject, e.ElementID, e.Err)
+	case e.Key != nil:
+		return fmt.Sprintf("could not map %s of node with key %v: %v", subject, e.Key, e.Err)
+	}
+	return fmt.Sprintf("could not map %s: %v", subject, e.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+// MappingErrors aggregates the records skipped by a finder called with CollectMappingErrors.
+type MappingErrors struct {
+	Errors []*MappingError
+}
+
+// Error implements the error interface.
+func (e *MappingErrors) Error() string {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return fmt.Sprintf("%d record(s) could not be mapped:\n%v", len(e.Errors), errors.Join(errs...))
+}
+
+// Unwrap exposes the individual mapping errors to errors.Is and errors.As.
+func (e *MappingErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// mapNodeToStruct is an internal helper function that populates a struct's fields
+// from a Node's properties, based on the parsed metadata.
+func mapNodeToStruct(node Node, entity any, meta *entityMetadata) error {
+	val := reflect.ValueOf(entity).Elem()
+
+	for fieldName, propName := range meta.Mappings {
+		key := propName
+		propValue, ok := node.Props[propName]
+		if !ok {
+			// Fall back to legacy property names; the first one present wins.
+			for _, alias := range meta.Aliases[fieldName] {
+				if propValue, ok = node.Props[alias]; ok {
+					key = alias
+					break
+				}
+			}
+		}
+		if !ok && meta.LenientMatching {
+			key, ok = foldedPropertyKey(node.Props, propName, meta.Aliases[fieldName])
+			propValue = node.Props[key]
+		}
+		if !ok {
+			continue // Skip if the property does not exist on the node.
+		}
+
+		// Nested structs behind pointers are only allocated once one of their properties exists.
+		field := meta.settableField(val, fieldName)
+		if !field.IsValid() || !field.CanSet() {
+			continue // Skip if the struct field cannot be set.
+		}
+
+		// Set the struct field's value.
+		if err := meta.setPropertyValue(fieldName, field, propValue); err != nil {
+			return &MappingError{ElementID: node.ElementID, Key: node.Props[meta.PKProp], Field: fieldName, Property: key, Err: err}
+		}
+	}
+	if meta.ElementIDField != "" {
+		if field := meta.field(val, meta.ElementIDField); field.CanSet() {
+			field.SetString(node.ElementID)
+		}
+	}
+	if meta.LabelsField != "" {
+		if field := meta.field(val, meta.LabelsField); field.CanSet() {
+			field.Set(reflect.ValueOf(append([]string(nil), node.Labels...)))
+		}
+	}
+	return nil
+}
+
+// foldedPropertyKey finds the property of props whose name equals propName, or else one of
+// the aliases, ignoring case. When several properties match a name, the first in lexical
+// order is returned, so that the result does not depend on map iteration.
+func foldedPropertyKey(props map[string]any, propName string, aliases []string) (string, bool) {
+	for _, name := range append([]string{propName}, aliases...) {
+		found := ""
+		for key := range props {
+			if strings.EqualFold(key, name) && (found == "" || key < found) {
+				found = key
+			}
+		}
+		if found != "" {
+			return found, true
+		}
+	}
+	return "", false
+}
+
+// mapRecordToStruct hydrates an entity from a single result record.
+//   - If a full Node is returned (e.g., `RETURN u`), it is mapped with mapNodeToStruct.
+//   - Otherwise the struct is populated property by property from projected columns
+//     (e.g., `RETURN u.name, u.email`), or from the columns named by `alias:` tag
+//     components, leaving unmatched fields at their zero value.
+func mapRecordToStruct(record *Record, entity any, meta *entityMetadata) error {
+	// Optimization: Check if a full node is present in the result. If so, map it directly.
+	// This is a common case (e.g., RETURN n) and is more efficient.
+	for _, value := range record.Values {
+		if node, ok := value.(Node); ok {
+			if err := mapNodeToStruct(node, entity, meta); err != nil {
+				return err
+			}
+			return mapComputedFields(record, entity, meta, node.ElementID, node.Props[meta.PKProp])
+		}
+	}
+
+	// The result did not contain a full node, so hydrate the struct property by property.
+	// The primary key is read first, so that errors can name the record they come from.
+	val := reflect.ValueOf(entity).Elem()
+	key := projectedKey(record, meta)
+	for goFieldName, neo4jPropName := range meta.Mappings {
+		// A column alias declared with `alias:` is matched exactly and takes precedence.
+		// Otherwise, find a key in the result record that matches the struct's property name.
+		// This works for direct aliases (`RETURN u.name AS name`) and for property projections (`RETURN u.name`).
+		// Legacy alias columns are only consulted if the primary one is missing or null.
+		var foundValue any
+		var found bool
+		if column, ok := meta.ColumnAliases[goFieldName]; ok {
+			foundValue, found = record.Get(column)
+		}
+		if !found {
+			foundValue, found = projectedValue(record, neo4jPropName)
+		}
+		if !found || foundValue == nil {
+			for _, alias := range meta.Aliases[goFieldName] {
+				if foundValue, found = projectedValue(record, alias); found && foundValue != nil {
+					break
+				}
+			}
+		}
+
+		// If a matching value was found, set it on the corresponding struct field.
+		if !found || foundValue == nil {
+			continue
+		}
+		if field := meta.settableField(val, goFieldName); field.IsValid() && field.CanSet() {
+			if err := meta.setPropertyValue(goFieldName, field, foundValue); err != nil {
+				return &MappingError{Key: key, Field: goFieldName, Property: neo4jPropName, Err: err}
+			}
+		}
+	}
+	return mapComputedFields(record, entity, meta, "", key)
+}
+
+// projectedKey returns the primary key projected in a record, from the column named by its
+// `alias:` tag component or from its property column, or nil if the record has none.
+func projectedKey(record *Record, meta *entityMetadata) any {
+	if column, ok := meta.ColumnAliases[meta.PKField]; ok {
+		if key, found := record.Get(column); found {
+			return key
+		}
+	}
+	key, _ := projectedValue(record, meta.PKProp)
+	return key
+}
+
+// projectedValue returns the value of the first record column named propName or ending in
+// "."+propName, without allocating the suffix.
+func projectedValue(record *Record, propName string) (any, bool) {
+	for i, key := range record.Keys {
+		if key == propName {
+			return record.Values[i], true
+		}
+		if n := len(key) - len(propName); n > 0 && key[n-1] == '.' && key[n:] == propName {
+			return record.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// mapComputedFields fills the fields tagged with `computed:` from the record columns of
+// the same name, such as `RETURN id(n) AS legacyId`. Unlike properties, computed columns
+// are matched by exact key only. Integer columns are converted to the field's integer
+// width or formatted into string fields, and vice versa. The element ID and key of the
+// record's entity, if known, identify it in errors.
+func mapComputedFields(record *Record, entity any, meta *entityMetadata, elementID string, key any) error {
+	if len(meta.Computed) == 0 {
+		return nil
+	}
+	val := reflect.ValueOf(entity).Elem()
+	for fieldName, column := range meta.Computed {
+		value, ok := record.Get(column)
+		if !ok {
+			continue
+		}
+		field := meta.field(val, fieldName)
+		if !field.IsValid() || !field.CanSet() {
+			continue
+		}
+		if err := setComputedValue(field, value); err != nil {
+			return &MappingError{ElementID: elementID, Key: key, Field: fieldName, Property: column, Err: err}
+		}
+	}
+	return nil
+}
+
+// setComputedValue assigns a computed column to a field, converting between the int64 and
+// string representations of identifiers when the field expects the other one.
+func setComputedValue(field reflect.Value, value any) error {
+	switch v := value.(type) {
+	case int64:
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if field.OverflowInt(v) {
+				return fmt.Errorf("value %d overflows field of type %s", v, field.Type())
+			}
+			field.SetInt(v)
+			return nil
+		case reflect.String:
+			field.SetString(strconv.FormatInt(v, 10))
+			return nil
+		}
+	case string:
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(v, 10, field.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("cannot convert %q to field of type %s: %w", v, field.Type(), err)
+			}
+			field.SetInt(n)
+			return nil
+		}
+	}
+	return setFieldValue(field, value)
+}
+
+// toPropertyValue converts a struct field into the value sent to the database. Nil slices
+// become null and times are converted according to the repository's time settings (see
+// paramValue). Other values, including library types such as Point, are passed through
+// unchanged; the runner converts them into driver values.
+func (m *entityMetadata) toPropertyValue(field reflect.Value) interface{} {
+	// The driver would write a nil slice as an empty list; null keeps the two apart.
+	if field.Kind() == reflect.Slice && field.IsNil() {
+		return nil
+	}
+	return m.paramValue(field.Interface())
+}
+
+// setPropertyValue assigns a property value to the mapped field fieldName. Fields tagged
+// `json` are decoded with setJSONValue, and those tagged `base64` with setBytesValue.
+// Otherwise the field's PropertyConverter, if any,
+// converts the value first. Temporal values are then converted
+// into time fields with setTimeValue, durations into time.Duration fields with
+// setDurationValue, lists into slice fields with setListValue, and other values with
+// setCoercedValue.
+func (m *entityMetadata) setPropertyValue(fieldName string, field reflect.Value, value any) (err error) {
+	// The conversions check types beforehand, but a reflect panic must not escape a load.
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("cannot assign value of type %T to field of type %s: %v", value, field.Type(), p)
+		}
+	}()
+	if m.JSONFields[fieldName] {
+		return setJSONValue(field, value)
+	}
+	if m.Base64Fields[fieldName] {
+		if ok, err := setBytesValue(field, value, true); ok {
+			return err
+		}
+	}
+	if conv, ok := m.Converters[fieldName]; ok {
+		if value, err = conv.FromProperty(value); err != nil {
+			return &ConversionError{Label: m.Label, Field: fieldName, Err: err}
+		}
+	}
+	if m.setTimeValue(field, value) {
+		return nil
+	}
+	if ok, err := setDurationValue(field, value); ok {
+		return err
+	}
+	if list, ok := value.([]any); ok && field.Kind() == reflect.Slice {
+		return m.setListValue(field, list)
+	}
+	return setCoercedValue(field, value)
+}
+
+// setCoercedValue assigns a value to a field like setFieldValue, but first converts it to
+// the field's type when they differ: numbers to any numeric kind and width, failing
+// instead of overflowing or truncating, strings and booleans to named types of the same
+// kind, and byte arrays to []byte fields, copied with setBytesValue. Pointer fields are
+// allocated and set through.
+func setCoercedValue(field reflect.Value, value any) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if isBytesType(field.Type()) {
+		if ok, err := setBytesValue(field, value, false); ok {
+			return err
+		}
+	}
+	rv := reflect.ValueOf(value)
+	target := field.Type()
+	if rv.Type().AssignableTo(target) {
+		field.Set(rv)
+		return nil
+	}
+	if target.Kind() == reflect.Pointer {
+		elem := reflect.New(target.Elem())
+		if err := setCoercedValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := rv.Int()
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if field.OverflowInt(v) {
+				return fmt.Errorf("value %d overflows field of type %s", v, target)
+			}
+			field.SetInt(v)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v < 0 || field.OverflowUint(uint64(v)) {
+				return fmt.Errorf("value %d overflows field of type %s", v, target)
+			}
+			field.SetUint(uint64(v))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(float64(v))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v := rv.Uint()
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v > math.MaxInt64 || field.OverflowInt(int64(v)) {
+				return fmt.Errorf("value %d overflows field of type %s", v, target)
+			}
+			field.SetInt(int64(v))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if field.OverflowUint(v) {
+				return fmt.Errorf("value %d overflows field of type %s", v, target)
+			}
+			field.SetUint(v)
+			return nil
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(float64(v))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		v := rv.Float()
+		switch field.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if field.OverflowFloat(v) {
+				return fmt.Errorf("value %g overflows field of type %s", v, target)
+			}
+			field.SetFloat(v)
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			// 2^63 is exactly representable, so the bounds below are exact.
+			if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 || field.OverflowInt(int64(v)) {
+				return fmt.Errorf("value %g cannot be stored in field of type %s without loss", v, target)
+			}
+			field.SetInt(int64(v))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 || field.OverflowUint(uint64(v)) {
+				return fmt.Errorf("value %g cannot be stored in field of type %s without loss", v, target)
+			}
+			field.SetUint(uint64(v))
+			return nil
+		}
+	case reflect.String:
+		if field.Kind() == reflect.String {
+			field.SetString(rv.String())
+			return nil
+		}
+	case reflect.Bool:
+		if field.Kind() == reflect.Bool {
+			field.SetBool(rv.Bool())
+			return nil
+		}
+	}
+	return setFieldValue(field, value)
+}
+
+// setFieldValue assigns a database value to a struct field, returning an error instead
+// of panicking when the value's type is not assignable to the field.
+func setFieldValue(field reflect.Value, value any) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if !rv.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("cannot assign value of type %s to field of type %s", rv.Type(), field.Type())
+	}
+	field.Set(rv)
+	return nil
+}
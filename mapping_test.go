@@ -0,0 +1,60 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+func TestMappingErrorsNameThePrimaryKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		record        *neopersist.Record
+		wantElementID string
+	}{
+		{
+			name: "node",
+			record: neopersist.NewRecord("n", neopersist.Node{
+				ElementID: "4:test:p1", Labels: []string{"Profile"},
+				Props: map[string]any{"id": "p1", "settings": "{not json"},
+			}),
+			wantElementID: "4:test:p1",
+		},
+		{
+			name:   "projection",
+			record: neopersist.NewRecord("n.id", "p1", "n.settings", "{not json"),
+		},
+		{
+			name:   "bare columns",
+			record: neopersist.NewRecord("id", "p1", "settings", "{not json"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := neopersisttest.NewFakeRunner()
+			runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+				return &neopersist.ResultSet{Records: []*neopersist.Record{tt.record}}, nil
+			}
+			repo, err := neopersist.NewRepository[Profile](runner)
+			if err != nil {
+				t.Fatalf("NewRepository: %v", err)
+			}
+
+			_, err = repo.FindRaw(context.Background(), "MATCH (n:Profile) RETURN n.id, n.settings", nil)
+			var mappingErr *neopersist.MappingError
+			if !errors.As(err, &mappingErr) {
+				t.Fatalf("FindRaw: err = %v, want a *MappingError", err)
+			}
+			if mappingErr.Key != "p1" || mappingErr.ElementID != tt.wantElementID || mappingErr.Field != "Settings" {
+				t.Errorf("MappingError = %+v, want key p1 and element ID %q on field Settings", mappingErr, tt.wantElementID)
+			}
+			if !strings.Contains(err.Error(), "p1") {
+				t.Errorf("message %q does not name the key", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+)
+
+// PoolStats is a snapshot of a Neo4jExecutor's connection usage.
+//
+// The v5 driver does not expose its pool internals, so the executor maintains its own
+// gauges around Run: a query in flight holds one connection for its whole duration, which
+// makes InUse a faithful approximation of the connections checked out by the executor.
+type PoolStats struct {
+	// InUse is the number of queries currently holding a connection.
+	InUse int64
+	// Idle is the number of idle pooled connections, or -1 when it cannot be determined
+	// (always the case with the v5 driver).
+	Idle int64
+	// MaxSize is the configured maximum pool size.
+	MaxSize int64
+	// TotalAcquired is the number of connections acquired since the executor was created.
+	TotalAcquired int64
+	// AcquireTimeouts is the number of queries that failed by running out of time, which
+	// is how queries waiting on a saturated pool fail. The v5 driver does not tell
+	// acquisition timeouts apart from other ones, so queries whose deadline expired on
+	// the server, or that the server aborted for exceeding the transaction timeout, are
+	// counted too.
+	AcquireTimeouts int64
+}
+
+// MetricsCollector receives executor observability data, for example to export it to
+// Prometheus. Implementations must be safe for concurrent use and should return quickly,
+// since they are called on the query path.
+type MetricsCollector interface {
+	// ObservePoolStats is called with fresh statistics whenever a connection is acquired
+	// or released.
+	ObservePoolStats(stats PoolStats)
+}
+
+// defaultMaxConnectionPoolSize mirrors the v5 driver's default pool size.
+const defaultMaxConnectionPoolSize = 100
+
+// WithMaxConnectionPoolSize sets the maximum number of connections the driver keeps per
+// server. It is also reported as PoolStats.MaxSize.
+func WithMaxConnectionPoolSize(n int) ExecutorOption {
+	return func(e *Neo4jExecutor) {
+		e.maxPoolSize = n
+	}
+}
+
+// WithMetricsCollector registers a collector that is notified of pool usage changes.
+func WithMetricsCollector(c MetricsCollector) ExecutorOption {
+	return func(e *Neo4jExecutor) {
+		e.metrics = c
+	}
+}
+
+// PoolStats returns a snapshot of the executor's connection usage.
+func (e *Neo4jExecutor) PoolStats() PoolStats {
+	maxSize := int64(e.maxPoolSize)
+	if maxSize <= 0 {
+		maxSize = defaultMaxConnectionPoolSize
+	}
+	return PoolStats{
+		InUse:           e.poolInUse.Load(),
+		Idle:            -1,
+		MaxSize:         maxSize,
+		TotalAcquired:   e.poolAcquired.Load(),
+		AcquireTimeouts: e.poolTimeouts.Load(),
+	}
+}
+
+// acquireConn records that a query is about to check out a connection.
+func (e *Neo4jExecutor) acquireConn() {
+	e.poolInUse.Add(1)
+	e.poolAcquired.Add(1)
+	e.observePool()
+}
+
+// releaseConn records that a query run with ctx finished, counting timeouts.
+func (e *Neo4jExecutor) releaseConn(ctx context.Context, err error) {
+	e.poolInUse.Add(-1)
+	if isTimeout(ctx, err) {
+		e.poolTimeouts.Add(1)
+	}
+	e.observePool()
+}
+
+// observePool forwards the current statistics to the metrics collector, if any.
+func (e *Neo4jExecutor) observePool() {
+	if e.metrics != nil {
+		e.metrics.ObservePoolStats(e.PoolStats())
+	}
+}
+
+// serverTimeoutCodes are the status codes with which the server aborts a transaction that
+// exceeded its timeout.
+var serverTimeoutCodes = map[string]bool{
+	"Neo.ClientError.Transaction.TransactionTimedOut":                    true,
+	"Neo.ClientError.Transaction.TransactionTimedOutClientConfiguration": true,
+}
+
+// isTimeout reports whether err, returned by a query run with ctx, means that the query
+// ran out of time.
+func isTimeout(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(ctx.Err(), context.DeadlineExceeded) || isTimeoutError(err)
+}
+
+// isTimeoutError reports whether err is, or was caused by, an expired deadline, a network
+// timeout or a server-side transaction timeout.
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || serverTimeoutCodes[dbErrorCode(err)] {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	code, causes := driverErrorDetails(err)
+	if serverTimeoutCodes[code] {
+		return true
+	}
+	for _, cause := range causes {
+		if isTimeoutError(cause) {
+			return true
+		}
+	}
+	return false
+}
+
+// poolCounters holds the gauges backing PoolStats; it is embedded in Neo4jExecutor.
+type poolCounters struct {
+	poolInUse    atomic.Int64
+	poolAcquired atomic.Int64
+	poolTimeouts atomic.Int64
+}
@@ -0,0 +1,45 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// netTimeout is a net.Error reporting a timeout.
+type netTimeout struct{}
+
+func (netTimeout) Error() string   { return "i/o timeout" }
+func (netTimeout) Timeout() bool   { return true }
+func (netTimeout) Temporary() bool { return false }
+
+func TestIsTimeout(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Unix(0, 0))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "no error", ctx: expired, err: nil, want: false},
+		{name: "expired call deadline", ctx: expired, err: errors.New("opaque driver failure"), want: true},
+		{name: "wrapped deadline", ctx: context.Background(), err: fmt.Errorf("error executing neo4j query: %w", context.DeadlineExceeded), want: true},
+		{name: "server transaction timeout", ctx: context.Background(), err: &DBError{Code: "Neo.ClientError.Transaction.TransactionTimedOut"}, want: true},
+		{name: "wrapped server timeout", ctx: context.Background(), err: fmt.Errorf("error executing neo4j query: %w", &DBError{Code: "Neo.ClientError.Transaction.TransactionTimedOutClientConfiguration"}), want: true},
+		{name: "network timeout", ctx: context.Background(), err: fmt.Errorf("read: %w", netTimeout{}), want: true},
+		{name: "cancellation", ctx: context.Background(), err: context.Canceled, want: false},
+		{name: "other server error", ctx: context.Background(), err: &DBError{Code: "Neo.ClientError.Statement.SyntaxError"}, want: false},
+		{name: "timeout only in the message", ctx: context.Background(), err: errors.New("Timeout while waiting for connection"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTimeout(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("isTimeout(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const (
+	// migrationLabel is the label of the nodes recording applied migrations.
+	migrationLabel = "NeopersistMigration"
+	// migrationLockName is the advisory lock serializing Migrate and EnsureSchema.
+	migrationLockName = "neopersist-migrations"
+	// defaultMigrationLockTTL is how long the migration lock survives its holder.
+	defaultMigrationLockTTL = 30 * time.Second
+)
+
+// Migration is a one-off change to the graph, such as a data backfill or a property
+// rename, applied at most once per database by PersistenceManager.Migrate.
+type Migration struct {
+	// ID identifies the migration. Applied IDs are recorded in the database, so an ID
+	// must never be reused for a different change.
+	ID string
+	// Up applies the migration. It should be idempotent where possible: if the process
+	// dies after Up but before the migration is recorded, it runs again.
+	Up func(ctx context.Context, runner DBRunner) error
+}
+
+// Migrate applies, in order, the given migrations that have not been applied yet, and
+// records each one as a :NeopersistMigration node once it succeeds. It holds the
+// "neopersist-migrations" advisory lock while doing so, so when several replicas start at
+// once, one applies the migrations and the others wait for it and then find nothing left
+// to do. The lock of a replica that died mid-run is taken over once its TTL expires. As
+// with AcquireLock, a uniqueness constraint on :NeopersistLock(name) keeps the very first
+// run exclusive too.
+//
+// Example:
+//
+//	applied, err := manager.Migrate(ctx, []neopersist.Migration{{
+//	    ID: "2024-05-rename-mail",
+//	    Up: func(ctx context.Context, runner neopersist.DBRunner) error {
+//	        _, err := runner.Run(ctx, "MATCH (u:User) WHERE u.mail IS NOT NULL SET u.email = u.mail REMOVE u.mail", nil)
+//	        return err
+//	    },
+//	}})
+//
+// Parameters:
+//   - ctx: The context bounding both the wait for the lock and the migrations.
+//   - migrations: The migrations to apply, in order. IDs must be unique and non-empty.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout. They apply to
+//     the lock, the bookkeeping queries and the runner given to each Up function.
+//
+// Returns:
+//
+//	The IDs of the migrations applied by this call, or an error if the lock cannot be
+//	acquired, a migration fails, or the lock is lost in between. Migrations applied
+//	before the failure stay recorded.
+func (pm *PersistenceManager) Migrate(ctx context.Context, migrations []Migration, opts ...WriteOption) ([]string, error) {
+	options, err := parseOptions("Migrate", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(migrations))
+	for _, migration := range migrations {
+		if migration.ID == "" || migration.Up == nil {
+			return nil, fmt.Errorf("migration must have an ID and an Up function")
+		}
+		if seen[migration.ID] {
+			return nil, fmt.Errorf("duplicate migration ID '%s'", migration.ID)
+		}
+		seen[migration.ID] = true
+	}
+
+	runner := pm.runnerWith(options)
+	var applied []string
+	err = pm.withMigrationLock(ctx, options, func(lock *AdvisoryLock) error {
+		done, err := appliedMigrations(ctx, runner)
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			if done[migration.ID] {
+				continue
+			}
+			if err := lock.Err(); err != nil {
+				return err
+			}
+			if err := migration.Up(ctx, runner); err != nil {
+				return fmt.Errorf("migration '%s' failed: %w", migration.ID, err)
+			}
+			query := fmt.Sprintf("MERGE (m:%s {id: $id})\nON CREATE SET m.appliedAt = datetime()", migrationLabel)
+			if _, err := runner.Run(ctx, query, map[string]interface{}{"id": migration.ID}); err != nil {
+				return fmt.Errorf("could not record migration '%s': %w", migration.ID, err)
+			}
+			applied = append(applied, migration.ID)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// EnsureSchema runs EnsureConstraints and then EnsureIndexes for each given entity type
+// while holding the same advisory lock as Migrate, so that replicas starting at once
+// bootstrap the schema one after the other. Rules created in the meantime by a replica
+// not using the lock are reported as existing rather than failing.
+//
+// Parameters:
+//   - ctx: The context bounding both the wait for the lock and the schema queries.
+//   - entityTypes: Values of, or pointers to, the entity structs to bootstrap.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout, applied to the
+//     lock and the schema queries.
+//
+// Returns:
+//
+//	The rules created and those that already existed, or an error if a type has
+//	invalid tags, the lock cannot be acquired, or a rule cannot be created.
+func (pm *PersistenceManager) EnsureSchema(ctx context.Context, entityTypes []any, opts ...WriteOption) (*SchemaChanges, error) {
+	options, err := parseOptions("EnsureSchema", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	metas := make([]*entityMetadata, 0, len(entityTypes))
+	for _, entityType := range entityTypes {
+		meta, err := pm.metadataFor(reflect.TypeOf(entityType))
+		if err != nil {
+			return nil, err
+		}
+		metas = append(metas, meta)
+	}
+
+	runner := pm.runnerWith(options)
+	changes := &SchemaChanges{}
+	err = pm.withMigrationLock(ctx, options, func(*AdvisoryLock) error {
+		for _, meta := range metas {
+			if err := ensureConstraints(ctx, runner, meta, changes); err != nil {
+				return err
+			}
+			if err := ensureIndexes(ctx, runner, meta, changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// withMigrationLock runs fn while holding the migration lock, and reports the lock as
+// lost if it was taken over before fn returned. The lock's queries run with options.
+func (pm *PersistenceManager) withMigrationLock(ctx context.Context, options *queryOptions, fn func(lock *AdvisoryLock) error) error {
+	lock, err := pm.acquireLock(ctx, migrationLockName, pm.migrationLockTTL(), options)
+	if err != nil {
+		return err
+	}
+	err = fn(lock)
+	return errors.Join(err, lock.Release(context.WithoutCancel(ctx)))
+}
+
+// migrationLockTTL returns the TTL set with WithMigrationLockTTL, or the default.
+func (pm *PersistenceManager) migrationLockTTL() time.Duration {
+	if pm.lockTTL > 0 {
+		return pm.lockTTL
+	}
+	return defaultMigrationLockTTL
+}
+
+// appliedMigrations returns the IDs of the migrations recorded as applied.
+func appliedMigrations(ctx context.Context, runner DBRunner) (map[string]bool, error) {
+	result, err := runner.Run(ctx, fmt.Sprintf("MATCH (m:%s)\nRETURN m.id AS id", migrationLabel), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not list applied migrations: %w", err)
+	}
+	applied := make(map[string]bool, len(result.Records))
+	for _, record := range result.Records {
+		if id, ok := record.Get("id"); ok {
+			if s, ok := id.(string); ok {
+				applied[s] = true
+			}
+		}
+	}
+	return applied, nil
+}
+
+// WithMigrationLockTTL sets how long the advisory lock taken by Migrate and EnsureSchema
+// survives a replica that dies while holding it (30 seconds by default). The holder
+// renews it every third of the TTL.
+func WithMigrationLockTTL(ttl time.Duration) ManagerOption {
+	return func(pm *PersistenceManager) {
+		pm.lockTTL = ttl
+	}
+}
@@ -0,0 +1,295 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+)
+
+// lockGraph is a DBRunner keeping the lock and migration nodes in memory. It answers the
+// queries of AcquireLock, Migrate and EnsureSchema atomically, as the database does once
+// it holds the node's write lock.
+type lockGraph struct {
+	mu       sync.Mutex
+	locks    map[string]lockNode
+	applied  map[string]bool
+	records  int
+	released int
+}
+
+type lockNode struct {
+	owner     string
+	expiresAt time.Time
+}
+
+func newLockGraph() *lockGraph {
+	return &lockGraph{locks: make(map[string]lockNode), applied: make(map[string]bool)}
+}
+
+func (g *lockGraph) Run(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	name, _ := params["name"].(string)
+	owner, _ := params["owner"].(string)
+	ttl, _ := params["ttlMillis"].(int64)
+	lock := g.locks[name]
+
+	switch {
+	case strings.HasPrefix(query, "MERGE (l:NeopersistLock"):
+		acquired := lock.owner == "" || lock.owner == owner || lock.expiresAt.Before(time.Now())
+		if acquired {
+			g.locks[name] = lockNode{owner: owner, expiresAt: time.Now().Add(time.Duration(ttl) * time.Millisecond)}
+		}
+		return recordSet(neopersist.NewRecord("acquired", acquired)), nil
+	case strings.HasPrefix(query, "MATCH (l:NeopersistLock") && strings.Contains(query, "SET l.expiresAt"):
+		if lock.owner != owner {
+			return &neopersist.ResultSet{}, nil
+		}
+		g.locks[name] = lockNode{owner: owner, expiresAt: time.Now().Add(time.Duration(ttl) * time.Millisecond)}
+		return recordSet(neopersist.NewRecord("name", name)), nil
+	case strings.HasPrefix(query, "MATCH (l:NeopersistLock") && strings.Contains(query, "DELETE l"):
+		if lock.owner == owner {
+			delete(g.locks, name)
+			g.released++
+		}
+		return &neopersist.ResultSet{}, nil
+	case strings.HasPrefix(query, "MATCH (m:NeopersistMigration)"):
+		result := &neopersist.ResultSet{}
+		for id := range g.applied {
+			result.Records = append(result.Records, neopersist.NewRecord("id", id))
+		}
+		return result, nil
+	case strings.HasPrefix(query, "MERGE (m:NeopersistMigration"):
+		g.applied[params["id"].(string)] = true
+		g.records++
+		return &neopersist.ResultSet{}, nil
+	}
+	return nil, fmt.Errorf("unexpected query %q", query)
+}
+
+// steal makes another, dead owner hold the named lock until expiresAt.
+func (g *lockGraph) steal(name string, expiresAt time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.locks[name] = lockNode{owner: "dead-replica", expiresAt: expiresAt}
+}
+
+func recordSet(records ...*neopersist.Record) *neopersist.ResultSet {
+	return &neopersist.ResultSet{Records: records}
+}
+
+// countingMigrations returns migrations counting how often each is applied and failing the
+// test if two of them ever run at the same time.
+func countingMigrations(t *testing.T, ids ...string) ([]neopersist.Migration, map[string]*int64) {
+	var inFlight int32
+	counts := make(map[string]*int64, len(ids))
+	migrations := make([]neopersist.Migration, len(ids))
+	for i, id := range ids {
+		count := new(int64)
+		counts[id] = count
+		migrations[i] = neopersist.Migration{ID: id, Up: func(ctx context.Context, runner neopersist.DBRunner) error {
+			if atomic.AddInt32(&inFlight, 1) != 1 {
+				t.Errorf("migration %s ran concurrently with another one", id)
+			}
+			defer atomic.AddInt32(&inFlight, -1)
+			atomic.AddInt64(count, 1)
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		}}
+	}
+	return migrations, counts
+}
+
+func TestMigrateAppliesEachMigrationOnceAcrossConcurrentReplicas(t *testing.T) {
+	graph := newLockGraph()
+	migrations, counts := countingMigrations(t, "001-users", "002-posts", "003-tags")
+
+	const replicas = 10
+	var wg sync.WaitGroup
+	var totalApplied int64
+	errs := make(chan error, replicas)
+	for i := 0; i < replicas; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pm := neopersist.NewPersistenceManager(graph, neopersist.WithMigrationLockTTL(time.Second))
+			applied, err := pm.Migrate(context.Background(), migrations)
+			atomic.AddInt64(&totalApplied, int64(len(applied)))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Migrate: %v", err)
+		}
+	}
+	for id, count := range counts {
+		if *count != 1 {
+			t.Errorf("migration %s applied %d times, want exactly once", id, *count)
+		}
+	}
+	if totalApplied != 3 || graph.records != 3 {
+		t.Errorf("reported %d and recorded %d applied migrations, want 3", totalApplied, graph.records)
+	}
+	if len(graph.locks) != 0 || graph.released != replicas {
+		t.Errorf("lock left held %v after %d releases, want released by all %d replicas", graph.locks, graph.released, replicas)
+	}
+}
+
+func TestMigrateStealsExpiredLock(t *testing.T) {
+	graph := newLockGraph()
+	graph.steal("neopersist-migrations", time.Now().Add(-time.Second))
+	migrations, counts := countingMigrations(t, "001-users")
+	pm := neopersist.NewPersistenceManager(graph)
+
+	applied, err := pm.Migrate(context.Background(), migrations)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) != 1 || *counts["001-users"] != 1 {
+		t.Errorf("applied %v, want the migration applied once after taking over the lock", applied)
+	}
+}
+
+func TestMigrateWaitsForLiveLockUntilItExpires(t *testing.T) {
+	graph := newLockGraph()
+	graph.steal("neopersist-migrations", time.Now().Add(300*time.Millisecond))
+	migrations, counts := countingMigrations(t, "001-users")
+	pm := neopersist.NewPersistenceManager(graph, neopersist.WithMigrationLockTTL(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := pm.Migrate(ctx, migrations); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Migrate on a held lock: got %v, want context.DeadlineExceeded", err)
+	}
+	if *counts["001-users"] != 0 {
+		t.Fatal("migration ran while another replica held the lock")
+	}
+
+	start := time.Now()
+	if _, err := pm.Migrate(context.Background(), migrations); err != nil {
+		t.Fatalf("Migrate after expiry: %v", err)
+	}
+	if *counts["001-users"] != 1 {
+		t.Errorf("migration applied %d times, want once after the lock expired", *counts["001-users"])
+	}
+	if time.Since(start) < 100*time.Millisecond {
+		t.Error("lock was taken over before it expired")
+	}
+}
+
+func TestAdvisoryLockReportsLossAfterSteal(t *testing.T) {
+	graph := newLockGraph()
+	pm := neopersist.NewPersistenceManager(graph)
+	lock, err := pm.AcquireLock(context.Background(), "bootstrap", 30*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireLock: %v", err)
+	}
+
+	graph.steal("bootstrap", time.Now().Add(time.Minute))
+	select {
+	case <-lock.Done():
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat did not notice the stolen lock")
+	}
+	if !errors.Is(lock.Err(), neopersist.ErrLockLost) {
+		t.Errorf("Err() = %v, want ErrLockLost", lock.Err())
+	}
+	if err := lock.Release(context.Background()); !errors.Is(err, neopersist.ErrLockLost) {
+		t.Errorf("Release() = %v, want ErrLockLost", err)
+	}
+	if graph.locks["bootstrap"].owner != "dead-replica" {
+		t.Error("Release removed a lock owned by another replica")
+	}
+}
+
+func TestMigrateRejectsDuplicateIDs(t *testing.T) {
+	graph := newLockGraph()
+	migrations, _ := countingMigrations(t, "001", "001")
+	pm := neopersist.NewPersistenceManager(graph)
+
+	if _, err := pm.Migrate(context.Background(), migrations); err == nil {
+		t.Fatal("Migrate accepted duplicate migration IDs")
+	}
+}
+
+func TestEnsureSchemaTreatsConcurrentlyCreatedRulesAsExisting(t *testing.T) {
+	graph := newLockGraph()
+	var constraintCalls int64
+	runner := runnerFunc(func(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+		if strings.HasPrefix(query, "CREATE CONSTRAINT") {
+			atomic.AddInt64(&constraintCalls, 1)
+			return nil, &neopersist.DBError{Code: "Neo.ClientError.Schema.EquivalentSchemaRuleAlreadyExists"}
+		}
+		return graph.Run(ctx, query, params)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			changes, err := neopersist.NewPersistenceManager(runner).EnsureSchema(context.Background(), []any{User{}})
+			if err != nil {
+				t.Errorf("EnsureSchema: %v", err)
+				return
+			}
+			if len(changes.Existing) != 1 || changes.Existing[0] != "User_userId_unique" {
+				t.Errorf("Existing = %v, want the primary key constraint", changes.Existing)
+			}
+		}()
+	}
+	wg.Wait()
+	if constraintCalls != 5 {
+		t.Errorf("sent %d constraint queries, want one per replica", constraintCalls)
+	}
+}
+
+// runnerFunc adapts a function to neopersist.DBRunner.
+type runnerFunc func(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error)
+
+func (f runnerFunc) Run(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+	return f(ctx, query, params)
+}
+
+func TestIntegrationMigrateConcurrentReplicas(t *testing.T) {
+	executor := integrationExecutor(t)
+	ctx := context.Background()
+	cleanup := func() {
+		_, _ = executor.Run(ctx, "MATCH (n) WHERE n:NeopersistMigration OR n:NeopersistLock DETACH DELETE n", nil)
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+	if _, err := executor.Run(ctx, "CREATE CONSTRAINT NeopersistLock_name_unique IF NOT EXISTS FOR (l:NeopersistLock) REQUIRE l.name IS UNIQUE", nil); err != nil {
+		t.Fatalf("could not create the lock constraint: %v", err)
+	}
+	migrations, counts := countingMigrations(t, "001-users", "002-posts")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pm := neopersist.NewPersistenceManager(executor, neopersist.WithMigrationLockTTL(time.Second))
+			if _, err := pm.Migrate(ctx, migrations); err != nil {
+				t.Errorf("Migrate: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	for id, count := range counts {
+		if *count != 1 {
+			t.Errorf("migration %s applied %d times, want exactly once", id, *count)
+		}
+	}
+}
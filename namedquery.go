@@ -0,0 +1,83 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// namedQueryPropertyPattern matches the properties of the entity alias n referenced by a
+// named query, as in n.email or n.`legacy name`.
+var namedQueryPropertyPattern = regexp.MustCompile("\\bn\\.(?:([A-Za-z_][A-Za-z0-9_]*)|`([^`]+)`)")
+
+// WithNamedQuery returns a RepositoryOption registering a Cypher statement under a name, to
+// be run with Repository.FindNamed. The statement refers to the entity as n and returns it
+// (or its property projections) as FindRaw expects. Declaring queries up front keeps them
+// next to the repository and lets ValidateAgainstDatabase check that the properties they
+// use, such as n.email, exist in the database. Registering a name twice keeps the last
+// statement.
+//
+// Example:
+//
+//	repo, err := neopersist.NewRepository[models.User](runner,
+//	    neopersist.WithNamedQuery("byEmailDomain", "MATCH (n:User) WHERE n.email ENDS WITH $domain RETURN n"))
+//	users, err := repo.FindNamed(ctx, "byEmailDomain", map[string]any{"domain": "@example.com"})
+func WithNamedQuery(name, cypher string) RepositoryOption {
+	return func(c *repositoryConfig) {
+		if c.namedQueries == nil {
+			c.namedQueries = make(map[string]string)
+		}
+		c.namedQueries[name] = cypher
+	}
+}
+
+// FindNamed runs the statement registered with WithNamedQuery under name and maps its
+// records as FindRaw does.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - name: The name the statement was registered under.
+//   - params: The statement's parameters; may be nil.
+//   - opts: Optional per-call settings, as accepted by FindRaw.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities, or an error if no statement is registered
+//	under name or the query or mapping fails.
+func (r *Repository[T]) FindNamed(ctx context.Context, name string, params map[string]interface{}, opts ...FindOption) ([]*T, error) {
+	cypher, ok := r.config.namedQueries[name]
+	if !ok {
+		return nil, fmt.Errorf("no named query '%s' is registered on the %s repository", name, r.meta.Label)
+	}
+	return r.FindRaw(ctx, cypher, params, opts...)
+}
+
+// namedQueryProperties maps each property of n referenced by the given named queries to
+// the sorted names of the queries using it.
+func namedQueryProperties(queries map[string]string) map[string][]string {
+	users := make(map[string][]string)
+	for name, cypher := range queries {
+		seen := make(map[string]bool)
+		for _, match := range namedQueryPropertyPattern.FindAllStringSubmatch(cypher, -1) {
+			prop := match[1] + match[2]
+			if !seen[prop] {
+				seen[prop] = true
+				users[prop] = append(users[prop], name)
+			}
+		}
+	}
+	for _, names := range users {
+		sort.Strings(names)
+	}
+	return users
+}
+
+// usedBy describes the named queries using a property, for validation messages.
+func usedBy(queries []string) string {
+	if len(queries) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (used by named queries: %s)", strings.Join(queries, ", "))
+}
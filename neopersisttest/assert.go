@@ -0,0 +1,169 @@
+package neopersisttest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Expect describes a query expected to have been recorded. Queries are compared after
+// normalizing whitespace, so line breaks and indentation do not matter.
+type Expect struct {
+	// Query, if set, must equal the whole recorded query.
+	Query string
+	// QueryContains lists fragments that must all appear in the recorded query.
+	QueryContains []string
+	// Params lists parameters that must be present with equal values. Parameters not
+	// listed are ignored unless ExactParams is set.
+	Params map[string]interface{}
+	// ExactParams requires the recorded parameters to contain no keys besides Params.
+	ExactParams bool
+	// Times, if greater than zero, is the exact number of recorded calls that must match.
+	// Otherwise at least one call must match.
+	Times int
+}
+
+// AssertQuery fails the test unless a call recorded by rec matches want (exactly
+// want.Times calls, if set). On failure it reports every recorded call with its
+// normalized query and sorted parameters, and why it did not match.
+func AssertQuery(t testing.TB, rec Recorder, want Expect) {
+	t.Helper()
+	calls := rec.Calls()
+
+	matched := 0
+	var mismatches []string
+	for i, call := range calls {
+		if reason := want.mismatch(call); reason != "" {
+			mismatches = append(mismatches, fmt.Sprintf("call %d: %s\n%s", i, reason, formatCall(call)))
+			continue
+		}
+		matched++
+	}
+
+	switch {
+	case want.Times > 0 && matched != want.Times:
+		t.Errorf("expected %d matching call(s), got %d\nexpected:\n%s\nrecorded:\n%s",
+			want.Times, matched, want.format(), formatCalls(calls))
+	case want.Times <= 0 && matched == 0:
+		t.Errorf("no recorded call matched\nexpected:\n%s\n%s",
+			want.format(), strings.Join(orNone(mismatches), "\n"))
+	}
+}
+
+// AssertCalls fails the test unless rec recorded exactly len(want) calls and each call
+// matches the expectation at the same position. Expect.Times is ignored.
+func AssertCalls(t testing.TB, rec Recorder, want ...Expect) {
+	t.Helper()
+	calls := rec.Calls()
+	if len(calls) != len(want) {
+		t.Errorf("expected %d call(s), got %d\nrecorded:\n%s", len(want), len(calls), formatCalls(calls))
+		return
+	}
+	for i, call := range calls {
+		if reason := want[i].mismatch(call); reason != "" {
+			t.Errorf("call %d: %s\nexpected:\n%s\nrecorded:\n%s", i, reason, want[i].format(), formatCall(call))
+		}
+	}
+}
+
+// AssertCallCount fails the test unless rec recorded exactly n calls.
+func AssertCallCount(t testing.TB, rec Recorder, n int) {
+	t.Helper()
+	if calls := rec.Calls(); len(calls) != n {
+		t.Errorf("expected %d call(s), got %d\nrecorded:\n%s", n, len(calls), formatCalls(calls))
+	}
+}
+
+// mismatch returns why call does not match the expectation, or "" if it does.
+func (e Expect) mismatch(call Call) string {
+	query := normalizeQuery(call.Query)
+	if e.Query != "" && query != normalizeQuery(e.Query) {
+		return "query differs"
+	}
+	for _, fragment := range e.QueryContains {
+		if !strings.Contains(query, normalizeQuery(fragment)) {
+			return fmt.Sprintf("query does not contain %q", normalizeQuery(fragment))
+		}
+	}
+	for _, key := range sortedKeys(e.Params) {
+		got, ok := call.Params[key]
+		if !ok {
+			return fmt.Sprintf("missing param %q", key)
+		}
+		if !reflect.DeepEqual(got, e.Params[key]) {
+			return fmt.Sprintf("param %q = %#v, want %#v", key, got, e.Params[key])
+		}
+	}
+	if e.ExactParams {
+		for _, key := range sortedKeys(call.Params) {
+			if _, ok := e.Params[key]; !ok {
+				return fmt.Sprintf("unexpected param %q", key)
+			}
+		}
+	}
+	return ""
+}
+
+// format renders the expectation for failure messages.
+func (e Expect) format() string {
+	var b strings.Builder
+	if e.Query != "" {
+		fmt.Fprintf(&b, "  query: %s\n", normalizeQuery(e.Query))
+	}
+	for _, fragment := range e.QueryContains {
+		fmt.Fprintf(&b, "  contains: %s\n", normalizeQuery(fragment))
+	}
+	b.WriteString(formatParams(e.Params))
+	return b.String()
+}
+
+// normalizeQuery collapses runs of whitespace into single spaces.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// formatCall renders a recorded call with its normalized query and sorted parameters.
+func formatCall(call Call) string {
+	return fmt.Sprintf("  query: %s\n%s", normalizeQuery(call.Query), formatParams(call.Params))
+}
+
+// formatCalls renders all recorded calls, numbered in order.
+func formatCalls(calls []Call) string {
+	if len(calls) == 0 {
+		return "  (none)"
+	}
+	parts := make([]string, len(calls))
+	for i, call := range calls {
+		parts[i] = fmt.Sprintf("call %d:\n%s", i, formatCall(call))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// formatParams renders parameters one per line, sorted by key.
+func formatParams(params map[string]interface{}) string {
+	var b strings.Builder
+	for _, key := range sortedKeys(params) {
+		fmt.Fprintf(&b, "  $%s = %#v\n", key, params[key])
+	}
+	return b.String()
+}
+
+// sortedKeys returns the keys of params in ascending order.
+func sortedKeys(params map[string]interface{}) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// orNone returns lines, or a placeholder if no calls were recorded.
+func orNone(lines []string) []string {
+	if len(lines) == 0 {
+		return []string{"recorded:\n  (none)"}
+	}
+	return lines
+}
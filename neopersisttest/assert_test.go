@@ -0,0 +1,149 @@
+package neopersisttest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// failures is a testing.TB that collects the messages of failed assertions instead of
+// failing the test running them.
+type failures struct {
+	testing.TB
+	messages []string
+}
+
+func (f *failures) Helper() {}
+
+func (f *failures) Errorf(format string, args ...any) {
+	f.messages = append(f.messages, fmt.Sprintf(format, args...))
+}
+
+// recorded returns a FakeRunner that received the given queries, in order, with params.
+func recorded(params map[string]interface{}, queries ...string) *FakeRunner {
+	runner := NewFakeRunner()
+	for _, query := range queries {
+		_, _ = runner.Run(context.Background(), query, params)
+	}
+	return runner
+}
+
+func TestAssertQueryNormalizesWhitespace(t *testing.T) {
+	runner := recorded(map[string]interface{}{"userId": "u1"}, "MERGE (n:User {userId: $userId})\n  SET n.name = $name\nRETURN n")
+	f := &failures{TB: t}
+
+	AssertQuery(f, runner, Expect{
+		Query:         "MERGE (n:User {userId: $userId}) SET n.name = $name RETURN n",
+		QueryContains: []string{"SET n.name = $name\tRETURN n"},
+		Params:        map[string]any{"userId": "u1"},
+	})
+
+	if len(f.messages) != 0 {
+		t.Errorf("unexpected failures: %v", f.messages)
+	}
+}
+
+func TestAssertQueryReportsSortedParamsOnMismatch(t *testing.T) {
+	runner := recorded(map[string]interface{}{"b": 2, "a": 1}, "MATCH (n:User)\nRETURN n")
+	f := &failures{TB: t}
+
+	AssertQuery(f, runner, Expect{QueryContains: []string{"MERGE (n:User"}})
+
+	if len(f.messages) != 1 {
+		t.Fatalf("got %d failures, want 1: %v", len(f.messages), f.messages)
+	}
+	msg := f.messages[0]
+	for _, want := range []string{
+		`query does not contain "MERGE (n:User"`,
+		"query: MATCH (n:User) RETURN n",
+		"$a = 1\n  $b = 2",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("failure message lacks %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestAssertQueryParams(t *testing.T) {
+	runner := recorded(map[string]interface{}{"userId": "u1", "name": "Ada"}, "MATCH (n:User) RETURN n")
+	tests := []struct {
+		name   string
+		want   Expect
+		reason string
+	}{
+		{name: "subset matches", want: Expect{Params: map[string]any{"userId": "u1"}}},
+		{name: "missing", want: Expect{Params: map[string]any{"email": "a@b"}}, reason: `missing param "email"`},
+		{name: "different value", want: Expect{Params: map[string]any{"userId": "u2"}}, reason: `param "userId" = "u1", want "u2"`},
+		{name: "exact rejects extra", want: Expect{Params: map[string]any{"userId": "u1"}, ExactParams: true}, reason: `unexpected param "name"`},
+		{name: "exact matches", want: Expect{Params: map[string]any{"userId": "u1", "name": "Ada"}, ExactParams: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &failures{TB: t}
+			AssertQuery(f, runner, tt.want)
+			switch {
+			case tt.reason == "" && len(f.messages) != 0:
+				t.Errorf("unexpected failures: %v", f.messages)
+			case tt.reason != "" && (len(f.messages) != 1 || !strings.Contains(f.messages[0], tt.reason)):
+				t.Errorf("want a failure mentioning %q, got %v", tt.reason, f.messages)
+			}
+		})
+	}
+}
+
+func TestAssertQueryTimes(t *testing.T) {
+	runner := recorded(nil, "MATCH (n:User) RETURN n", "MATCH (n:Post) RETURN n", "MATCH (n:User) RETURN n")
+
+	f := &failures{TB: t}
+	AssertQuery(f, runner, Expect{QueryContains: []string{"(n:User)"}, Times: 2})
+	if len(f.messages) != 0 {
+		t.Errorf("unexpected failures: %v", f.messages)
+	}
+
+	f = &failures{TB: t}
+	AssertQuery(f, runner, Expect{QueryContains: []string{"(n:User)"}, Times: 1})
+	if len(f.messages) != 1 || !strings.Contains(f.messages[0], "expected 1 matching call(s), got 2") {
+		t.Errorf("want a count mismatch, got %v", f.messages)
+	}
+}
+
+func TestAssertCallsChecksOrderAndCount(t *testing.T) {
+	runner := recorded(nil, "MATCH (n:User) RETURN n", "MATCH (n:Post) RETURN n")
+
+	f := &failures{TB: t}
+	AssertCalls(f, runner, Expect{QueryContains: []string{"(n:User)"}}, Expect{QueryContains: []string{"(n:Post)"}})
+	if len(f.messages) != 0 {
+		t.Errorf("unexpected failures: %v", f.messages)
+	}
+
+	f = &failures{TB: t}
+	AssertCalls(f, runner, Expect{QueryContains: []string{"(n:Post)"}}, Expect{QueryContains: []string{"(n:User)"}})
+	if len(f.messages) != 2 || !strings.HasPrefix(f.messages[0], "call 0:") || !strings.HasPrefix(f.messages[1], "call 1:") {
+		t.Errorf("want both calls reported out of order, got %v", f.messages)
+	}
+
+	f = &failures{TB: t}
+	AssertCalls(f, runner, Expect{QueryContains: []string{"(n:User)"}})
+	if len(f.messages) != 1 || !strings.Contains(f.messages[0], "expected 1 call(s), got 2") {
+		t.Errorf("want a count mismatch, got %v", f.messages)
+	}
+}
+
+func TestAssertCallCount(t *testing.T) {
+	runner := recorded(nil, "RETURN 1")
+
+	f := &failures{TB: t}
+	AssertCallCount(f, runner, 1)
+	AssertCallCount(f, runner, 0)
+	if len(f.messages) != 1 || !strings.Contains(f.messages[0], "expected 0 call(s), got 1") {
+		t.Errorf("want a single count mismatch, got %v", f.messages)
+	}
+
+	runner.Reset()
+	f = &failures{TB: t}
+	AssertCallCount(f, runner, 0)
+	if len(f.messages) != 0 {
+		t.Errorf("unexpected failures after Reset: %v", f.messages)
+	}
+}
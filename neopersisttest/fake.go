@@ -0,0 +1,106 @@
+// Package neopersisttest provides test helpers for code built on neopersist: a FakeRunner
+// that records the queries sent to it instead of contacting a database, and assertions
+// over the recorded calls for golden query tests.
+package neopersisttest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// Call is a single query recorded by a FakeRunner.
+type Call struct {
+	// Query is the Cypher query as passed to Run.
+	Query string
+	// Params are the query parameters as passed to Run.
+	Params map[string]interface{}
+}
+
+// Recorder is implemented by runners that record the queries they receive.
+type Recorder interface {
+	// Calls returns the recorded calls in the order they were made.
+	Calls() []Call
+}
+
+// FakeRunner is a neopersist.DBRunner that records every query it receives. By default it
+// returns an empty result; set Respond to return canned records or errors.
+//
+// Example:
+//
+//	runner := neopersisttest.NewFakeRunner()
+//	repo, _ := neopersist.NewRepository[models.User](runner)
+//	_ = repo.Save(ctx, &models.User{UserID: "u1", Name: "Ada"})
+//	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+//		QueryContains: []string{"MERGE (n:User"},
+//		Params:        map[string]any{"userId": "u1"},
+//	})
+type FakeRunner struct {
+	// Respond, if set, produces the result of each call. It is called with the call's
+	// index (starting at zero) after the call has been recorded.
+	Respond func(index int, query string, params map[string]interface{}) (*neopersist.ResultSet, error)
+	// ServerVersion, if set (e.g., "5.13"), is the server version the runner pretends to
+	// be connected to: version-dependent operations needing a newer server fail with a
+	// *features.UnsupportedError before sending any query. Empty supports every feature.
+	ServerVersion string
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewFakeRunner creates a FakeRunner that answers every query with an empty result.
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{}
+}
+
+// Run records the query and returns the result produced by Respond, or an empty result.
+func (f *FakeRunner) Run(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+	f.mu.Lock()
+	index := len(f.calls)
+	f.calls = append(f.calls, Call{Query: query, Params: params})
+	respond := f.Respond
+	f.mu.Unlock()
+
+	if respond != nil {
+		return respond(index, query, params)
+	}
+	return &neopersist.ResultSet{}, nil
+}
+
+// RequireFeature checks f against ServerVersion, making the FakeRunner usable to test how
+// code behaves on older servers.
+func (f *FakeRunner) RequireFeature(ctx context.Context, feature features.Feature) error {
+	if f.ServerVersion == "" {
+		return nil
+	}
+	version, err := features.ParseVersion(f.ServerVersion)
+	if err != nil {
+		return err
+	}
+	return features.Require(feature, version)
+}
+
+// RunInTransaction calls fn with the FakeRunner itself, making it a
+// neopersist.TransactionRunner. Queries run in the transaction are recorded like any
+// other; a rollback is not simulated, so they stay recorded when fn fails.
+func (f *FakeRunner) RunInTransaction(ctx context.Context, fn func(ctx context.Context, tx neopersist.DBRunner) error) error {
+	return fn(ctx, f)
+}
+
+// Calls returns a copy of the recorded calls in the order they were made.
+func (f *FakeRunner) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]Call, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// Reset discards the recorded calls.
+func (f *FakeRunner) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
@@ -0,0 +1,84 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Chain nests a Link, which nests itself through a prefixed pointer.
+type Chain struct {
+	ID   string `crud:"pk,property:id"`
+	Head *Link  `crud:"prefix:head_"`
+}
+
+type Link struct {
+	Name string `crud:"property:name"`
+	Next *Link  `crud:"prefix:next_"`
+}
+
+// Ring nests a RingNode, which nests itself through a RingEdge.
+type Ring struct {
+	ID    string    `crud:"pk,property:id"`
+	First *RingNode `crud:"prefix:first_"`
+}
+
+type RingNode struct {
+	Name string    `crud:"property:name"`
+	Out  *RingEdge `crud:"prefix:out_"`
+}
+
+type RingEdge struct {
+	Weight int64     `crud:"property:weight"`
+	To     *RingNode `crud:"prefix:to_"`
+}
+
+// Place nests the same value type twice, side by side.
+type Place struct {
+	ID   string   `crud:"pk,property:id"`
+	Home *Address `crud:"prefix:home_"`
+	Work Address  `crud:"prefix:work_"`
+}
+
+type Address struct {
+	City string `crud:"property:city"`
+}
+
+func TestNestedPrefixesRejectTypesNestingThemselves(t *testing.T) {
+	for name, newRepo := range map[string]func(...neopersist.RepositoryOption) error{
+		"Chain": func(opts ...neopersist.RepositoryOption) error {
+			_, err := neopersist.NewRepository[Chain](neopersisttest.NewFakeRunner(), opts...)
+			return err
+		},
+		"Ring": func(opts ...neopersist.RepositoryOption) error {
+			_, err := neopersist.NewRepository[Ring](neopersisttest.NewFakeRunner(), opts...)
+			return err
+		},
+	} {
+		for _, opts := range [][]neopersist.RepositoryOption{nil, {neopersist.StrictTags()}} {
+			err := newRepo(opts...)
+			if !errors.Is(err, neopersist.ErrInvalidTags) || !strings.Contains(err.Error(), "within itself") {
+				t.Errorf("%s: err = %v, want an ErrInvalidTags reporting the cycle", name, err)
+			}
+		}
+	}
+}
+
+func TestNestedPrefixesAllowTheSameTypeSideBySide(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Place](runner, neopersist.StrictTags())
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	place := &Place{ID: "p1", Home: &Address{City: "Lyon"}, Work: Address{City: "Paris"}}
+	if err := repo.Save(context.Background(), place); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"n.home_city = $", "n.work_city = $"},
+	})
+}
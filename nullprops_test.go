@@ -0,0 +1,56 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Profile is an entity with nillable properties.
+type Profile struct {
+	ID       string         `crud:"pk,property:id"`
+	Nickname *string        `crud:"property:nickname"`
+	Tags     []string       `crud:"property:tags"`
+	Settings map[string]any `crud:"property:settings,json"`
+}
+
+func TestPropertyLookupsRenderNilValuesAsIsNull(t *testing.T) {
+	nickname := "ada"
+	tests := []struct {
+		name  string
+		prop  string
+		value interface{}
+		want  string
+	}{
+		{name: "untyped nil", prop: "nickname", value: nil, want: "MATCH (n:Profile) WHERE n.nickname IS NULL RETURN n"},
+		{name: "nil pointer", prop: "nickname", value: (*string)(nil), want: "MATCH (n:Profile) WHERE n.nickname IS NULL RETURN n"},
+		{name: "nil slice", prop: "tags", value: []string(nil), want: "MATCH (n:Profile) WHERE n.tags IS NULL RETURN n"},
+		{name: "nil map", prop: "settings", value: map[string]any(nil), want: "MATCH (n:Profile) WHERE n.settings IS NULL RETURN n"},
+		{name: "pointer", prop: "nickname", value: &nickname, want: "MATCH (n:Profile {nickname: $nickname}) RETURN n"},
+		{name: "empty slice", prop: "tags", value: []string{}, want: "MATCH (n:Profile {tags: $tags}) RETURN n"},
+		{name: "slice", prop: "tags", value: []string{"go"}, want: "MATCH (n:Profile {tags: $tags}) RETURN n"},
+		{name: "map", prop: "settings", value: map[string]any{"theme": "dark"}, want: "MATCH (n:Profile {settings: $settings}) RETURN n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := neopersisttest.NewFakeRunner()
+			repo, err := neopersist.NewRepository[Profile](runner)
+			if err != nil {
+				t.Fatalf("NewRepository: %v", err)
+			}
+			if _, err := repo.FindByProperty(context.Background(), tt.prop, tt.value); err != nil {
+				t.Fatalf("FindByProperty: %v", err)
+			}
+			if _, err := repo.CountByProperty(context.Background(), tt.prop, tt.value); err != nil {
+				t.Fatalf("CountByProperty: %v", err)
+			}
+			neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: tt.want})
+			neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+				Query: strings.Replace(tt.want, "RETURN n", "RETURN count(n) AS count", 1),
+			})
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package neopersist
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// This file gathers the types shared across the repository API: functional options,
+// relationship directions, sorting and pagination.
+
+// QueryOption configures a single repository call, such as adding planner hints to the
+// generated query. Options are passed variadically to the methods that support them; a
+// method given an option that does not apply to it fails with an *UnsupportedOptionError
+// instead of silently ignoring it.
+type QueryOption func(*queryOptions)
+
+// FindOption is a QueryOption accepted by read methods (FindAll, Find, FindPage, ...).
+type FindOption = QueryOption
+
+// WriteOption is a QueryOption accepted by write methods (Save, Create, Delete, ...).
+type WriteOption = QueryOption
+
+// ErrUnsupportedOption is matched, via errors.Is, by the *UnsupportedOptionError returned
+// when a method is given an option that does not apply to it.
+var ErrUnsupportedOption = errors.New("unsupported option")
+
+// UnsupportedOptionError reports an option passed to a method that does not support it,
+// such as ConfirmDestructive passed to FindAll.
+type UnsupportedOptionError struct {
+	// Operation is the method that received the option.
+	Operation string
+	// Option is the name of the option's constructor (e.g., "ConfirmDestructive").
+	Option string
+}
+
+// Error implements the error interface.
+func (e *UnsupportedOptionError) Error() string {
+	return fmt.Sprintf("%s: %s does not support option %s", ErrUnsupportedOption, e.Operation, e.Option)
+}
+
+// Unwrap allows errors.Is(err, ErrUnsupportedOption) to match an *UnsupportedOptionError.
+func (e *UnsupportedOptionError) Unwrap() error {
+	return ErrUnsupportedOption
+}
+
+// optionKind identifies a QueryOption constructor, so that methods can reject the options
+// they do not support.
+type optionKind uint
+
+const (
+	optIndexHint optionKind = 1 << iota
+	optCollectMappingErrors
+	optRewrite
+	optConfirmDestructive
+	optDistanceOrder
+	optDeleteBatch
+	optIncludeNulls
+	optDatabase
+	optTimeout
+	optReadOnly
+	optSeed
+	optPageSize
+)
+
+// optionNames maps each optionKind to the name of its constructor, for error messages.
+var optionNames = map[optionKind]string{
+	optIndexHint:            "UseIndex",
+	optCollectMappingErrors: "CollectMappingErrors",
+	optRewrite:              "RewriteQuery",
+	optConfirmDestructive:   "ConfirmDestructive",
+	optDistanceOrder:        "OrderByDistance",
+	optDeleteBatch:          "InBatchesOf",
+	optIncludeNulls:         "IncludeNulls",
+	optDatabase:             "OnDatabase",
+	optTimeout:              "WithTimeout",
+	optReadOnly:             "ReadOnly",
+	optSeed:                 "WithSeed",
+	optPageSize:             "WithPageSize",
+}
+
+// The sets of options supported by each family of methods.
+const (
+	// readCallOptions are the per-call execution settings supported by every read method.
+	readCallOptions = optDatabase | optTimeout | optReadOnly
+	// listOptions are supported by finders returning a slice in query order.
+	listOptions = optIndexHint | optCollectMappingErrors | optRewrite | optDistanceOrder | readCallOptions
+	// pageOptions are supported by paginated finders, which impose their own order.
+	pageOptions = optIndexHint | optCollectMappingErrors | optRewrite | readCallOptions
+	// lookupOptions are supported by methods returning a single entity or an aggregate.
+	lookupOptions = optIndexHint | optRewrite | readCallOptions
+	// procedureOptions are supported by methods calling an index procedure instead of
+	// matching nodes, where planner hints do not apply.
+	procedureOptions = optRewrite | readCallOptions
+	// writeOptions are supported by methods writing a single entity or batch.
+	writeOptions = optRewrite | optDatabase | optTimeout
+	// statementOptions are supported by read methods whose query the caller cannot shape,
+	// such as dry-run estimates and the manager's raw and graph queries.
+	statementOptions = optRewrite | readCallOptions
+)
+
+// Direction is the direction of a relationship relative to the entity that declares it.
+type Direction string
+
+const (
+	// Outgoing relationships point from the declaring entity to the target: (a)-[:REL]->(b).
+	Outgoing Direction = "out"
+	// Incoming relationships point from the target to the declaring entity: (a)<-[:REL]-(b).
+	Incoming Direction = "in"
+)
+
+// Sort orders results by a field, given as a Go field name (e.g., "Name") or a mapped
+// property name (e.g., "name"). Sort fields are validated against the entity's mappings.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Asc returns a Sort on field in ascending order.
+func Asc(field string) Sort {
+	return Sort{Field: field}
+}
+
+// Desc returns a Sort on field in descending order.
+func Desc(field string) Sort {
+	return Sort{Field: field, Desc: true}
+}
+
+// PageRequest describes a page of results for offset pagination.
+type PageRequest struct {
+	// Limit is the maximum number of items in the page; it must be positive.
+	Limit int64
+	// Offset is the number of items skipped before the page.
+	Offset int64
+	// Sort lists the orderings to apply, most significant first. Ties are always broken
+	// by primary key so that pages are stable. Empty means primary key order.
+	Sort []Sort
+}
+
+// Page is a page of results together with the total number of matching items. It
+// marshals to JSON for direct use in API responses.
+type Page[T any] struct {
+	Items      []*T  `json:"items"`
+	TotalCount int64 `json:"totalCount"`
+	Limit      int64 `json:"limit"`
+	Offset     int64 `json:"offset"`
+	// HasMore reports whether items exist after this page.
+	HasMore bool `json:"hasMore"`
+}
+
+// newPage assembles a Page, deriving HasMore from the total count.
+func newPage[T any](items []*T, total, limit, offset int64) *Page[T] {
+	if items == nil {
+		items = []*T{}
+	}
+	return &Page[T]{
+		Items:      items,
+		TotalCount: total,
+		Limit:      limit,
+		Offset:     offset,
+		HasMore:    offset+int64(len(items)) < total,
+	}
+}
+
+// queryOptions holds the per-call settings collected from a list of QueryOption values.
+type queryOptions struct {
+	// indexHints are the `USING INDEX` hints to inject after the entity's MATCH clause.
+	indexHints []IndexHint
+	// collectMappingErrors makes slice finders skip unmappable records instead of failing.
+	collectMappingErrors bool
+	// rewriters are the user-supplied per-call query rewriters.
+	rewriters []QueryRewriter
+	// confirmDestructive confirms an unbounded destructive operation.
+	confirmDestructive bool
+	// distanceOrder orders results by distance from a point, if set.
+	distanceOrder *distanceOrder
+	// deleteBatchSize splits bulk deletes into transactions of this many nodes, if positive.
+	deleteBatchSize int
+	// call holds the per-call execution settings (OnDatabase, WithTimeout, ReadOnly).
+	call CallConfig
+	// resultCap is the WithMaxResults cap applied to a slice finder, if positive.
+	resultCap int64
+	// includeNulls makes value helpers report missing property values as nil.
+	includeNulls bool
+	// seed makes FindRandom draw a reproducible sample, if set.
+	seed *int64
+	// pageSize is the number of entities FindAllStream loads per query, if positive.
+	pageSize int
+	// used records which kinds of options were given, for parseOptions.
+	used optionKind
+}
+
+// RepositoryOption configures a Repository when it is created with NewRepository or RepositoryFor.
+type RepositoryOption func(*repositoryConfig)
+
+// repositoryConfig holds the settings collected from a list of RepositoryOption values.
+type repositoryConfig struct {
+	// rewriters are applied to every query issued by the repository.
+	rewriters []QueryRewriter
+	// forbidDestructive rejects unbounded destructive operations even when confirmed.
+	forbidDestructive bool
+	// batchSize is the maximum number of entities sent per statement by bulk operations.
+	batchSize int
+	// entityPool supplies the instances slice finders map records into, if set.
+	entityPool *sync.Pool
+	// entityPoolType is the *T type the pool was declared for by WithEntityPool.
+	entityPoolType reflect.Type
+	// maxResults caps the entities loaded by slice finders, if positive.
+	maxResults int64
+	// validator checks entities before they are written, if set; see WithValidator.
+	validator func(entity any) error
+	// validatorType is the *T type the validator was declared for.
+	validatorType reflect.Type
+	// readOnly makes write methods fail with a *ReadOnlyRepositoryError; see WithReadOnly.
+	readOnly bool
+	// strictTags rejects entities with untagged exported fields; see StrictTags.
+	strictTags bool
+	// lenientMatching makes loads fall back to case-insensitive property names; see
+	// LenientPropertyMatching.
+	lenientMatching bool
+	// timeStorage is the Neo4j type times are written as; see WithTimeStorage.
+	timeStorage TimeStorage
+	// timeLocation is the location loaded times are converted into; see WithTimeLocation.
+	timeLocation *time.Location
+	// converters convert the fields of their type; see WithConverter.
+	converters map[reflect.Type]PropertyConverter
+	// enumParsers parse the fields of their type tagged `enum:string`; see WithEnumParser.
+	enumParsers map[reflect.Type]func(string) (any, error)
+	// namedQueries maps names to the statements registered with WithNamedQuery.
+	namedQueries map[string]string
+}
+
+// defaultBatchSize is the number of entities per statement used by bulk operations
+// when no WithBatchSize option is given.
+const defaultBatchSize = 1000
+
+// WithBatchSize returns a RepositoryOption setting how many entities bulk operations such
+// as SaveAll send per statement. Values of zero or less keep the default of 1000.
+func WithBatchSize(n int) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.batchSize = n
+	}
+}
+
+// WithEntityPool returns a RepositoryOption under which slice finders (FindAll,
+// FindByProperty, Find and the like) take the entities they return from pool instead of
+// allocating a new one per record, which reduces allocations in mapping-heavy workloads.
+// Pooled entities are reset to their zero value before being mapped.
+//
+// The caller owns the returned entities and should hand them back with Repository.Release
+// once they are no longer referenced. The pool's New function, if any, must return *T;
+// entities that are never released are simply garbage collected.
+//
+// Example:
+//
+//	pool := &sync.Pool{New: func() any { return new(models.User) }}
+//	repo, err := neopersist.NewRepository[models.User](runner, neopersist.WithEntityPool[models.User](pool))
+//	users, err := repo.FindAll(ctx)
+//	// ... use users ...
+//	repo.Release(users)
+func WithEntityPool[T any](pool *sync.Pool) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.entityPool = pool
+		c.entityPoolType = reflect.TypeOf((*T)(nil))
+	}
+}
+
+// StrictTags returns a RepositoryOption under which NewRepository fails if an exported
+// field of the entity, or of a nested struct tagged with `prefix:`, carries no `crud` tag.
+// Fields that are deliberately not persisted must then be marked `crud:"-"`. This turns a
+// forgotten tag, which would otherwise silently leave the field unsaved, into an error at
+// startup that names the field.
+func StrictTags() RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.strictTags = true
+	}
+}
+
+// LenientPropertyMatching returns a RepositoryOption under which loading a node falls back
+// to a property whose name differs only in case, such as userID for a field mapped to
+// userId, when neither the mapped property nor its legacy names declared with `aliases:`
+// (consulted first, in order) exist on the node. It helps with graphs whose property
+// casing is inconsistent; writes still use the mapped name. If several properties match,
+// the first in lexical order is used. Exact matching remains the default.
+func LenientPropertyMatching() RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.lenientMatching = true
+	}
+}
+
+// batchSizeOrDefault returns the configured batch size, or the default if unset.
+func (c *repositoryConfig) batchSizeOrDefault() int {
+	if c.batchSize <= 0 {
+		return defaultBatchSize
+	}
+	return c.batchSize
+}
+
+// newQueryOptions applies the given options on top of the default settings.
+func newQueryOptions(opts []QueryOption) *queryOptions {
+	o := &queryOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// parseOptions applies the given options and rejects, with an *UnsupportedOptionError,
+// any option whose kind is not in supported.
+func parseOptions(operation string, opts []QueryOption, supported optionKind) (*queryOptions, error) {
+	o := newQueryOptions(opts)
+	if unsupported := o.used &^ supported; unsupported != 0 {
+		for kind := optionKind(1); kind <= unsupported; kind <<= 1 {
+			if unsupported&kind != 0 {
+				return nil, &UnsupportedOptionError{Operation: operation, Option: optionNames[kind]}
+			}
+		}
+	}
+	return o, nil
+}
+
+// withoutOptions returns the options of opts whose kind is not in kinds, so that a method
+// can consume its own options before delegating the rest to another method.
+func withoutOptions(opts []QueryOption, kinds optionKind) []QueryOption {
+	kept := make([]QueryOption, 0, len(opts))
+	for _, opt := range opts {
+		if opt != nil && newQueryOptions([]QueryOption{opt}).used&kinds == 0 {
+			kept = append(kept, opt)
+		}
+	}
+	return kept
+}
+
+// CollectMappingErrors returns a QueryOption under which slice finders (FindAll,
+// FindByProperty, Find) skip records that cannot be mapped onto the entity instead of
+// aborting. The successfully mapped entities are returned together with a non-nil
+// *MappingErrors describing every skipped record, which can be retrieved via errors.As:
+//
+//	users, err := userRepo.FindAll(ctx, neopersist.CollectMappingErrors())
+//	var mappingErrs *neopersist.MappingErrors
+//	if errors.As(err, &mappingErrs) {
+//	    // users holds the good records; mappingErrs.Errors lists the bad ones.
+//	}
+func CollectMappingErrors() QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optCollectMappingErrors
+		o.collectMappingErrors = true
+	}
+}
+
+// IncludeNulls returns a QueryOption under which DistinctValues also reports entities
+// lacking the property, as a single nil value, instead of skipping them.
+func IncludeNulls() QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optIncludeNulls
+		o.includeNulls = true
+	}
+}
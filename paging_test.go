@@ -0,0 +1,162 @@
+package neopersist_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// followersOf returns a builder matching the users that follow someone, returning the
+// follower first. A user following several people is matched once per followee.
+func followersOf() *gocypher.QueryBuilder {
+	return gocypher.NewQueryBuilder().
+		Match(gocypher.N("u", "User"), gocypher.R("", "FOLLOWS").To(), gocypher.N("f", "User")).
+		Return("u")
+}
+
+func TestFindPageWithTotalCountsDistinctEntities(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Keys: []string{"u", "total"}, Records: []*neopersist.Record{
+			neopersist.NewRecord("u", userNode("u3", "Carol"), "total", int64(5)),
+			neopersist.NewRecord("u", userNode("u4", "Dave"), "total", int64(5)),
+		}}, nil
+	}
+
+	page, err := repo.FindPageWithTotal(context.Background(), followersOf(), 2, 2)
+	if err != nil {
+		t.Fatalf("FindPageWithTotal: %v", err)
+	}
+	if len(page.Items) != 2 || page.Items[0].UserID != "u3" || page.Items[1].UserID != "u4" {
+		t.Errorf("Items = %+v, want u3 and u4", page.Items)
+	}
+	if page.TotalCount != 5 || page.Limit != 2 || page.Offset != 2 || !page.HasMore {
+		t.Errorf("page = %+v, want 5 in total with more after it", page)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{
+			"RETURN count(DISTINCT u) AS total",
+			"RETURN DISTINCT u, total",
+			"ORDER BY u.userId",
+		},
+		Params: map[string]any{"pageOffset": int64(2), "pageLimit": int64(2)},
+	})
+	if strings.Contains(runner.Calls()[0].Query, "count(*)") {
+		t.Errorf("the total counts rows: %s", runner.Calls()[0].Query)
+	}
+}
+
+func TestFindPageWithTotalCountsAnEmptyPageSeparately(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(_ int, query string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		if strings.HasPrefix(query, "CALL") {
+			return &neopersist.ResultSet{Keys: []string{"u", "total"}}, nil
+		}
+		return &neopersist.ResultSet{Keys: []string{"total"}, Records: []*neopersist.Record{
+			neopersist.NewRecord("total", int64(3)),
+		}}, nil
+	}
+
+	page, err := repo.FindPageWithTotal(context.Background(), followersOf(), 10, 50)
+	if err != nil {
+		t.Fatalf("FindPageWithTotal: %v", err)
+	}
+	if len(page.Items) != 0 || page.TotalCount != 3 || page.HasMore {
+		t.Errorf("page = %+v, want no items out of 3", page)
+	}
+	neopersisttest.AssertCallCount(t, runner, 2)
+	count := runner.Calls()[1].Query
+	if !strings.HasSuffix(count, "RETURN count(DISTINCT u) AS total") {
+		t.Errorf("count query = %s, want a distinct count of u", count)
+	}
+}
+
+func TestFindPageWithTotalRejectsInvalidRequests(t *testing.T) {
+	repo, runner := newUserRepo(t)
+
+	tests := map[string]struct {
+		qb            *gocypher.QueryBuilder
+		limit, offset int64
+		want          string
+	}{
+		"zero limit":      {qb: followersOf(), limit: 0, want: "page limit must be positive"},
+		"negative offset": {qb: followersOf(), limit: 10, offset: -1, want: "page offset must not be negative"},
+		"no return": {
+			qb:    gocypher.NewQueryBuilder().Match(gocypher.N("u", "User")),
+			limit: 10,
+			want:  "must have a RETURN clause",
+		},
+		"expression first": {
+			qb:    gocypher.NewQueryBuilder().Match(gocypher.N("u", "User")).Return("u.name", "u"),
+			limit: 10,
+			want:  "must be the entity alias",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := repo.FindPageWithTotal(context.Background(), tt.qb, tt.limit, tt.offset)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("FindPageWithTotal error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestPageMarshalsToJSON(t *testing.T) {
+	page := neopersist.Page[User]{
+		Items:      []*User{{UserID: "u1", Name: "Alice"}},
+		TotalCount: 3,
+		Limit:      1,
+		Offset:     0,
+		HasMore:    true,
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := map[string]string{
+		"items":      `[{"UserID":"u1","Name":"Alice","Email":"","Age":0}]`,
+		"totalCount": "3",
+		"limit":      "1",
+		"offset":     "0",
+		"hasMore":    "true",
+	}
+	if len(got) != len(want) {
+		t.Errorf("JSON = %s, want exactly the fields items, totalCount, limit, offset and hasMore", data)
+	}
+	for field, value := range want {
+		if string(got[field]) != value {
+			t.Errorf("%s = %s, want %s", field, got[field], value)
+		}
+	}
+}
+
+func TestEmptyPageMarshalsItemsAsAnArray(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{}, nil
+	}
+
+	page, err := repo.FindPaged(context.Background(), neopersist.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("FindPaged: %v", err)
+	}
+	data, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"items":[],"totalCount":0,"limit":10,"offset":0,"hasMore":false}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
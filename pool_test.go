@@ -0,0 +1,74 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// statsRecorder is a MetricsCollector keeping every snapshot it receives.
+type statsRecorder struct {
+	mu    sync.Mutex
+	stats []PoolStats
+}
+
+func (r *statsRecorder) ObservePoolStats(stats PoolStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats = append(r.stats, stats)
+}
+
+func (r *statsRecorder) maxInUse() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var peak int64
+	for _, s := range r.stats {
+		peak = max(peak, s.InUse)
+	}
+	return peak
+}
+
+func TestPoolStatsObserveASaturatedPool(t *testing.T) {
+	collector := &statsRecorder{}
+	executor := &Neo4jExecutor{}
+	for _, opt := range []ExecutorOption{WithMaxConnectionPoolSize(2), WithMetricsCollector(collector)} {
+		opt(executor)
+	}
+	ctx := context.Background()
+
+	// Two queries take every connection of the pool and keep them.
+	executor.acquireConn()
+	executor.acquireConn()
+	if stats := executor.PoolStats(); stats.InUse != 2 || stats.MaxSize != 2 {
+		t.Errorf("stats with a saturated pool = %+v, want 2 in use out of 2", stats)
+	}
+
+	// A third query cannot get a connection before its deadline.
+	short, cancel := context.WithDeadline(ctx, time.Unix(0, 0))
+	defer cancel()
+	executor.acquireConn()
+	executor.releaseConn(short, errors.New("could not acquire a connection"))
+	stats := executor.PoolStats()
+	if stats.AcquireTimeouts != 1 || stats.InUse != 2 || stats.TotalAcquired != 3 {
+		t.Errorf("stats after a timeout = %+v, want 1 timeout, 2 in use and 3 acquired", stats)
+	}
+
+	// Failures other than timeouts are not counted.
+	executor.releaseConn(ctx, &DBError{Code: "Neo.ClientError.Statement.SyntaxError"})
+	executor.releaseConn(ctx, nil)
+	stats = executor.PoolStats()
+	if stats.InUse != 0 || stats.AcquireTimeouts != 1 || stats.Idle != -1 {
+		t.Errorf("stats once released = %+v, want none in use, 1 timeout and unknown idle", stats)
+	}
+	if peak := collector.maxInUse(); peak != 3 {
+		t.Errorf("collector saw at most %d queries in use, want 3 including the waiting one", peak)
+	}
+}
+
+func TestPoolStatsDefaultMaxSize(t *testing.T) {
+	if stats := (&Neo4jExecutor{}).PoolStats(); stats.MaxSize != defaultMaxConnectionPoolSize {
+		t.Errorf("MaxSize = %d, want the driver default %d", stats.MaxSize, defaultMaxConnectionPoolSize)
+	}
+}
@@ -0,0 +1,260 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// FindMaps executes a custom query and returns each record as a map from column name to
+// value, without mapping it onto the entity type. It is the escape hatch for queries whose
+// results do not correspond to the entity, such as aggregations or joined data, which Find
+// would silently turn into zero-valued structs.
+//
+// Whole nodes (e.g., `RETURN u`) are expanded into their property maps; other values are
+// returned as they come from the runner.
+//
+// Example:
+//
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("u", "User")).
+//	    Return("u.country AS country", "count(u) AS users")
+//	rows, err := userRepo.FindMaps(ctx, qb)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - qb: A configured gocypher.QueryBuilder instance.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	One map per record, or an error if the query cannot be built or fails.
+func (r *Repository[T]) FindMaps(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) ([]map[string]interface{}, error) {
+	options, err := parseOptions("FindMaps", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("FindMaps", r.meta.Label, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]interface{}, len(eagerResult.Records))
+	for i, record := range eagerResult.Records {
+		rows[i] = recordToMap(record)
+	}
+	return rows, nil
+}
+
+// recordToMap converts a record into a column→value map, expanding whole nodes into their
+// property maps.
+func recordToMap(record *Record) map[string]interface{} {
+	row := make(map[string]interface{}, len(record.Keys))
+	for i, key := range record.Keys {
+		value := record.Values[i]
+		if node, ok := value.(Node); ok {
+			value = node.Props
+		}
+		row[key] = value
+	}
+	return row
+}
+
+// RowOf is a record returned by FindWithExtras: the entity mapped from the record's node,
+// and the other columns of the record.
+type RowOf[T any] struct {
+	// Entity is the entity mapped from the first node of the record.
+	Entity *T `json:"entity"`
+	// Extras holds the record's other columns, keyed by column name (e.g., "postCount").
+	Extras map[string]interface{} `json:"extras"`
+}
+
+// FindWithExtras executes a custom query returning an entity node alongside computed
+// columns, such as `RETURN u, count(p) AS postCount`, and keeps both: each record becomes
+// a RowOf whose Entity is mapped from the first node of the record, as Find would, and
+// whose Extras hold every other column. This avoids a throwaway wrapper struct per report
+// query. Records without a node are mapped from their projected columns, which then all
+// appear in Extras too.
+//
+// Example:
+//
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("u", "User"), gocypher.R("", "WROTE").To(), gocypher.N("p", "Post")).
+//	    Return("u", "count(p) AS postCount")
+//	rows, err := userRepo.FindWithExtras(ctx, qb)
+//	for _, row := range rows {
+//	    fmt.Println(row.Entity.Name, row.Extras["postCount"])
+//	}
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - qb: A configured gocypher.QueryBuilder instance.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	One row per record, or an error if the query cannot be built, fails, or a record
+//	cannot be mapped.
+func (r *Repository[T]) FindWithExtras(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) ([]RowOf[T], error) {
+	options, err := parseOptions("FindWithExtras", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("FindWithExtras", r.meta.Label, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]RowOf[T], len(eagerResult.Records))
+	for i, record := range eagerResult.Records {
+		entity := new(T)
+		if err := mapRecordToStruct(record, entity, r.meta); err != nil {
+			return nil, err
+		}
+		if err := r.afterLoad(ctx, entity, i); err != nil {
+			return nil, err
+		}
+		extras := make(map[string]interface{}, len(record.Keys))
+		consumed := false
+		for j, key := range record.Keys {
+			// mapRecordToStruct maps the first node of the record, which is not an extra.
+			if _, ok := record.Values[j].(Node); ok && !consumed {
+				consumed = true
+				continue
+			}
+			extras[key] = record.Values[j]
+		}
+		rows[i] = RowOf[T]{Entity: entity, Extras: extras}
+	}
+	return rows, nil
+}
+
+// projectionField is a field of a projection struct and the column it is read from.
+type projectionField struct {
+	index  int
+	name   string
+	column string
+	// exact is true when the column comes from a `crud:"property:..."` tag and must match
+	// exactly; otherwise it is the field name, matched case-insensitively.
+	exact bool
+}
+
+// FindAs executes a custom query and maps each record onto a projection struct P, such as
+// a read model for `RETURN u.name AS name, count(p) AS postCount`. Unlike entities,
+// projections need no primary key or label.
+//
+// Each exported field of P is read from the column named by its `crud:"property:..."` tag
+// component if it has one, or otherwise from the column whose name, or whose last segment
+// after a dot (as in `u.name`), equals the field name ignoring case. Fields without a
+// matching column keep their zero value. Integer and float columns are converted to the
+// width of numeric fields, failing on overflow instead of panicking.
+//
+// Example:
+//
+//	type AuthorStats struct {
+//	    Name      string
+//	    PostCount int `crud:"property:posts"`
+//	}
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("u", "User"), gocypher.R("", "WROTE").To(), gocypher.N("p", "Post")).
+//	    Return("u.name AS name", "count(p) AS posts")
+//	stats, err := neopersist.FindAs[AuthorStats](ctx, runner, qb)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - runner: The DBRunner executing the query.
+//   - qb: A configured gocypher.QueryBuilder instance.
+//
+// Returns:
+//
+//	One projection per record, or an error if P is not a struct, the query fails or a
+//	value cannot be assigned to its field.
+func FindAs[P any](ctx context.Context, runner DBRunner, qb *gocypher.QueryBuilder) ([]*P, error) {
+	typ := reflect.TypeOf((*P)(nil)).Elem()
+	fields, err := projectionFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("FindAs", typ.Name(), qb)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := runner.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	projections := make([]*P, len(result.Records))
+	for i, record := range result.Records {
+		projection := new(P)
+		if err := mapProjection(record, reflect.ValueOf(projection).Elem(), fields); err != nil {
+			return nil, err
+		}
+		projections[i] = projection
+	}
+	return projections, nil
+}
+
+// projectionFields lists the exported fields of a projection struct and their columns.
+func projectionFields(typ reflect.Type) ([]projectionField, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("projection type %s is not a struct", typ)
+	}
+	var fields []projectionField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		pf := projectionField{index: i, name: field.Name, column: field.Name}
+		for _, part := range strings.Split(field.Tag.Get("crud"), ",") {
+			if strings.HasPrefix(part, "property:") {
+				pf.column = strings.TrimPrefix(part, "property:")
+				pf.exact = true
+			}
+		}
+		fields = append(fields, pf)
+	}
+	return fields, nil
+}
+
+// mapProjection fills the fields of a projection from a record.
+func mapProjection(record *Record, val reflect.Value, fields []projectionField) error {
+	for _, pf := range fields {
+		value, ok := projectionValue(record, pf)
+		if !ok || value == nil {
+			continue
+		}
+		if err := setCoercedValue(val.Field(pf.index), value); err != nil {
+			return &MappingError{Field: pf.name, Property: pf.column, Err: err}
+		}
+	}
+	return nil
+}
+
+// projectionValue finds the column feeding a projection field.
+func projectionValue(record *Record, pf projectionField) (any, bool) {
+	if pf.exact {
+		return projectedValue(record, pf.column)
+	}
+	for i, key := range record.Keys {
+		if dot := strings.LastIndexByte(key, '.'); dot >= 0 {
+			key = key[dot+1:]
+		}
+		if strings.EqualFold(key, pf.column) {
+			return record.Values[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,153 @@
+package neopersist_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// ShipmentStatus is an enum stored by name.
+type ShipmentStatus int
+
+const (
+	Pending ShipmentStatus = iota
+	Shipped
+)
+
+func (s ShipmentStatus) String() string {
+	return [...]string{"pending", "shipped"}[s]
+}
+
+func ParseShipmentStatus(s string) (ShipmentStatus, error) {
+	switch s {
+	case "pending":
+		return Pending, nil
+	case "shipped":
+		return Shipped, nil
+	}
+	return 0, fmt.Errorf("no such status")
+}
+
+// Money is an amount stored as an integer number of cents.
+type Money struct {
+	Cents int64
+}
+
+// centsConverter stores Money as its cents.
+type centsConverter struct{}
+
+func (centsConverter) ToProperty(v any) (any, error) {
+	return v.(Money).Cents, nil
+}
+
+func (centsConverter) FromProperty(p any) (any, error) {
+	cents, ok := p.(int64)
+	if !ok {
+		return nil, fmt.Errorf("expected an integer, got %T", p)
+	}
+	return Money{Cents: cents}, nil
+}
+
+// ShipmentID is a key of a named string type.
+type ShipmentID string
+
+// Shipment has properties of every type needing a conversion before being matched.
+type Shipment struct {
+	ID        ShipmentID     `crud:"pk,property:id"`
+	Status    ShipmentStatus `crud:"property:status,enum:string"`
+	Price     Money          `crud:"property:price"`
+	ShippedAt time.Time      `crud:"property:shippedAt"`
+	Carrier   *string        `crud:"property:carrier"`
+}
+
+func newShipmentRepo(t *testing.T, opts ...neopersist.RepositoryOption) (*neopersist.Repository[Shipment], *neopersisttest.FakeRunner) {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	opts = append([]neopersist.RepositoryOption{
+		neopersist.WithEnumParser(ParseShipmentStatus),
+		neopersist.WithConverter(reflect.TypeOf(Money{}), centsConverter{}),
+	}, opts...)
+	repo, err := neopersist.NewRepository[Shipment](runner, opts...)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	return repo, runner
+}
+
+func TestFindByPropertiesConvertsValuesLikeFindByProperty(t *testing.T) {
+	repo, runner := newShipmentRepo(t, neopersist.WithTimeStorage(neopersist.TimeAsLocalDateTime))
+	shippedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := repo.FindByProperties(context.Background(), map[string]interface{}{
+		"ID":        ShipmentID("s1"),
+		"Status":    Shipped,
+		"price":     Money{Cents: 1999},
+		"ShippedAt": shippedAt,
+	})
+	if err != nil {
+		t.Fatalf("FindByProperties: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"MATCH (n:Shipment {", "RETURN n"},
+		Params: map[string]any{
+			"id":        "s1",
+			"status":    "shipped",
+			"price":     int64(1999),
+			"shippedAt": neopersist.LocalDateTime(shippedAt),
+		},
+		ExactParams: true,
+	})
+
+	for prop, value := range map[string]interface{}{"Status": Shipped, "ID": ShipmentID("s1")} {
+		runner.Reset()
+		if _, err := repo.FindByProperty(context.Background(), prop, value); err != nil {
+			t.Fatalf("FindByProperty(%s): %v", prop, err)
+		}
+		single := runner.Calls()[0]
+		runner.Reset()
+		if _, err := repo.FindByProperties(context.Background(), map[string]interface{}{prop: value}); err != nil {
+			t.Fatalf("FindByProperties(%s): %v", prop, err)
+		}
+		neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: single.Query, Params: single.Params, ExactParams: true})
+	}
+}
+
+func TestFindByPropertiesMatchesNilValuesWithIsNull(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+
+	_, err := repo.FindByProperties(context.Background(), map[string]interface{}{
+		"Status":  Pending,
+		"Carrier": (*string)(nil),
+	})
+	if err != nil {
+		t.Fatalf("FindByProperties: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query:       "MATCH (n:Shipment {status: $status}) WHERE n.carrier IS NULL RETURN n",
+		Params:      map[string]any{"status": "pending"},
+		ExactParams: true,
+	})
+
+	runner.Reset()
+	if _, err := repo.FindByProperties(context.Background(), map[string]interface{}{"carrier": nil, "shippedAt": nil}); err != nil {
+		t.Fatalf("FindByProperties: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (n:Shipment) WHERE n.carrier IS NULL AND n.shippedAt IS NULL RETURN n",
+	})
+}
+
+func TestFindByPropertiesRejectsEmptyAndUnknownProperties(t *testing.T) {
+	repo, runner := newShipmentRepo(t)
+	for _, props := range []map[string]interface{}{nil, {"weight": 3}, {"Status": Shipped, "status": "shipped"}} {
+		if _, err := repo.FindByProperties(context.Background(), props); err == nil {
+			t.Errorf("FindByProperties(%v) succeeded", props)
+		}
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
@@ -0,0 +1,81 @@
+package neopersist
+
+import (
+	"context"
+	"time"
+)
+
+// Default property names used by WithRelationshipProvenance.
+const (
+	defaultCreatedAtProperty = "createdAt"
+	defaultCreatedByProperty = "createdBy"
+)
+
+// relationshipProvenance configures the properties stamped on relationships the manager creates.
+type relationshipProvenance struct {
+	// enabled is set by WithRelationshipProvenance.
+	enabled bool
+	// actor returns the identity recorded in the created-by property; empty means omit it.
+	actor func(ctx context.Context) string
+	// createdAtProp and createdByProp are the property names to stamp.
+	createdAtProp string
+	createdByProp string
+}
+
+// WithRelationshipProvenance returns a ManagerOption that stamps every relationship created
+// by CreateRelation or Relate with its creation time and the acting identity, for lineage.
+// actorFromCtx extracts the actor from the call's context (e.g., the authenticated user);
+// it may be nil, and an empty actor leaves the created-by property unset.
+//
+// CreateRelation and Relate are the only methods that stamp provenance. The manager has no
+// MergeRelation or batch CreateRelations; relationships created any other way, such as
+// with ExecuteWithStats or a migration, are not stamped.
+//
+// The properties are only set when a relationship is created: Relate does not touch them
+// when the relationship already exists, and values supplied by the caller in the property
+// map of CreateRelation are never overwritten. The property names default to "createdAt"
+// and "createdBy" and can be changed with WithProvenanceProperties.
+func WithRelationshipProvenance(actorFromCtx func(ctx context.Context) string) ManagerOption {
+	return func(pm *PersistenceManager) {
+		if pm.provenance == nil {
+			pm.provenance = &relationshipProvenance{
+				createdAtProp: defaultCreatedAtProperty,
+				createdByProp: defaultCreatedByProperty,
+			}
+		}
+		pm.provenance.enabled = true
+		pm.provenance.actor = actorFromCtx
+	}
+}
+
+// WithProvenanceProperties returns a ManagerOption that changes the property names used by
+// WithRelationshipProvenance. An empty name disables the corresponding property. It has no
+// effect unless WithRelationshipProvenance is also given; the options may appear in any order.
+func WithProvenanceProperties(createdAt, createdBy string) ManagerOption {
+	return func(pm *PersistenceManager) {
+		if pm.provenance == nil {
+			pm.provenance = &relationshipProvenance{}
+		}
+		pm.provenance.createdAtProp = createdAt
+		pm.provenance.createdByProp = createdBy
+	}
+}
+
+// provenanceProperties returns the properties to stamp on a relationship created now,
+// or nil if provenance is not enabled.
+func (pm *PersistenceManager) provenanceProperties(ctx context.Context) map[string]interface{} {
+	p := pm.provenance
+	if p == nil || !p.enabled {
+		return nil
+	}
+	props := make(map[string]interface{}, 2)
+	if p.createdAtProp != "" {
+		props[p.createdAtProp] = time.Now().UTC()
+	}
+	if p.createdByProp != "" && p.actor != nil {
+		if actor := p.actor(ctx); actor != "" {
+			props[p.createdByProp] = actor
+		}
+	}
+	return props
+}
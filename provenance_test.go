@@ -0,0 +1,135 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/examples/models"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+type actorKey struct{}
+
+// actorFromCtx reads the actor stored in a context by withActor.
+func actorFromCtx(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+func withActor(actor string) context.Context {
+	return context.WithValue(context.Background(), actorKey{}, actor)
+}
+
+func TestCreateRelationStampsProvenance(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner, neopersist.WithRelationshipProvenance(actorFromCtx))
+	before := time.Now().UTC()
+
+	err := pm.CreateRelation(withActor("ada"), &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "LIKES", nil)
+	if err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"CREATE (a)-[r:LIKES {", "createdAt: $createdAt", "createdBy: $createdBy"},
+		Params:        map[string]any{"createdBy": "ada"},
+	})
+	createdAt, ok := runner.Calls()[0].Params["createdAt"].(time.Time)
+	if !ok || createdAt.Before(before) || createdAt.Location() != time.UTC {
+		t.Errorf("createdAt = %#v, want the UTC creation time", runner.Calls()[0].Params["createdAt"])
+	}
+}
+
+func TestCreateRelationKeepsCallerProvenanceValues(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner, neopersist.WithRelationshipProvenance(actorFromCtx))
+	imported := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err := pm.CreateRelation(withActor("ada"), &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "LIKES",
+		map[string]interface{}{"createdAt": imported, "createdBy": "importer"})
+	if err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Params: map[string]any{"createdAt": imported, "createdBy": "importer"},
+	})
+}
+
+func TestProvenancePropertyNamesAreConfigurable(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	// The options apply in either order.
+	pm := neopersist.NewPersistenceManager(runner,
+		neopersist.WithProvenanceProperties("linkedAt", ""),
+		neopersist.WithRelationshipProvenance(actorFromCtx),
+	)
+
+	err := pm.CreateRelation(withActor("ada"), &models.User{UserID: "u1"}, &models.Post{PostID: "p1"}, "LIKES", nil)
+	if err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	call := runner.Calls()[0]
+	if _, ok := call.Params["linkedAt"].(time.Time); !ok {
+		t.Errorf("params = %v, want the creation time under linkedAt", call.Params)
+	}
+	if _, ok := call.Params["createdBy"]; ok || strings.Contains(call.Query, "createdAt") {
+		t.Errorf("query %q with params %v still uses a disabled or default property", call.Query, call.Params)
+	}
+}
+
+func TestRelateOnlyStampsProvenanceOnCreate(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	pm := neopersist.NewPersistenceManager(runner, neopersist.WithRelationshipProvenance(actorFromCtx))
+
+	if err := pm.Relate(withActor("ada"), &models.Post{PostID: "p1"}, "Author", &models.User{UserID: "u1"}); err != nil {
+		t.Fatalf("Relate: %v", err)
+	}
+	neopersisttest.AssertCalls(t, runner, neopersisttest.Expect{
+		Query: "MATCH (a:Post {postId: $fromId}) MATCH (b:User {userId: $toId}) " +
+			"MERGE (a)<-[r:WROTE]-(b) ON CREATE SET r += $provenance",
+	})
+	provenance, _ := runner.Calls()[0].Params["provenance"].(map[string]interface{})
+	if provenance["createdBy"] != "ada" {
+		t.Errorf("provenance = %v, want createdBy ada", provenance)
+	}
+}
+
+func TestIntegrationRemergeKeepsCreatedAt(t *testing.T) {
+	executor := integrationExecutor(t)
+	ctx := withActor("ada")
+	cleanup := func() {
+		_, _ = executor.Run(ctx, "MATCH (n) WHERE n:User AND n.userId = 'prov-u1' OR n:Post AND n.postId = 'prov-p1' DETACH DELETE n", nil)
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+	if _, err := executor.Run(ctx, "CREATE (:User {userId: 'prov-u1'}), (:Post {postId: 'prov-p1'})", nil); err != nil {
+		t.Fatalf("could not create the nodes: %v", err)
+	}
+	pm := neopersist.NewPersistenceManager(executor, neopersist.WithRelationshipProvenance(actorFromCtx))
+	post, user := &models.Post{PostID: "prov-p1"}, &models.User{UserID: "prov-u1"}
+	createdAt := func() any {
+		t.Helper()
+		result, err := executor.Run(ctx, "MATCH (:User {userId: 'prov-u1'})-[r:WROTE]->(:Post {postId: 'prov-p1'}) RETURN r.createdAt AS createdAt, count(r) AS count", nil)
+		if err != nil || len(result.Records) != 1 {
+			t.Fatalf("could not read the relationship: %v", err)
+		}
+		if count, _ := result.Records[0].Get("count"); count != int64(1) {
+			t.Fatalf("found %v WROTE relationships, want 1", count)
+		}
+		value, _ := result.Records[0].Get("createdAt")
+		return value
+	}
+
+	if err := pm.Relate(ctx, post, "Author", user); err != nil {
+		t.Fatalf("Relate: %v", err)
+	}
+	first := createdAt()
+	time.Sleep(10 * time.Millisecond)
+	if err := pm.Relate(withActor("bob"), post, "Author", user); err != nil {
+		t.Fatalf("Relate again: %v", err)
+	}
+	if second := createdAt(); second != first {
+		t.Errorf("re-merging bumped createdAt from %v to %v", first, second)
+	}
+}
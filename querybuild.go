@@ -0,0 +1,90 @@
+package neopersist
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+// ErrNilQueryBuilder is reported, wrapped in a *QueryBuildError, when a nil
+// *gocypher.QueryBuilder is passed to a method that expects one.
+var ErrNilQueryBuilder = errors.New("query builder is nil")
+
+// QueryBuildError reports that a caller-supplied gocypher.QueryBuilder could not be built.
+// It records which operation received the builder and the clauses the builder held at the
+// time, which helps to debug builders assembled dynamically.
+type QueryBuildError struct {
+	// Operation is the method that received the builder (e.g., "Find").
+	Operation string
+	// Label is the entity label of the repository, or empty for manager operations.
+	Label string
+	// Clauses are the clauses successfully added to the builder before the failure, in
+	// the order Build would render them (e.g., "MATCH (n:User {email: $email})").
+	Clauses []string
+	// Err is the underlying cause.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *QueryBuildError) Error() string {
+	operation := e.Operation
+	if e.Label != "" {
+		operation = fmt.Sprintf("%s on %s", e.Operation, e.Label)
+	}
+	if len(e.Clauses) == 0 {
+		return fmt.Sprintf("%s: could not build query: %v (builder has no clauses)", operation, e.Err)
+	}
+	return fmt.Sprintf("%s: could not build query: %v (builder clauses: %s)", operation, e.Err, strings.Join(e.Clauses, " | "))
+}
+
+// Unwrap returns the underlying cause.
+func (e *QueryBuildError) Unwrap() error {
+	return e.Err
+}
+
+// buildQuery builds a caller-supplied builder, wrapping failures in a *QueryBuildError.
+func buildQuery(operation, label string, qb *gocypher.QueryBuilder) (string, map[string]interface{}, error) {
+	if qb == nil {
+		return "", nil, &QueryBuildError{Operation: operation, Label: label, Err: ErrNilQueryBuilder}
+	}
+	query, params, err := qb.Build()
+	if err != nil {
+		return "", nil, &QueryBuildError{Operation: operation, Label: label, Clauses: builderClauses(qb), Err: err}
+	}
+	return query, params, nil
+}
+
+// builderClauses returns the clauses held by a builder. gocypher does not expose them, so
+// they are read from its unexported fields; fields that cannot be found are skipped, which
+// only makes the reported state less detailed.
+func builderClauses(qb *gocypher.QueryBuilder) []string {
+	val := reflect.ValueOf(qb).Elem()
+	var clauses []string
+	for _, name := range []string{"matchClauses", "mergeClauses", "createClauses"} {
+		clauses = append(clauses, stringSliceField(val, name)...)
+	}
+	if set := stringSliceField(val, "setClauses"); len(set) > 0 {
+		clauses = append(clauses, "SET "+strings.Join(set, ", "))
+	}
+	clauses = append(clauses, stringSliceField(val, "deleteClauses")...)
+	if aliases := stringSliceField(val, "returnAliases"); len(aliases) > 0 {
+		clauses = append(clauses, "RETURN "+strings.Join(aliases, ", "))
+	}
+	return clauses
+}
+
+// stringSliceField reads a []string struct field by name, or returns nil if there is none.
+func stringSliceField(val reflect.Value, name string) []string {
+	field := val.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+		return nil
+	}
+	values := make([]string, field.Len())
+	for i := range values {
+		values[i] = field.Index(i).String()
+	}
+	return values
+}
@@ -0,0 +1,107 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RawResult is the result of QueryRaw.
+type RawResult struct {
+	// Keys are the names of the columns the query returned, in order. They are reported
+	// even when the query returned no rows.
+	Keys []string
+	// Rows holds one map per record, from column name to value. Whole nodes are expanded
+	// into their property maps.
+	Rows []map[string]interface{}
+}
+
+// ExecuteResult is the result of ExecuteWithStats.
+type ExecuteResult struct {
+	// Keys are the names of the columns the statement returned, in order, if any.
+	Keys []string
+	// Counters report the updates made by the statement.
+	Counters Counters
+}
+
+// QueryRaw runs a hand-written Cypher query that is not tied to an entity type, such as a
+// report joining several labels, and returns its rows together with the ordered column
+// names, so that callers can render the result without running the query twice.
+//
+// Example:
+//
+//	result, err := manager.QueryRaw(ctx,
+//	    "MATCH (u:User)-[:WROTE]->(p:Post) RETURN u.name AS author, count(p) AS posts", nil)
+//	fmt.Println(result.Keys) // [author posts]
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - cypher: The Cypher query; values must be passed as $parameters.
+//   - params: The query parameters.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or ReadOnly.
+//
+// Returns:
+//
+//	The ordered column names and the rows, or an error if the query is empty or fails.
+func (pm *PersistenceManager) QueryRaw(ctx context.Context, cypher string, params map[string]interface{}, opts ...FindOption) (*RawResult, error) {
+	options, err := parseOptions("QueryRaw", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(cypher) == "" {
+		return nil, fmt.Errorf("QueryRaw: query is empty")
+	}
+	eagerResult, err := pm.run(ctx, cypher, params, options)
+	if err != nil {
+		return nil, err
+	}
+	result := &RawResult{Keys: resultKeys(eagerResult), Rows: make([]map[string]interface{}, len(eagerResult.Records))}
+	for i, record := range eagerResult.Records {
+		result.Rows[i] = recordToMap(record)
+	}
+	return result, nil
+}
+
+// ExecuteWithStats runs a hand-written Cypher statement, typically a write, and returns
+// the update counters of its result summary together with the ordered names of the
+// columns it returned, if any. Returned rows are discarded.
+//
+// Example:
+//
+//	result, err := manager.ExecuteWithStats(ctx,
+//	    "MATCH (u:User {active: false}) SET u:Archived", nil)
+//	log.Printf("archived %d users", result.Counters.LabelsAdded)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - cypher: The Cypher statement; values must be passed as $parameters.
+//   - params: The statement parameters.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The column names and update counters, or an error if the statement is empty or fails.
+func (pm *PersistenceManager) ExecuteWithStats(ctx context.Context, cypher string, params map[string]interface{}, opts ...WriteOption) (*ExecuteResult, error) {
+	options, err := parseOptions("ExecuteWithStats", opts, writeOptions)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(cypher) == "" {
+		return nil, fmt.Errorf("ExecuteWithStats: statement is empty")
+	}
+	eagerResult, err := pm.run(ctx, cypher, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecuteResult{Keys: resultKeys(eagerResult), Counters: eagerResult.Counters}, nil
+}
+
+// resultKeys returns a copy of the column names of a result, falling back to those of its
+// first record for runners that do not fill ResultSet.Keys.
+func resultKeys(result *ResultSet) []string {
+	keys := result.Keys
+	if len(keys) == 0 && len(result.Records) > 0 {
+		keys = result.Records[0].Keys
+	}
+	return append([]string{}, keys...)
+}
@@ -0,0 +1,95 @@
+package neopersist_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+func TestQueryRawReturnsKeysInOrder(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{
+			Keys:    []string{"author", "posts"},
+			Records: []*neopersist.Record{neopersist.NewRecord("author", "Ada", "posts", int64(3))},
+		}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	result, err := pm.QueryRaw(context.Background(), "MATCH (u:User)-[:WROTE]->(p) RETURN u.name AS author, count(p) AS posts", nil)
+	if err != nil {
+		t.Fatalf("QueryRaw: %v", err)
+	}
+	if !reflect.DeepEqual(result.Keys, []string{"author", "posts"}) {
+		t.Errorf("Keys = %v, want [author posts]", result.Keys)
+	}
+	if len(result.Rows) != 1 || result.Rows[0]["author"] != "Ada" || result.Rows[0]["posts"] != int64(3) {
+		t.Errorf("Rows = %v, want one row for Ada", result.Rows)
+	}
+}
+
+func TestQueryRawReportsKeysWithoutRows(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Keys: []string{"name"}}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	result, err := pm.QueryRaw(context.Background(), "MATCH (u:User) RETURN u.name AS name", nil)
+	if err != nil {
+		t.Fatalf("QueryRaw: %v", err)
+	}
+	if !reflect.DeepEqual(result.Keys, []string{"name"}) || len(result.Rows) != 0 {
+		t.Errorf("result = %+v, want the name column and no rows", result)
+	}
+}
+
+func TestExecuteWithStatsReturnsKeysAndCounters(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{
+			Keys:     []string{"archived"},
+			Records:  []*neopersist.Record{neopersist.NewRecord("archived", int64(2))},
+			Counters: neopersist.Counters{LabelsAdded: 2},
+		}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	result, err := pm.ExecuteWithStats(context.Background(), "MATCH (u:User {active: $active}) SET u:Archived RETURN count(u) AS archived", map[string]interface{}{"active": false})
+	if err != nil {
+		t.Fatalf("ExecuteWithStats: %v", err)
+	}
+	if !reflect.DeepEqual(result.Keys, []string{"archived"}) || result.Counters.LabelsAdded != 2 {
+		t.Errorf("result = %+v, want the archived column and 2 labels added", result)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Params: map[string]any{"active": false}, ExactParams: true})
+}
+
+func TestDescribeQueryExplainsWithoutChangingTheBuilder(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Keys: []string{"country", "users"}}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+	qb := gocypher.NewQueryBuilder().Match(gocypher.N("u", "User")).Return("u.country AS country", "count(u) AS users")
+	before, _, err := qb.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	description, err := pm.DescribeQuery(context.Background(), qb)
+	if err != nil {
+		t.Fatalf("DescribeQuery: %v", err)
+	}
+	if !reflect.DeepEqual(description.Keys, []string{"country", "users"}) {
+		t.Errorf("Keys = %v, want [country users]", description.Keys)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: "EXPLAIN " + before})
+	if after, _, _ := qb.Build(); after != before {
+		t.Errorf("DescribeQuery changed the builder:\n%s\nbecame\n%s", before, after)
+	}
+}
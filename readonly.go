@@ -0,0 +1,100 @@
+package neopersist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReadOnlyRepository is matched, via errors.Is, by the *ReadOnlyRepositoryError returned
+// when a write method is called on a repository created with WithReadOnly.
+var ErrReadOnlyRepository = errors.New("repository is read-only")
+
+// ReadOnlyRepositoryError reports a write method called on a read-only repository. It is
+// returned before any query reaches the runner.
+type ReadOnlyRepositoryError struct {
+	// Operation is the write method that was called (e.g., "Save").
+	Operation string
+	// Label is the label of the repository's entities.
+	Label string
+}
+
+// Error implements the error interface.
+func (e *ReadOnlyRepositoryError) Error() string {
+	return fmt.Sprintf("%s: %s on %s is not allowed", ErrReadOnlyRepository, e.Operation, e.Label)
+}
+
+// Unwrap allows errors.Is(err, ErrReadOnlyRepository) to match a *ReadOnlyRepositoryError.
+func (e *ReadOnlyRepositoryError) Unwrap() error {
+	return ErrReadOnlyRepository
+}
+
+// WithReadOnly returns a RepositoryOption making the repository unable to write, as a
+// defense in depth for services that must only read, such as reporting services pointed
+// at a cluster follower. Write methods (Save, Create, Update, Delete, SaveAll and the
+// like) fail with a *ReadOnlyRepositoryError without issuing any query.
+//
+// Reads are routed to a reader of the cluster, as with the ReadOnly per-call option, when
+// the runner implements ConfigurableRunner. Whatever the runner, statements passed to read
+// methods such as Find, FindRaw or AggregateWithQuery are rejected the same way, before
+// reaching the runner, if they contain a write clause (CREATE, MERGE, SET and the like).
+//
+// Example:
+//
+//	reports, err := neopersist.NewRepository[models.Order](runner, neopersist.WithReadOnly())
+func WithReadOnly() RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.readOnly = true
+	}
+}
+
+// parseWriteOptions is parseOptions for write methods: it fails with a
+// *ReadOnlyRepositoryError if the repository was created with WithReadOnly.
+func (r *Repository[T]) parseWriteOptions(operation string, opts []QueryOption, supported optionKind) (*queryOptions, error) {
+	if r.config.readOnly {
+		return nil, &ReadOnlyRepositoryError{Operation: operation, Label: r.meta.Label}
+	}
+	return parseOptions(operation, opts, supported)
+}
+
+// writeClauseKeywords are the clauses a statement run by a read-only repository may not
+// contain.
+var writeClauseKeywords = map[string]bool{
+	"CREATE": true, "MERGE": true, "SET": true, "DELETE": true, "DETACH": true,
+	"REMOVE": true, "FOREACH": true, "DROP": true,
+}
+
+// checkReadOnlyQuery fails with a *ReadOnlyRepositoryError if the repository is read-only
+// and the statement contains a write clause outside string literals, comments, property
+// names, labels and map keys, so that read-only holds even when the runner cannot open
+// read sessions.
+func (r *Repository[T]) checkReadOnlyQuery(query string) error {
+	if !r.config.readOnly {
+		return nil
+	}
+	tokens, err := scanCypher(query)
+	if err != nil {
+		return fmt.Errorf("cannot check the statement of read-only repository %s: %w", r.meta.Label, err)
+	}
+	for i, token := range tokens {
+		if !token.isKeyword(writeClauseKeywords) {
+			continue
+		}
+		// A word right after a colon is a label or relationship type, and one right
+		// before a colon is a map key.
+		if i > 0 && tokens[i-1].text == ":" || i+1 < len(tokens) && tokens[i+1].text == ":" {
+			continue
+		}
+		return &ReadOnlyRepositoryError{Operation: token.upper(), Label: r.meta.Label}
+	}
+	return nil
+}
+
+// callConfig returns the execution settings of a call, routing it to a reader when the
+// repository is read-only and the runner can honor it.
+func (r *Repository[T]) callConfig(options *queryOptions) CallConfig {
+	call := options.call
+	if _, ok := r.runner.(ConfigurableRunner); ok && r.config.readOnly {
+		call.ReadOnly = true
+	}
+	return call
+}
@@ -0,0 +1,81 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+	"github.com/saulfrancisco-ruizacevedo/gocypher"
+)
+
+func TestReadOnlyRepositoryRejectsWriteStatementsOnAPlainRunner(t *testing.T) {
+	ctx := context.Background()
+	repo, runner := newUserRepo(t, neopersist.WithReadOnly())
+	calls := map[string]func() error{
+		"Save": func() error {
+			return repo.Save(ctx, &User{UserID: "u1", Name: "Ada"})
+		},
+		"Find": func() error {
+			_, err := repo.Find(ctx, gocypher.NewQueryBuilder().
+				Merge(gocypher.N("n", "User").WithProperties(map[string]interface{}{"userId": "u1"})).
+				Return("n"))
+			return err
+		},
+		"FindRaw": func() error {
+			_, err := repo.FindRaw(ctx, "MATCH (n:User) SET n.name = $name RETURN n", map[string]interface{}{"name": "Ada"})
+			return err
+		},
+		"FindRaw lower case": func() error {
+			_, err := repo.FindRaw(ctx, "match (n:User) detach delete n return n", nil)
+			return err
+		},
+		"AggregateWithQuery": func() error {
+			_, err := repo.AggregateWithQuery(ctx, gocypher.NewQueryBuilder().
+				Create(gocypher.N("n", "User")).
+				Return("count(n) AS value"))
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		var readOnly *neopersist.ReadOnlyRepositoryError
+		if !errors.Is(err, neopersist.ErrReadOnlyRepository) || !errors.As(err, &readOnly) || readOnly.Label != "User" {
+			t.Errorf("%s: err = %v, want a *ReadOnlyRepositoryError for User", name, err)
+		}
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestReadOnlyRepositoryRejectsRewrittenWritesOnStreams(t *testing.T) {
+	runner := newStreamRunner()
+	repo, err := neopersist.NewRepository[User](runner, neopersist.WithReadOnly())
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	touch := neopersist.QueryRewriterFunc(func(query string, params map[string]any) (string, map[string]any, error) {
+		return strings.Replace(query, "RETURN n", "SET n.seen = true RETURN n", 1), params, nil
+	})
+
+	if _, err := repo.FindAllIter(context.Background(), neopersist.RewriteQuery(touch)); !errors.Is(err, neopersist.ErrReadOnlyRepository) {
+		t.Fatalf("FindAllIter: err = %v, want ErrReadOnlyRepository", err)
+	}
+	neopersisttest.AssertCallCount(t, runner.FakeRunner, 0)
+}
+
+func TestReadOnlyRepositoryRunsReadStatements(t *testing.T) {
+	repo, runner := newUserRepo(t, neopersist.WithReadOnly())
+	queries := []string{
+		"MATCH (n:User) WHERE n.set = $v OR n.note = 'CREATE' RETURN n",
+		"MATCH (n:User:Merge)-[:REMOVE]->(m) RETURN n // DELETE",
+		"MATCH (n:User) RETURN n {.name, create: true}",
+	}
+	for _, query := range queries {
+		if _, err := repo.FindRaw(context.Background(), query, map[string]interface{}{"v": 1}); err != nil {
+			t.Errorf("FindRaw(%q): %v", query, err)
+		}
+	}
+	neopersisttest.AssertCallCount(t, runner, len(queries))
+}
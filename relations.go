@@ -0,0 +1,230 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FindByIDWith retrieves an entity by its primary key together with the entities related
+// to it through the given relationship fields, such as Posts on a User declared with
+// `crud:"rel:WROTE,direction:out"`, in a single query. Each relationship is loaded with an
+// OPTIONAL MATCH collecting the related nodes, so an entity without related nodes is still
+// found, with an empty relationship field.
+//
+// Slice fields (e.g., []*Post or []Post) receive every related entity; single-valued
+// fields (e.g., *User or User) receive one of them, and stay unset when there is none.
+// Related entities are mapped from their own `crud` tags, but their own relationship
+// fields are not loaded.
+//
+// Example:
+//
+//	user, err := userRepo.FindByIDWith(ctx, "user-1", []string{"Posts"})
+//	for _, post := range user.Posts {
+//	    fmt.Println(post.Title)
+//	}
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to find.
+//   - relations: The names of the relationship fields to load.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or ReadOnly.
+//
+// Returns:
+//
+//	A pointer to the found entity, ErrNotFound if no record is found, or another error if
+//	a name is not a declared relationship field or the query or mapping fails.
+func (r *Repository[T]) FindByIDWith(ctx context.Context, id interface{}, relations []string, opts ...FindOption) (*T, error) {
+	options, err := parseOptions("FindByIDWith", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return nil, err
+	}
+	rels := make([]*relationMetadata, len(relations))
+	targets := make([]*entityMetadata, len(relations))
+	for i, field := range relations {
+		rel, ok := r.meta.Relations[field]
+		if !ok {
+			return nil, fmt.Errorf("field '%s' is not a declared relationship on entity type %s", field, r.meta.Label)
+		}
+		if targets[i], err = r.relatedMetadata(rel.TargetType); err != nil {
+			return nil, fmt.Errorf("could not parse related entity type %s: %w", rel.TargetType.Name(), err)
+		}
+		rels[i] = rel
+	}
+
+	// Each relationship is collected before the next is matched, so that the rows of one
+	// OPTIONAL MATCH do not multiply those of another.
+	var b strings.Builder
+	fmt.Fprintf(&b, "MATCH (n:%s {%s: $id})", r.meta.Label, r.meta.PKProp)
+	carried := []string{"n"}
+	for i, rel := range rels {
+		column := fmt.Sprintf("r%d", i)
+		pattern := "(n)-[:%s]->(%s:%s)"
+		if rel.Direction == Incoming {
+			pattern = "(n)<-[:%s]-(%s:%s)"
+		}
+		fmt.Fprintf(&b, "\nOPTIONAL MATCH "+pattern, rel.Type, column, targets[i].Label)
+		fmt.Fprintf(&b, "\nWITH %s, collect(DISTINCT %s) AS %s", strings.Join(carried, ", "), column, column)
+		carried = append(carried, column)
+	}
+	fmt.Fprintf(&b, "\nRETURN %s", strings.Join(carried, ", "))
+
+	eagerResult, err := r.run(ctx, b.String(), map[string]interface{}{"id": key}, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return nil, ErrNotFound
+	}
+	if len(eagerResult.Records) > 1 {
+		return nil, &MultipleFoundError{Label: r.meta.Label, Count: len(eagerResult.Records)}
+	}
+
+	record := eagerResult.Records[0]
+	nodeValue, _ := record.Get("n")
+	node, ok := nodeValue.(Node)
+	if !ok {
+		return nil, fmt.Errorf("return value 'n' is not a node")
+	}
+	entity := new(T)
+	if err := mapNodeToStruct(node, entity, r.meta); err != nil {
+		return nil, err
+	}
+	val := reflect.ValueOf(entity).Elem()
+	for i, rel := range rels {
+		related, _ := record.Get(fmt.Sprintf("r%d", i))
+		nodes, _ := related.([]any)
+		if err := setRelationValue(val.FieldByName(rel.Field), nodes, rel.TargetType, targets[i]); err != nil {
+			return nil, fmt.Errorf("could not map relationship field %s of entity type %s: %w", rel.Field, r.meta.Label, err)
+		}
+	}
+	if err := r.afterLoad(ctx, entity, -1); err != nil {
+		return entity, err
+	}
+	return entity, nil
+}
+
+// setRelationValue maps the collected related nodes onto a relationship field: a slice
+// receives all of them, and a struct or pointer to a struct the first one, if any. The
+// nodes are mapped onto structs of type target, described by meta.
+func setRelationValue(field reflect.Value, nodes []any, target reflect.Type, meta *entityMetadata) error {
+	related := make([]reflect.Value, 0, len(nodes))
+	for _, value := range nodes {
+		node, ok := value.(Node)
+		if !ok {
+			return fmt.Errorf("collected value of type %T is not a node", value)
+		}
+		entity := reflect.New(target)
+		if err := mapNodeToStruct(node, entity.Interface(), meta); err != nil {
+			return err
+		}
+		related = append(related, entity)
+	}
+
+	typ := field.Type()
+	if typ.Kind() != reflect.Slice {
+		if len(related) > 0 {
+			field.Set(adaptRelated(related[0], typ))
+		}
+		return nil
+	}
+	slice := reflect.MakeSlice(typ, len(related), len(related))
+	for i, entity := range related {
+		slice.Index(i).Set(adaptRelated(entity, typ.Elem()))
+	}
+	field.Set(slice)
+	return nil
+}
+
+// adaptRelated returns entity, a pointer to a related struct, as a value of typ, which is
+// either the pointer type or the struct type itself.
+func adaptRelated(entity reflect.Value, typ reflect.Type) reflect.Value {
+	if typ.Kind() == reflect.Pointer {
+		return entity
+	}
+	return entity.Elem()
+}
+
+// DeleteRelationsWhere deletes the outgoing relationships of the given type from the
+// entities satisfying all the given conditions, and returns how many relationships were
+// deleted. The entities at both ends are left in place. Counters registered with
+// MaintainCounter are not updated; use Counter.RecountAll afterwards if relationships of a
+// counted type were removed.
+//
+// Without conditions every relationship of the type starting at the label is deleted, so
+// the call must then be confirmed with ConfirmDestructive, and fails with
+// ErrDestructiveForbidden on repositories whose manager disallows destructive operations.
+// Pass InBatchesOf to delete many relationships in several transactions instead of one.
+// Use EstimateDeleteRelationsWhere to preview its effect.
+//
+// Example:
+//
+//	removed, err := userRepo.DeleteRelationsWhere(ctx, "FOLLOWS",
+//	    []neopersist.Condition{neopersist.Field("Active").Eq(false)})
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - relType: The relationship type (e.g., "FOLLOWS").
+//   - conditions: The conditions the entities the relationships start from must satisfy;
+//     none selects every entity.
+//   - opts: Optional per-call settings, such as InBatchesOf or ConfirmDestructive.
+//
+// Returns:
+//
+//	The number of relationships deleted, or an error if the repository is read-only, an
+//	unconditional call was not allowed, the relationship type or a condition is invalid,
+//	or the query fails.
+func (r *Repository[T]) DeleteRelationsWhere(ctx context.Context, relType string, conditions []Condition, opts ...WriteOption) (int64, error) {
+	options, err := r.parseWriteOptions("DeleteRelationsWhere", opts, writeOptions|optConfirmDestructive|optDeleteBatch)
+	if err != nil {
+		return 0, err
+	}
+	if len(conditions) == 0 {
+		if err := r.checkDestructive("DeleteRelationsWhere", options); err != nil {
+			return 0, err
+		}
+	}
+	plan, err := r.deleteRelationsPlan(relType, conditions)
+	if err != nil {
+		return 0, err
+	}
+	return plan.execute(ctx, r.runnerWith(options), options.deleteBatchSize)
+}
+
+// EstimateDeleteRelationsWhere is the dry-run counterpart of DeleteRelationsWhere. It
+// reports how many relationships DeleteRelationsWhere would remove, broken down by type,
+// without modifying anything. The node counts of the estimate are always zero.
+func (r *Repository[T]) EstimateDeleteRelationsWhere(ctx context.Context, relType string, conditions []Condition, opts ...FindOption) (*DeleteEstimate, error) {
+	options, err := parseOptions("EstimateDeleteRelationsWhere", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := r.deleteRelationsPlan(relType, conditions)
+	if err != nil {
+		return nil, err
+	}
+	return plan.estimate(ctx, r.runnerWith(options))
+}
+
+// deleteRelationsPlan builds the deletePlan shared by DeleteRelationsWhere and
+// EstimateDeleteRelationsWhere, binding the relationships to delete to rel.
+func (r *Repository[T]) deleteRelationsPlan(relType string, conditions []Condition) (*deletePlan, error) {
+	if !identifierPattern.MatchString(relType) {
+		return nil, fmt.Errorf("invalid relationship type '%s'", relType)
+	}
+	params := &paramSet{values: make(map[string]interface{})}
+	match := fmt.Sprintf("MATCH (n:%s)-[rel:%s]->()", r.meta.Label, relType)
+	if len(conditions) > 0 {
+		where, err := And(conditions...).render(r.meta, params)
+		if err != nil {
+			return nil, fmt.Errorf("could not build conditions for %s: %w", r.meta.Label, err)
+		}
+		match += "\nWHERE " + where
+	}
+	return &deletePlan{match: match, alias: "rel", params: params.values, relationships: true}, nil
+}
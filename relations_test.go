@@ -0,0 +1,74 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Writer has posts, loaded with FindByIDWith.
+type Writer struct {
+	ID    string        `crud:"pk,property:id"`
+	Name  string        `crud:"property:name"`
+	Posts []*WriterPost `crud:"rel:WROTE,direction:out"`
+}
+
+type WriterPost struct {
+	ID          string    `crud:"pk,property:id"`
+	Title       string    `crud:"property:title"`
+	PublishedAt time.Time `crud:"property:publishedAt"`
+}
+
+func TestFindByIDWithMapsRelatedEntitiesWithTheRepositorySettings(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	published := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		author := neopersist.Node{Labels: []string{"Writer"}, Props: map[string]any{"id": "a1", "name": "Ada"}}
+		post := neopersist.Node{Labels: []string{"WriterPost"}, Props: map[string]any{"id": "p1", "TITLE": "Notes", "publishedAt": published}}
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord("n", author, "r0", []any{post})}}, nil
+	}
+	repo, err := neopersist.NewRepository[Writer](runner, neopersist.WithTimeLocation(paris), neopersist.LenientPropertyMatching())
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	for range 2 {
+		author, err := repo.FindByIDWith(context.Background(), "a1", []string{"Posts"})
+		if err != nil {
+			t.Fatalf("FindByIDWith: %v", err)
+		}
+		if len(author.Posts) != 1 {
+			t.Fatalf("Posts = %v, want one post", author.Posts)
+		}
+		post := author.Posts[0]
+		if post.Title != "Notes" {
+			t.Errorf("Title = %q, want the leniently matched TITLE property", post.Title)
+		}
+		if post.PublishedAt.Location() != paris || !post.PublishedAt.Equal(published) {
+			t.Errorf("PublishedAt = %v, want %v in Europe/Paris", post.PublishedAt, published)
+		}
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (n:Writer {id: $id}) OPTIONAL MATCH (n)-[:WROTE]->(r0:WriterPost) " +
+			"WITH n, collect(DISTINCT r0) AS r0 RETURN n, r0",
+		Times: 2,
+	})
+}
+
+func TestRelationshipTypesMustBeIdentifiers(t *testing.T) {
+	type Reader struct {
+		ID    string        `crud:"pk,property:id"`
+		Posts []*WriterPost `crud:"rel:READ]->() DETACH DELETE (x"`
+	}
+	if _, err := neopersist.NewRepository[Reader](neopersisttest.NewFakeRunner()); !errors.Is(err, neopersist.ErrInvalidTags) {
+		t.Fatalf("NewRepository: err = %v, want ErrInvalidTags", err)
+	}
+}
@@ -6,10 +6,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
 	"github.com/saulfrancisco-ruizacevedo/gocypher"
 )
 
@@ -17,11 +22,43 @@ import (
 // matching the criteria is found in the database.
 var ErrNotFound = errors.New("record not found")
 
+// ErrAlreadyExists is a sentinel error returned by Create when a node with the same
+// primary key already exists in the database.
+var ErrAlreadyExists = errors.New("record already exists")
+
+// ErrMultipleFound is matched, via errors.Is, by the *MultipleFoundError returned by
+// unique lookups such as FindByID and FindOne when more than one record matched.
+var ErrMultipleFound = errors.New("multiple records found")
+
+// MultipleFoundError reports that a unique lookup matched several records, which for a
+// primary key lookup points at a data integrity problem rather than a missing entity.
+type MultipleFoundError struct {
+	// Label is the label of the queried entities.
+	Label string
+	// Count is the number of records that matched.
+	Count int
+}
+
+// Error implements the error interface.
+func (e *MultipleFoundError) Error() string {
+	return fmt.Sprintf("%s: expected 1 record but found %d", e.Label, e.Count)
+}
+
+// Unwrap allows errors.Is(err, ErrMultipleFound) to match a *MultipleFoundError.
+func (e *MultipleFoundError) Unwrap() error {
+	return ErrMultipleFound
+}
+
 // Repository provides a generic abstraction for CRUD operations for a specific
 // entity type T. It relies on struct tags to map struct fields to node properties.
 type Repository[T any] struct {
 	runner DBRunner
 	meta   *entityMetadata
+	config repositoryConfig
+	hooks  entityHooks
+	// related caches the metadata of the entity types reached through relationship fields,
+	// with the repository's settings applied; see relatedMetadata.
+	related sync.Map
 }
 
 // NewRepository creates a new generic repository for the type T.
@@ -29,256 +66,1757 @@ type Repository[T any] struct {
 //
 // Parameters:
 //   - runner: An instance of DBRunner, used to execute all Cypher queries.
+//   - opts: Optional repository-wide settings, such as WithQueryRewriter.
 //
 // Returns:
 //
 //	A new Repository instance or an error if the struct tags are invalid.
-func NewRepository[T any](runner DBRunner) (*Repository[T], error) {
+func NewRepository[T any](runner DBRunner, opts ...RepositoryOption) (*Repository[T], error) {
 	meta, err := parseTags[T]()
 	if err != nil {
 		return nil, err
 	}
-	return &Repository[T]{
+	return newRepository[T](runner, meta, opts)
+}
+
+// newRepository creates a repository of T from its parsed metadata, which it takes
+// ownership of to apply the repository's settings.
+func newRepository[T any](runner DBRunner, meta *entityMetadata, opts []RepositoryOption) (*Repository[T], error) {
+	repo := &Repository[T]{
 		runner: runner,
 		meta:   meta,
-	}, nil
+		hooks:  hooksFor[T](),
+	}
+	for _, opt := range opts {
+		opt(&repo.config)
+	}
+	if want := reflect.TypeOf((*T)(nil)); repo.config.entityPool != nil && repo.config.entityPoolType != want {
+		return nil, fmt.Errorf("entity pool declared for %s cannot be used by a repository of %s", repo.config.entityPoolType, want)
+	}
+	if want := reflect.TypeOf((*T)(nil)); repo.config.validator != nil && repo.config.validatorType != want {
+		return nil, fmt.Errorf("validator declared for %s cannot be used by a repository of %s", repo.config.validatorType, want)
+	}
+	if repo.config.strictTags {
+		if err := checkStrictTags(reflect.TypeOf((*T)(nil)).Elem(), "", nil); err != nil {
+			return nil, err
+		}
+	}
+	// The metadata is parsed for this repository alone, so it can carry its time settings.
+	if err := repo.applySettings(meta); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// applySettings applies the repository's mapping settings, such as WithTimeLocation,
+// WithConverter and LenientPropertyMatching, to metadata parsed for the repository.
+func (r *Repository[T]) applySettings(meta *entityMetadata) error {
+	meta.TimeStorage = r.config.timeStorage
+	meta.TimeLocation = r.config.timeLocation
+	meta.LenientMatching = r.config.lenientMatching
+	meta.resolveConverters(r.config.converters)
+	return meta.resolveEnums(r.config.enumParsers)
+}
+
+// relatedMetadata returns the metadata of typ, an entity type reached through a
+// relationship field, with the repository's settings applied. It is parsed on first use,
+// since related types may refer back to this one, and cached afterwards.
+func (r *Repository[T]) relatedMetadata(typ reflect.Type) (*entityMetadata, error) {
+	if cached, ok := r.related.Load(typ); ok {
+		return cached.(*entityMetadata), nil
+	}
+	meta, err := parseTagsFromType(typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.applySettings(meta); err != nil {
+		return nil, err
+	}
+	actual, _ := r.related.LoadOrStore(typ, meta)
+	return actual.(*entityMetadata), nil
 }
 
 // Save creates a new node or updates an existing one.
 // It uses a MERGE query based on the struct's primary key (`pk` tag).
-// All other tagged fields are set on the node.
+// All other tagged fields are set on the node. If the entity has an `updatedAt` field,
+// it is set to the current time before saving. Properties are always written under their
+// primary name; legacy names declared with `aliases:`, or matched with
+// LenientPropertyMatching, are only read. Fields tagged
+// `omitempty` are skipped while they hold their zero value, leaving the stored property
+// unchanged; a time counts as zero when time.Time.IsZero says so. Time fields are written
+// as DATETIME or LOCAL DATETIME values, as set with WithTimeStorage. Fields tagged
+// `default:` (e.g., `crud:"property:status,default:active"`) that hold their zero value
+// are only written, with their default, when the node is created, in which case the
+// entity is updated to match. Use SaveReturning to also read back the stored node.
+//
+// A []string field tagged `crud:"labels"` receives the node's labels when loading and is
+// not written. Tagged `crud:"labels:add"`, Save also adds the labels it lists to the node;
+// tagged `crud:"labels:sync"`, Save additionally removes the labels it does not list,
+// except the primary one, which requires the dynamic labels of Neo4j 5.26 or later.
 //
 // Parameters:
 //   - ctx: The context for the query execution.
 //   - entity: A pointer to the struct instance to be saved.
+//   - opts: Optional per-call settings, such as RewriteQuery.
 //
 // Returns:
 //
 //	An error if the query building or execution fails.
-func (r *Repository[T]) Save(ctx context.Context, entity *T) error {
+func (r *Repository[T]) Save(ctx context.Context, entity *T, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("Save", opts, writeOptions)
+	if err != nil {
+		return err
+	}
+	if err := r.prepareSave(ctx, entity, -1); err != nil {
+		return err
+	}
+	query, params, err := r.saveQuery(ctx, entity)
+	if err != nil {
+		return err
+	}
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return err
+	}
+	if eagerResult.Counters.NodesCreated > 0 {
+		r.meta.applyDefaults(reflect.ValueOf(entity).Elem())
+	}
+	return nil
+}
+
+// SaveReturning saves the entity like Save, then maps the stored node back onto it, so that
+// state set on the server, such as properties written by other clients or by triggers, is
+// reflected in the struct. If the entity has a string field tagged `crud:"elementId"`, it
+// receives the node's element ID, for use by follow-up graph operations.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entity: A pointer to the struct instance to be saved and refreshed.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	An error if the query building, execution or mapping fails.
+func (r *Repository[T]) SaveReturning(ctx context.Context, entity *T, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("SaveReturning", opts, writeOptions)
+	if err != nil {
+		return err
+	}
+	if err := r.prepareSave(ctx, entity, -1); err != nil {
+		return err
+	}
+	query, params, err := r.saveQuery(ctx, entity)
+	if err != nil {
+		return err
+	}
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return err
+	}
+	if len(eagerResult.Records) == 0 {
+		return fmt.Errorf("MERGE of %s returned no node", r.meta.Label)
+	}
+	nodeValue, _ := eagerResult.Records[0].Get("n")
+	node, ok := nodeValue.(Node)
+	if !ok {
+		return fmt.Errorf("return value 'n' is not a node")
+	}
+	return mapNodeToStruct(node, entity, r.meta)
+}
+
+// saveQuery builds the MERGE statement shared by Save and SaveReturning.
+func (r *Repository[T]) saveQuery(ctx context.Context, entity *T) (string, map[string]interface{}, error) {
 	val := reflect.ValueOf(entity).Elem()
+	r.touchUpdatedAt(val)
 	pkValue := val.FieldByName(r.meta.PKField).Interface()
 	mergeProps := map[string]interface{}{r.meta.PKProp: pkValue}
+	props, err := r.savedProperties(val)
+	if err != nil {
+		return "", nil, err
+	}
+	defaults, err := r.meta.createDefaults(val)
+	if err != nil {
+		return "", nil, err
+	}
 
-	setProps := make(map[string]interface{})
-	for fieldName, propName := range r.meta.Mappings {
-		if fieldName != r.meta.PKField {
-			// The property is prefixed with 'n.' for the SET clause.
-			setProps["n."+propName] = val.FieldByName(fieldName).Interface()
+	query, params, err := gocypher.NewQueryBuilder().
+		Merge(gocypher.N("n", r.meta.Label).WithProperties(mergeProps)).
+		Set(setClauseProperties(props)).
+		Return("n").
+		Build()
+	if err != nil {
+		return "", nil, err
+	}
+	query = withCreateDefaults(query, params, defaults)
+	if r.meta.LabelsMode == "" {
+		return query, params, nil
+	}
+	if r.meta.LabelsMode == "sync" {
+		if err := requireFeature(ctx, r.runner, features.DynamicLabels); err != nil {
+			return "", nil, fmt.Errorf("cannot sync the labels of %s: %w", r.meta.Label, err)
 		}
 	}
+	return r.withLabelClauses(query, params, r.meta.field(val, r.meta.LabelsField).Interface().([]string))
+}
 
-	qb := gocypher.NewQueryBuilder().
-		Merge(gocypher.N("n", r.meta.Label).WithProperties(mergeProps)).
-		Set(setProps).
-		Return("n")
+// withLabelClauses inserts, before the final RETURN of a save query, the clauses writing
+// the entity's `labels` field: a SET adding the listed labels and, in "sync" mode, a
+// REMOVE of the others. The primary label is never removed.
+func (r *Repository[T]) withLabelClauses(query string, params map[string]interface{}, labels []string) (string, map[string]interface{}, error) {
+	var clauses []string
+	extra := make([]string, 0, len(labels))
+	for _, label := range labels {
+		// Labels cannot be parameters, so they are validated before being rendered.
+		if !identifierPattern.MatchString(label) {
+			return "", nil, fmt.Errorf("invalid label '%s' in field %s of entity type %s", label, r.meta.LabelsField, r.meta.Label)
+		}
+		if label != r.meta.Label {
+			extra = append(extra, label)
+		}
+	}
+	if len(extra) > 0 {
+		clauses = append(clauses, "SET n:"+strings.Join(extra, ":"))
+	}
+	if r.meta.LabelsMode == "sync" {
+		// Labels not known in advance can only be removed with a dynamic label expression.
+		clauses = append(clauses,
+			"WITH n, [l IN labels(n) WHERE l <> $primaryLabel AND NOT l IN $keepLabels] AS staleLabels",
+			"REMOVE n:$(staleLabels)")
+		params["primaryLabel"] = r.meta.Label
+		params["keepLabels"] = extra
+	}
+	if len(clauses) == 0 {
+		return query, params, nil
+	}
+	body, returns, _ := strings.Cut(query, "\nRETURN ")
+	return body + "\n" + strings.Join(clauses, "\n") + "\nRETURN " + returns, params, nil
+}
 
-	query, params, err := qb.Build()
+// SaveIfNewer saves the entity like Save, but only if its timestamp is newer than the one
+// stored on the node, so that events ingested out of order cannot overwrite fresher data
+// with stale data. A node that does not exist yet, or lacks the timestamp property, is
+// always written. The comparison and the write run in a single statement, which takes the
+// node's write lock before comparing, so that concurrent writers are serialized and a
+// stale one cannot slip in between another's comparison and write.
+//
+// If the timestamp field is the entity's `updatedAt` field, it is written as given rather
+// than set to the current time, since it carries the version being compared.
+//
+// Example:
+//
+//	applied, err := orderRepo.SaveIfNewer(ctx, order, "LastModified")
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entity: A pointer to the struct instance to be saved.
+//   - timestampField: The Go field name, or mapped property name, of a time.Time or
+//     numeric field holding the entity's version.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	true if the entity was written, false if the stored timestamp is the same or newer,
+//	or an error if the timestamp field is not a mapped time or numeric field or is a nil
+//	pointer, the primary key is the zero value, or the query fails.
+func (r *Repository[T]) SaveIfNewer(ctx context.Context, entity *T, timestampField string, opts ...WriteOption) (bool, error) {
+	options, err := r.parseWriteOptions("SaveIfNewer", opts, writeOptions)
 	if err != nil {
-		return err
+		return false, err
 	}
-	_, err = r.runner.Run(ctx, query, params)
-	return err
+	tsProp, err := r.resolveProperty(timestampField)
+	if err != nil {
+		return false, err
+	}
+	tsField, _ := r.meta.fieldForProperty(tsProp)
+	if !isTimestampType(r.meta.Types[tsField]) {
+		return false, fmt.Errorf("field %s of entity type %s is of type %s, not a time or numeric type",
+			tsField, r.meta.Label, r.meta.Types[tsField])
+	}
+	if err := r.prepareSave(ctx, entity, -1); err != nil {
+		return false, err
+	}
+	val := reflect.ValueOf(entity).Elem()
+	pkField := val.FieldByName(r.meta.PKField)
+	if pkField.IsZero() {
+		return false, fmt.Errorf("cannot save %s with a zero-value primary key (%s)", r.meta.Label, r.meta.PKField)
+	}
+	if tsField != r.meta.UpdatedAtField {
+		r.touchUpdatedAt(val)
+	}
+	props, err := r.savedProperties(val)
+	if err != nil {
+		return false, err
+	}
+	tsValue := r.meta.field(val, tsField)
+	if !tsValue.IsValid() || isNilValue(tsValue.Interface()) {
+		return false, fmt.Errorf("cannot save %s if newer: timestamp field %s is nil", r.meta.Label, tsField)
+	}
+	ts, err := r.meta.propertyValue(tsField, tsValue)
+	if err != nil {
+		return false, err
+	}
+
+	// MERGE does not lock a node it matches, so the no-op SET takes the write lock before
+	// the timestamp is read. The FOREACH over a one- or zero-element list is a conditional
+	// SET, which Cypher lacks; a freshly merged node has no timestamp yet, so it is always
+	// written.
+	query := fmt.Sprintf(
+		"MERGE (n:%s {%s: $pk})\n"+
+			"SET n.%s = n.%s\n"+
+			"WITH n, (n.%s IS NULL OR n.%s < $ts) AS applied\n"+
+			"FOREACH (_ IN CASE WHEN applied THEN [1] ELSE [] END | SET n += $props)\n"+
+			"RETURN applied",
+		r.meta.Label, r.meta.PKProp, r.meta.PKProp, r.meta.PKProp, tsProp, tsProp,
+	)
+	params := map[string]interface{}{
+		"pk":    pkField.Interface(),
+		"ts":    ts,
+		"props": props,
+	}
+	defaults, err := r.meta.createDefaults(val)
+	if err != nil {
+		return false, err
+	}
+	query = withCreateDefaults(query, params, defaults)
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return false, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return false, fmt.Errorf("MERGE of %s returned no row", r.meta.Label)
+	}
+	value, _ := eagerResult.Records[0].Get("applied")
+	applied, _ := value.(bool)
+	return applied, nil
+}
+
+// isTimestampType reports whether a field of type typ can hold a version timestamp for
+// SaveIfNewer: a time.Time or a number, possibly behind a pointer.
+func isTimestampType(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == timeType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
 }
 
-// FindByID retrieves a single entity from the database by its primary key.
+// Create inserts a new node for the entity and fails with ErrAlreadyExists if a node with
+// the same primary key already exists, instead of overwriting it like Save does. This lets
+// APIs report a conflict (e.g., HTTP 409) rather than silently replacing data.
+//
+// The existence check and the insert run in a single statement. Under concurrent callers
+// it is only race-free when a uniqueness constraint exists on the primary key: the
+// database then rejects the losing insert, which is also reported as ErrAlreadyExists.
+// Fields tagged `default:` that hold their zero value are written with their default, and
+// the entity is updated to match.
 //
 // Parameters:
 //   - ctx: The context for the query execution.
-//   - id: The primary key value of the entity to find.
+//   - entity: A pointer to the struct instance to be created.
+//   - opts: Optional per-call settings, such as RewriteQuery.
 //
 // Returns:
 //
-//	A pointer to the found entity, ErrNotFound if no record is found, or another
-//	error if the query or mapping fails.
-func (r *Repository[T]) FindByID(ctx context.Context, id interface{}) (*T, error) {
-	// 1. Build the query using gocypher.
-	props := map[string]interface{}{r.meta.PKProp: id}
-	query, params, err := gocypher.NewQueryBuilder().
-		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
-		Return("n").
-		Build()
+//	ErrAlreadyExists if the node exists, a validation error if the primary key is the
+//	zero value, or another error if the query fails.
+func (r *Repository[T]) Create(ctx context.Context, entity *T, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("Create", opts, writeOptions)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	// 2. Execute the query using the runner.
-	// The result is an EagerResult, which contains a slice of all records.
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	if err := r.prepareSave(ctx, entity, -1); err != nil {
+		return err
+	}
+	val := reflect.ValueOf(entity).Elem()
+	pkField := val.FieldByName(r.meta.PKField)
+	if pkField.IsZero() {
+		return fmt.Errorf("cannot create %s with a zero-value primary key (%s)", r.meta.Label, r.meta.PKField)
+	}
+	r.touchUpdatedAt(val)
+	props, err := r.createProperties(val)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// 3. Process the result records.
+	// The CREATE only runs for the row where no node with the key was found.
+	query := fmt.Sprintf(
+		"OPTIONAL MATCH (existing:%s {%s: $pk})\n"+
+			"WITH existing WHERE existing IS NULL\n"+
+			"CREATE (n:%s {%s: $pk})\n"+
+			"SET n += $props\n"+
+			"RETURN n",
+		r.meta.Label, r.meta.PKProp,
+		r.meta.Label, r.meta.PKProp,
+	)
+	params := map[string]interface{}{"pk": pkField.Interface(), "props": props}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		if isConstraintViolation(err) {
+			return fmt.Errorf("%s with %s %v: %w", r.meta.Label, r.meta.PKProp, pkField.Interface(), ErrAlreadyExists)
+		}
+		return err
+	}
 	if len(eagerResult.Records) == 0 {
-		return nil, ErrNotFound
+		return fmt.Errorf("%s with %s %v: %w", r.meta.Label, r.meta.PKProp, pkField.Interface(), ErrAlreadyExists)
 	}
-	if len(eagerResult.Records) > 1 {
-		// This indicates a data integrity issue, as a primary key lookup should be unique.
-		return nil, fmt.Errorf("expected 1 record but found %d", len(eagerResult.Records))
+	r.meta.applyDefaults(val)
+	return nil
+}
+
+// FindOrCreate looks up the node with the entity's primary key and creates it from the
+// entity if it does not exist, in a single MERGE statement, so that concurrent callers
+// cannot both create it. Unlike Save, an existing node is left untouched: the entity is
+// instead populated from it, so after the call it reflects the stored state either way.
+// Fields whose property is absent from the node keep the values they had.
+//
+// Under concurrent callers the MERGE is only race-free when a uniqueness constraint
+// exists on the primary key.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entity: A pointer to the struct instance to look up or create.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	true if the node was created, false if it already existed, or an error if the primary
+//	key is the zero value or the query or mapping fails.
+func (r *Repository[T]) FindOrCreate(ctx context.Context, entity *T, opts ...WriteOption) (bool, error) {
+	options, err := r.parseWriteOptions("FindOrCreate", opts, writeOptions)
+	if err != nil {
+		return false, err
+	}
+	if err := r.prepareSave(ctx, entity, -1); err != nil {
+		return false, err
 	}
+	val := reflect.ValueOf(entity).Elem()
+	pkField := val.FieldByName(r.meta.PKField)
+	if pkField.IsZero() {
+		return false, fmt.Errorf("cannot find or create %s with a zero-value primary key (%s)", r.meta.Label, r.meta.PKField)
+	}
+	r.touchUpdatedAt(val)
 
-	record := eagerResult.Records[0]
-	nodeValue, ok := record.Get("n")
-	if !ok {
-		return nil, fmt.Errorf("could not find return value 'n' in query result")
+	// Whether the MERGE created the node is read from the summary counters rather than
+	// from a marker property, so no extra write is needed.
+	query := fmt.Sprintf(
+		"MERGE (n:%s {%s: $pk})\n"+
+			"ON CREATE SET n += $props\n"+
+			"RETURN n",
+		r.meta.Label, r.meta.PKProp,
+	)
+	props, err := r.createProperties(val)
+	if err != nil {
+		return false, err
 	}
+	params := map[string]interface{}{"pk": pkField.Interface(), "props": props}
 
-	node, ok := nodeValue.(neo4j.Node)
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return false, err
+	}
+	if len(eagerResult.Records) == 0 {
+		return false, fmt.Errorf("MERGE of %s returned no node", r.meta.Label)
+	}
+	nodeValue, _ := eagerResult.Records[0].Get("n")
+	node, ok := nodeValue.(Node)
 	if !ok {
-		return nil, fmt.Errorf("return value 'n' is not a node")
+		return false, fmt.Errorf("return value 'n' is not a node")
 	}
-
-	// 4. Map the node properties to a new struct instance.
-	entity := new(T)
 	if err := mapNodeToStruct(node, entity, r.meta); err != nil {
-		return nil, err
+		return false, err
 	}
-
-	return entity, nil
+	return eagerResult.Counters.NodesCreated > 0, nil
 }
 
-// Delete removes a node from the database by its primary key.
-// It uses a DETACH DELETE query to also remove any relationships connected to the node.
+// Update overwrites the mapped properties of an existing node, failing with ErrNotFound
+// if no node with the entity's primary key exists. Unlike Save, it never creates a node,
+// so a mistyped ID cannot silently produce a new, half-empty node.
 //
 // Parameters:
 //   - ctx: The context for the query execution.
-//   - id: The primary key value of the entity to delete.
+//   - entity: A pointer to the struct instance holding the new state.
+//   - opts: Optional per-call settings, such as RewriteQuery.
 //
 // Returns:
 //
-//	An error if the query building or execution fails.
-func (r *Repository[T]) Delete(ctx context.Context, id interface{}) error {
-	props := map[string]interface{}{r.meta.PKProp: id}
-	query, params, err := gocypher.NewQueryBuilder().
-		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
-		DetachDelete("n").
-		Build()
+//	ErrNotFound if no node matched, a validation error if the primary key is the zero
+//	value, or another error if the query fails.
+func (r *Repository[T]) Update(ctx context.Context, entity *T, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("Update", opts, writeOptions)
 	if err != nil {
 		return err
 	}
-	_, err = r.runner.Run(ctx, query, params)
-	return err
-}
-
-// mapNodeToStruct is an internal helper function that populates a struct's fields
-// from a neo4j.Node's properties, based on the parsed metadata.
-func mapNodeToStruct(node neo4j.Node, entity any, meta *entityMetadata) error {
+	if err := r.prepareSave(ctx, entity, -1); err != nil {
+		return err
+	}
 	val := reflect.ValueOf(entity).Elem()
+	pkField := val.FieldByName(r.meta.PKField)
+	if pkField.IsZero() {
+		return fmt.Errorf("cannot update %s with a zero-value primary key (%s)", r.meta.Label, r.meta.PKField)
+	}
+	r.touchUpdatedAt(val)
+	props, err := r.entityProperties(val)
+	if err != nil {
+		return err
+	}
 
-	for fieldName, propName := range meta.Mappings {
-		field := val.FieldByName(fieldName)
-		if !field.IsValid() || !field.CanSet() {
-			continue // Skip if the struct field cannot be set.
-		}
-
-		propValue, ok := node.Props[propName]
-		if !ok {
-			continue // Skip if the property does not exist on the node.
-		}
+	matchProps := map[string]interface{}{r.meta.PKProp: pkField.Interface()}
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label).WithProperties(matchProps)).
+		Set(setClauseProperties(props)).
+		Return("n").
+		Build()
+	if err != nil {
+		return err
+	}
 
-		// Set the struct field's value.
-		field.Set(reflect.ValueOf(propValue))
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return err
+	}
+	if len(eagerResult.Records) == 0 {
+		return ErrNotFound
 	}
 	return nil
 }
 
-// FindAll retrieves all entities of type T from the database.
-// It performs a `MATCH (n:Label) RETURN n` query. Use with caution on large datasets,
-// as this can consume significant memory.
+// PatchProperties updates selected properties of an existing node without loading it,
+// leaving every other property untouched. This avoids overwriting concurrent changes to
+// unrelated fields, as a load-modify-Save cycle would. A nil value removes the property.
+// If the entity has an `updatedAt` field that is not being patched, it is set to the
+// current time so that ChangesSince picks up the change.
+//
+// Example:
+//
+//	err := userRepo.PatchProperties(ctx, "u1", map[string]interface{}{"active": false})
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to patch.
+//   - props: The new values, keyed by mapped property name (not struct field name).
+//   - opts: Optional per-call settings, such as RewriteQuery.
 //
 // Returns:
 //
-//	A slice of pointers to the found entities. Returns an empty slice if no entities are found.
-func (r *Repository[T]) FindAll(ctx context.Context) ([]*T, error) {
+//	ErrNotFound if no node matched, a validation error if a key is not a mapped property
+//	or is the primary key, or another error if the query fails.
+func (r *Repository[T]) PatchProperties(ctx context.Context, id interface{}, props map[string]interface{}, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("PatchProperties", opts, writeOptions)
+	if err != nil {
+		return err
+	}
+	if len(props) == 0 {
+		return fmt.Errorf("no properties to patch for entity type %s", r.meta.Label)
+	}
+	setProps := make(map[string]interface{}, len(props)+1)
+	for propName, value := range props {
+		if propName == r.meta.PKProp {
+			return fmt.Errorf("cannot patch primary key property '%s' of entity type %s", propName, r.meta.Label)
+		}
+		if err := r.requireMappedProperty(propName); err != nil {
+			return err
+		}
+		if value != nil {
+			fieldName, _ := r.meta.fieldForProperty(propName)
+			if value, err = r.meta.propertyValue(fieldName, reflect.ValueOf(value)); err != nil {
+				return err
+			}
+		}
+		setProps["n."+propName] = value
+	}
+	if _, patched := props[r.meta.UpdatedAtProp]; r.meta.UpdatedAtProp != "" && !patched {
+		setProps["n."+r.meta.UpdatedAtProp] = r.meta.timeParam(time.Now().UTC())
+	}
+
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return err
+	}
+	matchProps := map[string]interface{}{r.meta.PKProp: key}
 	query, params, err := gocypher.NewQueryBuilder().
-		Match(gocypher.N("n", r.meta.Label)).
+		Match(gocypher.N("n", r.meta.Label).WithProperties(matchProps)).
+		Set(setProps).
 		Return("n").
 		Build()
+	if err != nil {
+		return err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return err
+	}
+	if len(eagerResult.Records) == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// entityProperties returns the database properties of an entity, keyed by property name,
+// excluding the primary key. It fails if a PropertyConverter fails.
+func (r *Repository[T]) entityProperties(val reflect.Value) (map[string]interface{}, error) {
+	props := make(map[string]interface{}, len(r.meta.Mappings))
+	for fieldName, propName := range r.meta.Mappings {
+		if fieldName == r.meta.PKField {
+			continue
+		}
+		// A nested field behind a nil pointer is written as null, removing the property.
+		field := r.meta.field(val, fieldName)
+		if !field.IsValid() {
+			props[propName] = nil
+			continue
+		}
+		value, err := r.meta.propertyValue(fieldName, field)
+		if err != nil {
+			return nil, err
+		}
+		props[propName] = value
+	}
+	return props, nil
+}
+
+// savedProperties returns the properties written by Save and SaveAll: the entity's
+// properties without the `omitempty` fields that hold their zero value. Pointer fields are
+// only zero when nil, so they can express "leave unchanged" for values such as false or 0.
+// Fields with a `default:` are left out too while zero, since their default is only
+// written on creation (see createDefaults).
+func (r *Repository[T]) savedProperties(val reflect.Value) (map[string]interface{}, error) {
+	props, err := r.entityProperties(val)
+	if err != nil {
+		return nil, err
+	}
+	for fieldName := range r.meta.OmitEmpty {
+		if field := r.meta.field(val, fieldName); !field.IsValid() || isZeroField(field) {
+			delete(props, r.meta.Mappings[fieldName])
+		}
+	}
+	for fieldName := range r.meta.Defaults {
+		if isZeroField(r.meta.field(val, fieldName)) {
+			delete(props, r.meta.Mappings[fieldName])
+		}
+	}
+	return props, nil
+}
+
+// createProperties returns the properties of a node about to be created: the entity's
+// properties, with the defaults of the `default:` fields that hold their zero value.
+func (r *Repository[T]) createProperties(val reflect.Value) (map[string]interface{}, error) {
+	props, err := r.entityProperties(val)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := r.meta.createDefaults(val)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(props, defaults)
+	return props, nil
+}
+
+// setClauseProperties prefixes the given properties with 'n.', as expected by gocypher's
+// Set clause.
+func setClauseProperties(props map[string]interface{}) map[string]interface{} {
+	setProps := make(map[string]interface{}, len(props))
+	for propName, value := range props {
+		setProps["n."+propName] = value
+	}
+	return setProps
+}
+
+// isConstraintViolation reports whether err was caused by a schema constraint rejecting a write.
+func isConstraintViolation(err error) bool {
+	return dbErrorCode(err) == "Neo.ClientError.Schema.ConstraintValidationFailed"
+}
+
+// FindByID retrieves a single entity from the database by its primary key. The ID is
+// converted to the type of the primary key field first, so an int64 key can be looked up
+// with an int or a decimal string; IDs that cannot be converted are rejected.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to find.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	A pointer to the found entity, ErrNotFound if no record is found, a
+//	*MultipleFoundError if several nodes share the key, or another error if the query or
+//	mapping fails.
+func (r *Repository[T]) FindByID(ctx context.Context, id interface{}, opts ...FindOption) (*T, error) {
+	options, err := parseOptions("FindByID", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	node, err := r.findNodeByID(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the node properties to a new struct instance.
+	entity := new(T)
+	if err := mapNodeToStruct(node, entity, r.meta); err != nil {
+		return nil, err
+	}
+	if err := r.afterLoad(ctx, entity, -1); err != nil {
+		return entity, err
+	}
+
+	return entity, nil
+}
+
+// findNodeByID loads the node with the given primary key, or fails with ErrNotFound.
+func (r *Repository[T]) findNodeByID(ctx context.Context, id interface{}, options *queryOptions) (Node, error) {
+	// 1. Build the query using gocypher, with the ID converted to the key's type.
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return Node{}, err
+	}
+	props := map[string]interface{}{r.meta.PKProp: key}
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
+		Return("n").
+		Build()
+	if err != nil {
+		return Node{}, err
+	}
+
+	// 2. Execute the query using the runner.
+	// The result is a ResultSet, which contains a slice of all records.
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return Node{}, err
+	}
+
+	// 3. Process the result records.
+	if len(eagerResult.Records) == 0 {
+		return Node{}, ErrNotFound
+	}
+	if len(eagerResult.Records) > 1 {
+		// This indicates a data integrity issue, as a primary key lookup should be unique.
+		return Node{}, &MultipleFoundError{Label: r.meta.Label, Count: len(eagerResult.Records)}
+	}
+
+	record := eagerResult.Records[0]
+	nodeValue, ok := record.Get("n")
+	if !ok {
+		return Node{}, fmt.Errorf("could not find return value 'n' in query result")
+	}
+
+	node, ok := nodeValue.(Node)
+	if !ok {
+		return Node{}, fmt.Errorf("return value 'n' is not a node")
+	}
+	return node, nil
+}
+
+// Refresh reloads the entity's node by its primary key and maps it onto the same struct,
+// so that every holder of the pointer sees changes made by other processes. Unlike the
+// finders, which leave fields at their zero value when a property is missing, Refresh
+// clears mapped fields whose property no longer exists on the node. Fields that are not
+// mapped to properties are left untouched.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entity: A pointer to the struct instance to refresh; its primary key must be set.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	ErrNotFound if the node has been deleted, or another error if the query or mapping fails.
+func (r *Repository[T]) Refresh(ctx context.Context, entity *T, opts ...FindOption) error {
+	options, err := parseOptions("Refresh", opts, lookupOptions)
+	if err != nil {
+		return err
+	}
+	if entity == nil {
+		return fmt.Errorf("cannot refresh a nil %s", r.meta.Label)
+	}
+	val := reflect.ValueOf(entity).Elem()
+	node, err := r.findNodeByID(ctx, val.FieldByName(r.meta.PKField).Interface(), options)
+	if err != nil {
+		return err
+	}
+
+	// Map onto a fresh instance first, so that a mapping failure leaves the entity intact
+	// and missing properties come out as zero values.
+	fresh := new(T)
+	if err := mapNodeToStruct(node, fresh, r.meta); err != nil {
+		return err
+	}
+	freshVal := reflect.ValueOf(fresh).Elem()
+	for fieldName := range r.meta.Mappings {
+		// Nested fields are copied with the whole struct field containing them.
+		fieldName, _, _ = strings.Cut(fieldName, ".")
+		r.meta.field(val, fieldName).Set(r.meta.field(freshVal, fieldName))
+	}
+	for _, fieldName := range []string{r.meta.ElementIDField, r.meta.LabelsField} {
+		if fieldName != "" {
+			r.meta.field(val, fieldName).Set(r.meta.field(freshVal, fieldName))
+		}
+	}
+	return r.afterLoad(ctx, entity, -1)
+}
+
+// ExistsByID reports whether a node with the given primary key exists, without fetching
+// and mapping the node like FindByID does.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value to look for.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	true if the node exists, false if it does not, or an error if the query fails.
+func (r *Repository[T]) ExistsByID(ctx context.Context, id interface{}, opts ...FindOption) (bool, error) {
+	options, err := parseOptions("ExistsByID", opts, lookupOptions)
+	if err != nil {
+		return false, err
+	}
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return false, err
+	}
+	props := map[string]interface{}{r.meta.PKProp: key}
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
+		Return("count(n) > 0 AS exists").
+		Build()
+	if err != nil {
+		return false, err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return false, err
+	}
+	return existsFromResult(eagerResult), nil
+}
+
+// existsFromResult reads the boolean `exists` column of an existence query, treating a
+// result without records as false.
+func existsFromResult(result *ResultSet) bool {
+	if len(result.Records) == 0 {
+		return false
+	}
+	value, _ := result.Records[0].Get("exists")
+	exists, _ := value.(bool)
+	return exists
+}
+
+// Delete removes a node from the database by its primary key.
+// It uses a DETACH DELETE query to also remove any relationships connected to the node.
+// Deleting a key that does not exist is not an error; use DeleteReported to find out
+// whether a node was removed.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to delete.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	An error if the query building or execution fails.
+func (r *Repository[T]) Delete(ctx context.Context, id interface{}, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("Delete", opts, writeOptions)
+	if err != nil {
+		return err
+	}
+	plan, err := r.deleteByIDPlan(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.run(ctx, plan.deleteQuery(), plan.params, options)
+	return err
+}
+
+// DeleteEntity removes the node of the given entity, reading its primary key from the
+// struct, so that call sites holding an entity need not extract the key themselves. It
+// behaves like Delete otherwise.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entity: A pointer to the struct instance to delete; its primary key must be set.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	An error if the entity is nil, its primary key is the zero value, or the query
+//	building or execution fails.
+func (r *Repository[T]) DeleteEntity(ctx context.Context, entity *T, opts ...WriteOption) error {
+	if entity == nil {
+		return fmt.Errorf("cannot delete a nil %s", r.meta.Label)
+	}
+	pkField := reflect.ValueOf(entity).Elem().FieldByName(r.meta.PKField)
+	if pkField.IsZero() {
+		return fmt.Errorf("cannot delete %s with a zero-value primary key (%s)", r.meta.Label, r.meta.PKField)
+	}
+	return r.Delete(ctx, pkField.Interface(), opts...)
+}
+
+// DeleteReported is like Delete, but reports whether a node was actually removed, as
+// read from the result summary counters. This lets callers distinguish "deleted" from
+// "was never there", for example to answer a REST DELETE with 204 or 404.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity to delete.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	true if a node was deleted, false if none had the key, or an error if the query
+//	building or execution fails.
+func (r *Repository[T]) DeleteReported(ctx context.Context, id interface{}, opts ...WriteOption) (bool, error) {
+	options, err := r.parseWriteOptions("DeleteReported", opts, writeOptions)
+	if err != nil {
+		return false, err
+	}
+	plan, err := r.deleteByIDPlan(id)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := plan.execute(ctx, r.runnerWith(options), 0)
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+// DeleteByIDs removes the nodes with the given primary keys, and their relationships, in
+// as few round trips as possible. Long ID lists are split into chunks of the repository's
+// batch size (1000 by default, configurable with WithBatchSize), each deleted in its own
+// statement; if one fails, the chunks before it remain deleted. Keys that do not exist
+// are ignored, and an empty list is a no-op.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - ids: The primary key values of the entities to delete.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	The number of nodes deleted, as reported by the result summary counters, or an error
+//	if a chunk fails, together with the number deleted before it.
+func (r *Repository[T]) DeleteByIDs(ctx context.Context, ids []interface{}, opts ...WriteOption) (int64, error) {
+	options, err := r.parseWriteOptions("DeleteByIDs", opts, writeOptions)
+	if err != nil {
+		return 0, err
+	}
+	ids, err = r.meta.pkParams(ids)
+	if err != nil {
+		return 0, err
+	}
+	runner := r.runnerWith(options)
+	match := fmt.Sprintf("MATCH (n:%s)\nWHERE n.%s IN $ids", r.meta.Label, r.meta.PKProp)
+	batchSize := r.config.batchSizeOrDefault()
+
+	var total int64
+	for start := 0; start < len(ids); start += batchSize {
+		end := min(start+batchSize, len(ids))
+		plan := &deletePlan{match: match, alias: "n", params: map[string]interface{}{"ids": ids[start:end]}}
+		deleted, err := plan.execute(ctx, runner, 0)
+		total += deleted
+		if err != nil {
+			return total, fmt.Errorf("could not delete chunk %d (ids %d to %d): %w", start/batchSize, start, end-1, err)
+		}
+	}
+	return total, nil
+}
+
+// EstimateDelete is the dry-run counterpart of Delete. It reports how many nodes and
+// relationships Delete would remove for the given primary key, without modifying anything.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - id: The primary key value of the entity that would be deleted.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or ReadOnly.
+//
+// Returns:
+//
+//	A DeleteEstimate broken down by label and relationship type, or an error if the
+//	query building or execution fails.
+func (r *Repository[T]) EstimateDelete(ctx context.Context, id interface{}, opts ...FindOption) (*DeleteEstimate, error) {
+	options, err := parseOptions("EstimateDelete", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := r.deleteByIDPlan(id)
+	if err != nil {
+		return nil, err
+	}
+	return plan.estimate(ctx, r.runnerWith(options))
+}
+
+// DeleteAll removes every node with the repository's label, together with its
+// relationships, and returns how many nodes were deleted. It is intended for integration
+// tests and admin tooling.
+//
+// Because it is unbounded, DeleteAll must be confirmed with ConfirmDestructive and fails
+// with ErrDestructiveForbidden on repositories whose manager disallows destructive
+// operations. Pass InBatchesOf to delete large labels in several transactions instead of
+// one. Use EstimateDeleteAll to preview its effect.
+//
+// Example:
+//
+//	deleted, err := userRepo.DeleteAll(ctx, neopersist.ConfirmDestructive(), neopersist.InBatchesOf(10000))
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - opts: Per-call settings; ConfirmDestructive is required.
+//
+// Returns:
+//
+//	The number of nodes deleted, or an error if the operation was not allowed or a query
+//	fails. When a batch fails, the count reflects the batches that were committed.
+func (r *Repository[T]) DeleteAll(ctx context.Context, opts ...WriteOption) (int64, error) {
+	options, err := r.parseWriteOptions("DeleteAll", opts, writeOptions|optConfirmDestructive|optDeleteBatch)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.checkDestructive("DeleteAll", options); err != nil {
+		return 0, err
+	}
+	plan, err := r.deleteAllPlan()
+	if err != nil {
+		return 0, err
+	}
+	return plan.execute(ctx, r.runnerWith(options), options.deleteBatchSize)
+}
+
+// EstimateDeleteAll is the dry-run counterpart of DeleteAll. It reports how many nodes and
+// relationships DeleteAll would remove, without modifying anything or requiring confirmation.
+func (r *Repository[T]) EstimateDeleteAll(ctx context.Context, opts ...FindOption) (*DeleteEstimate, error) {
+	options, err := parseOptions("EstimateDeleteAll", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := r.deleteAllPlan()
+	if err != nil {
+		return nil, err
+	}
+	return plan.estimate(ctx, r.runnerWith(options))
+}
+
+// deleteAllPlan builds the deletePlan shared by DeleteAll and EstimateDeleteAll.
+func (r *Repository[T]) deleteAllPlan() (*deletePlan, error) {
+	match, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return &deletePlan{match: match, alias: "n", params: params}, nil
+}
+
+// DeleteWhere deletes the entities matched by a caller-supplied query and returns how many
+// nodes were deleted, as reported by the result summary. It enables conditional cleanup
+// that Delete and DeleteAll cannot express.
+//
+// The builder supplies the MATCH clauses and must bind the repository's entity to the
+// alias "n" with its label, e.g. gocypher.N("n", "User"). The repository appends
+// `DETACH DELETE n`, so only the nodes bound to n are deleted, together with their
+// relationships; other matched nodes are left untouched. Alternatively, the builder may
+// carry its own DELETE or DETACH DELETE clause, in which case the query is run unchanged
+// and only the deleted-node count is reported; InBatchesOf is then only supported when
+// that clause is exactly `DETACH DELETE n`. Builders must not contain a RETURN clause.
+//
+// gocypher's Where does not render anything, so the nodes bound to n are only filtered
+// by the properties given to their pattern or by a WHERE clause. A query without either
+// deletes every node of the label: like DeleteAll, it must then be confirmed with
+// ConfirmDestructive and is refused on managers created with AllowDestructive(false).
+//
+// Example:
+//
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("n", "User").WithProperties(map[string]interface{}{"active": false}))
+//	deleted, err := userRepo.DeleteWhere(ctx, qb)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - qb: A configured gocypher.QueryBuilder binding the nodes to delete to "n".
+//   - opts: Optional per-call settings, such as UseIndex, InBatchesOf or ConfirmDestructive.
+//
+// Returns:
+//
+//	The number of nodes deleted, or an error if the builder is invalid, an unfiltered
+//	delete was not allowed, or the query fails.
+func (r *Repository[T]) DeleteWhere(ctx context.Context, qb *gocypher.QueryBuilder, opts ...WriteOption) (int64, error) {
+	options, err := r.parseWriteOptions("DeleteWhere", opts, writeOptions|optIndexHint|optDeleteBatch|optConfirmDestructive)
+	if err != nil {
+		return 0, err
+	}
+	query, params, err := buildQuery("DeleteWhere", r.meta.Label, qb)
+	if err != nil {
+		return 0, err
+	}
+	match, deletes := splitDeleteClauses(query)
+	if len(deletes) > 0 && !(len(deletes) == 1 && deletes[0] == "DETACH DELETE n") {
+		if hasClause(query, "RETURN ") {
+			return 0, fmt.Errorf("DeleteWhere on %s: builder must not contain a RETURN clause", r.meta.Label)
+		}
+		if options.deleteBatchSize > 0 {
+			return 0, fmt.Errorf("DeleteWhere on %s: InBatchesOf requires the builder's delete clause to be 'DETACH DELETE n'", r.meta.Label)
+		}
+		if err := r.checkUnfilteredDelete(match, options); err != nil {
+			return 0, err
+		}
+		result, err := r.run(ctx, query, params, options)
+		if err != nil {
+			return 0, err
+		}
+		return nodesDeleted(result), nil
+	}
+
+	plan, err := r.deleteWherePlan(match, params)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.checkUnfilteredDelete(match, options); err != nil {
+		return 0, err
+	}
+	return plan.execute(ctx, r.runnerWith(options), options.deleteBatchSize)
+}
+
+// EstimateDeleteWhere is the dry-run counterpart of DeleteWhere for builders that leave
+// the delete clause to the repository. It reports how many nodes and relationships
+// DeleteWhere would remove, without modifying anything.
+func (r *Repository[T]) EstimateDeleteWhere(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (*DeleteEstimate, error) {
+	options, err := parseOptions("EstimateDeleteWhere", opts, statementOptions|optIndexHint)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("EstimateDeleteWhere", r.meta.Label, qb)
+	if err != nil {
+		return nil, err
+	}
+	if hasClause(query, "DELETE ", "DETACH DELETE ") {
+		return nil, fmt.Errorf("EstimateDeleteWhere on %s: builder must not contain a delete clause", r.meta.Label)
+	}
+	plan, err := r.deleteWherePlan(query, params)
+	if err != nil {
+		return nil, err
+	}
+	return plan.estimate(ctx, r.runnerWith(options))
+}
+
+// deleteWherePlan validates a caller-supplied MATCH query and wraps it in a deletePlan.
+func (r *Repository[T]) deleteWherePlan(match string, params map[string]interface{}) (*deletePlan, error) {
+	if hasClause(match, "RETURN ", "SET ", "CREATE ", "MERGE ") {
+		return nil, fmt.Errorf("delete query on %s may only contain MATCH clauses", r.meta.Label)
+	}
+	if !r.bindsEntity(match) {
+		return nil, fmt.Errorf("delete query must bind the entity to alias 'n' with label %s, e.g. (n:%s)", r.meta.Label, r.meta.Label)
+	}
+	return &deletePlan{match: match, alias: "n", params: params}, nil
+}
+
+// checkUnfilteredDelete guards DeleteWhere queries that filter the nodes bound to n
+// neither by pattern properties nor by a WHERE clause, and thus delete the whole label.
+func (r *Repository[T]) checkUnfilteredDelete(match string, options *queryOptions) error {
+	if hasClause(match, "WHERE ") {
+		return nil
+	}
+	for _, pattern := range entityPatternRegex.FindAllStringSubmatch(match, -1) {
+		if pattern[2] == "{" {
+			return nil
+		}
+	}
+	return r.checkDestructive("DeleteWhere", options)
+}
+
+// entityPatternRegex matches the node patterns binding the alias n, capturing their labels
+// (e.g. ":User:Admin") and whether a property map follows them.
+var entityPatternRegex = regexp.MustCompile(`\(n((?::[A-Za-z_][A-Za-z0-9_]*)+)\s*([{)])`)
+
+// bindsEntity reports whether a query binds the alias n to a pattern carrying exactly the
+// repository's label among its labels, so that "(n:UserArchive)" does not pass for User.
+func (r *Repository[T]) bindsEntity(match string) bool {
+	for _, pattern := range entityPatternRegex.FindAllStringSubmatch(match, -1) {
+		for _, label := range strings.Split(pattern[1][1:], ":") {
+			if label == r.meta.Label {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitDeleteClauses separates the delete clauses of a built query from the rest of it.
+func splitDeleteClauses(query string) (string, []string) {
+	var rest, deletes []string
+	for _, line := range strings.Split(query, "\n") {
+		if strings.HasPrefix(line, "DELETE ") || strings.HasPrefix(line, "DETACH DELETE ") {
+			deletes = append(deletes, strings.TrimSpace(line))
+			continue
+		}
+		rest = append(rest, line)
+	}
+	return strings.Join(rest, "\n"), deletes
+}
+
+// hasClause reports whether any line of a built query starts with one of the prefixes.
+func hasClause(query string, prefixes ...string) bool {
+	for _, line := range strings.Split(query, "\n") {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(line, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deleteByIDPlan builds the deletePlan shared by Delete and EstimateDelete.
+func (r *Repository[T]) deleteByIDPlan(id interface{}) (*deletePlan, error) {
+	key, err := r.meta.pkParam(id)
+	if err != nil {
+		return nil, err
+	}
+	props := map[string]interface{}{r.meta.PKProp: key}
+	match, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return &deletePlan{match: match, alias: "n", params: params}, nil
+}
+
+// FindAll retrieves all entities of type T from the database.
+// It performs a `MATCH (n:Label) RETURN n` query. Use with caution on large datasets,
+// as this can consume significant memory.
+//
+// When the runner is a Neo4jExecutor configured with WithMaxBufferedRecords, labels with
+// more nodes than the limit make FindAll fail with a *ResultLimitError instead of
+// exhausting memory. Callers that knowingly load large labels can raise the limit for a
+// single call by passing a context created with WithRecordLimit, or stream the label with
+// FindAllIter instead.
+//
+// Pass CollectMappingErrors to skip nodes that cannot be mapped instead of failing.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities. Returns an empty slice if no entities are found.
+func (r *Repository[T]) FindAll(ctx context.Context, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("FindAll", opts)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label)).
+		Return("n").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		// An empty result set is not considered an error for FindAll.
+		if errors.Is(err, ErrNotFound) {
+			return []*T{}, nil
+		}
+		return nil, err
+	}
+
+	// Map all resulting records to a slice of entity structs.
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// FindPage retrieves one page of entities of type T, as a bounded alternative to FindAll.
+// Entities are ordered by orderBy, with ties broken by primary key so that pages are
+// stable, and then offset entities are skipped before at most limit are returned.
+//
+// orderBy accepts either a Go field name (e.g., "Name") or the mapped property name
+// (e.g., "name") and is validated against the entity's mappings, so it cannot inject
+// Cypher. An empty orderBy orders by primary key. SKIP still scans the skipped nodes, so
+// deep pages on large labels get slower; FindAfter avoids that.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - limit: The maximum number of entities to return; must be positive.
+//   - offset: The number of entities to skip; must not be negative.
+//   - orderBy: The field or property to order by, or empty for the primary key.
+//   - desc: Whether to order in descending order.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	The entities of the page, or an empty slice past the last page.
+func (r *Repository[T]) FindPage(ctx context.Context, limit, offset int64, orderBy string, desc bool, opts ...FindOption) ([]*T, error) {
+	options, err := parseOptions("FindPage", opts, pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := r.pageQuery("n", limit, offset, []Sort{{Field: orderBy, Desc: desc}})
+	if err != nil {
+		return nil, err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// FindPageWithCount is like FindPage but also returns the total number of entities of the
+// label, which list endpoints need to render pagination. Both come from a single statement,
+// so they are consistent with each other; only when the page is empty is the total counted
+// separately, as the statement then returns no rows to carry it.
+func (r *Repository[T]) FindPageWithCount(ctx context.Context, limit, offset int64, orderBy string, desc bool, opts ...FindOption) ([]*T, int64, error) {
+	options, err := parseOptions("FindPageWithCount", opts, pageOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.findPageWithCount(ctx, limit, offset, []Sort{{Field: orderBy, Desc: desc}}, options)
+}
+
+// FindPaged is the PageRequest-based form of FindPageWithCount: it returns the requested
+// page, ordered by any number of Sort fields, as a Page ready to be serialized.
+//
+// Example:
+//
+//	page, err := userRepo.FindPaged(ctx, neopersist.PageRequest{
+//	    Limit: 20,
+//	    Sort:  []neopersist.Sort{neopersist.Desc("CreatedAt"), neopersist.Asc("Name")},
+//	})
+func (r *Repository[T]) FindPaged(ctx context.Context, req PageRequest, opts ...FindOption) (*Page[T], error) {
+	options, err := parseOptions("FindPaged", opts, pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities, total, err := r.findPageWithCount(ctx, req.Limit, req.Offset, req.Sort, options)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(entities, total, req.Limit, req.Offset), nil
+}
+
+// findPageWithCount implements FindPageWithCount and FindPaged.
+func (r *Repository[T]) findPageWithCount(ctx context.Context, limit, offset int64, sorts []Sort, options *queryOptions) ([]*T, int64, error) {
+	page, params, err := r.pageQuery("n, total", limit, offset, sorts)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := fmt.Sprintf("CALL { MATCH (c:%s) RETURN count(c) AS total }\n%s", r.meta.Label, page)
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(eagerResult.Records) == 0 {
+		// The total is counted with the same per-call settings, so that it comes from the
+		// database the page was read from.
+		countResult, err := r.run(ctx, fmt.Sprintf("MATCH (c:%s)\nRETURN count(c) AS total", r.meta.Label), nil, options)
+		if err != nil {
+			return nil, 0, err
+		}
+		var total int64
+		if len(countResult.Records) > 0 {
+			value, _ := countResult.Records[0].Get("total")
+			total, _ = value.(int64)
+		}
+		return []*T{}, total, nil
+	}
+
+	total, _ := eagerResult.Records[0].Get("total")
+	entities, err := r.mapRecords(ctx, eagerResult.Records, options)
+	count, _ := total.(int64)
+	return entities, count, err
+}
+
+// FindPageWithTotal returns one page of the entities matched by a custom query, together
+// with the total number of matches, as a Page ready to be serialized in an API response.
+// The builder supplies the MATCH logic and a RETURN clause whose first expression is the
+// entity alias; the page is ordered by that entity's primary key so that consecutive pages
+// neither overlap nor skip entities.
+//
+// The page and the total are read in a single statement, counting the matches in a
+// subquery, so they are consistent with each other; only when the page is empty is the
+// total counted separately, as the statement then returns no rows to carry it.
+//
+// The total counts distinct entities rather than rows, and the page returns distinct
+// rows, so an entity the MATCH reaches along several paths is counted and returned once
+// as long as the other returned expressions are the same on each of those rows.
+//
+// Example:
+//
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("u", "User").WithProperties(map[string]any{"active": true})).
+//	    Return("u")
+//	page, err := userRepo.FindPageWithTotal(ctx, qb, 20, 40)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - qb: A configured gocypher.QueryBuilder instance returning the entity alias first.
+//   - limit: The maximum number of entities in the page; must be positive.
+//   - offset: The number of entities to skip; must not be negative.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	The requested Page, or an error if the builder has no RETURN clause starting with an
+//	alias, cannot be built, or the query fails.
+func (r *Repository[T]) FindPageWithTotal(ctx context.Context, qb *gocypher.QueryBuilder, limit, offset int64, opts ...FindOption) (*Page[T], error) {
+	options, err := parseOptions("FindPageWithTotal", opts, pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("page limit must be positive, got %d", limit)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("page offset must not be negative, got %d", offset)
+	}
+	query, params, err := buildQuery("FindPageWithTotal", r.meta.Label, qb)
+	if err != nil {
+		return nil, err
+	}
+	match, returns, ok := strings.Cut(query, "\nRETURN ")
+	if !ok {
+		return nil, fmt.Errorf("FindPageWithTotal on %s: the builder must have a RETURN clause", r.meta.Label)
+	}
+	alias, _, _ := strings.Cut(returns, ",")
+	alias = strings.TrimSpace(alias)
+	if !identifierPattern.MatchString(alias) {
+		return nil, fmt.Errorf("FindPageWithTotal on %s: the first returned expression must be the entity alias, got '%s'", r.meta.Label, alias)
+	}
+
+	// gocypher has no CALL, ORDER BY, SKIP or LIMIT, so the query is assembled here. The
+	// subquery does not import any variable, so the caller's aliases do not clash.
+	count := fmt.Sprintf("%s\nRETURN count(DISTINCT %s) AS total", match, alias)
+	paged := fmt.Sprintf("CALL {\n%s\n}\n%s\nRETURN DISTINCT %s, total\nORDER BY %s.%s\nSKIP $pageOffset\nLIMIT $pageLimit",
+		count, match, returns, alias, r.meta.PKProp)
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["pageOffset"] = offset
+	params["pageLimit"] = limit
+
+	eagerResult, err := r.run(ctx, paged, params, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(eagerResult.Records) == 0 {
+		countResult, err := r.run(ctx, count, params, options)
+		if err != nil {
+			return nil, err
+		}
+		var total int64
+		if len(countResult.Records) > 0 {
+			value, _ := countResult.Records[0].Get("total")
+			total, _ = value.(int64)
+		}
+		return newPage[T](nil, total, limit, offset), nil
+	}
+
+	value, _ := eagerResult.Records[0].Get("total")
+	total, _ := value.(int64)
+	entities, err := r.mapRecords(ctx, eagerResult.Records, options)
+	if entities == nil {
+		return nil, err
+	}
+	return newPage(entities, total, limit, offset), err
+}
+
+// pageQuery builds the MATCH ... RETURN ... ORDER BY ... SKIP ... LIMIT query shared by
+// the paginated finders, returning the given expressions.
+func (r *Repository[T]) pageQuery(returns string, limit, offset int64, sorts []Sort) (string, map[string]interface{}, error) {
+	if limit <= 0 {
+		return "", nil, fmt.Errorf("page limit must be positive, got %d", limit)
+	}
+	if offset < 0 {
+		return "", nil, fmt.Errorf("page offset must not be negative, got %d", offset)
+	}
+	order, err := r.orderClause(sorts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query, params, err := gocypher.NewQueryBuilder().
+		Match(gocypher.N("n", r.meta.Label)).
+		Return(returns).
+		Build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// gocypher has no ORDER BY, SKIP or LIMIT, so they are appended to the built query.
+	query = fmt.Sprintf("%s\nORDER BY %s\nSKIP $pageOffset\nLIMIT $pageLimit", query, order)
+	params["pageOffset"] = offset
+	params["pageLimit"] = limit
+	return query, params, nil
+}
+
+// orderClause renders the sorts as the expressions of an ORDER BY clause on alias n, with
+// ties broken by primary key in the direction of the last sort. Property names come from
+// the validated mappings, never from the caller.
+func (r *Repository[T]) orderClause(sorts []Sort) (string, error) {
+	terms := make([]string, 0, len(sorts)+1)
+	hasPK := false
+	pkDesc := false
+	for _, sort := range sorts {
+		propName, err := r.orderProperty(sort.Field)
+		if err != nil {
+			return "", err
+		}
+		term := "n." + propName
+		if sort.Desc {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+		hasPK = hasPK || propName == r.meta.PKProp
+		pkDesc = sort.Desc
+	}
+	if !hasPK {
+		term := "n." + r.meta.PKProp
+		if pkDesc {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, ", "), nil
+}
+
+// orderProperty resolves an ordering with resolveProperty, defaulting to the primary key
+// when empty.
+func (r *Repository[T]) orderProperty(orderBy string) (string, error) {
+	if orderBy == "" {
+		return r.meta.PKProp, nil
+	}
+	propName, err := r.resolveProperty(orderBy)
+	if err != nil {
+		return "", fmt.Errorf("cannot order by '%s': %w", orderBy, err)
+	}
+	return propName, nil
+}
+
+// FindByProperty retrieves all entities of type T that match a specific property-value pair.
+// This is useful for querying on non-primary-key fields (e.g., finding users by email).
+//
+// Parameters:
+//   - propName: The name of the property in the Neo4j node (e.g., "email"), or the Go
+//     field name mapped to it (e.g., "Email").
+//   - propValue: The value to match for the given property. A nil value matches the
+//     nodes that lack the property.
+//   - opts: Optional per-call settings, such as UseIndex planner hints or CollectMappingErrors.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities. Returns an empty slice if no entities match.
+func (r *Repository[T]) FindByProperty(ctx context.Context, propName string, propValue interface{}, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("FindByProperty", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Safety check: ensure the key is a valid, mapped field or property for the entity.
+	propName, err = r.resolveProperty(propName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the MATCH query with the specified property.
+	query, params, err := r.propertyQuery(propName, propValue, "n")
+	if err != nil {
+		return nil, err
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return []*T{}, nil
+		}
+		return nil, err
+	}
+
+	// Map all resulting records to a slice of entity structs.
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// FindByProperties retrieves all entities of type T matching every given property-value
+// pair, e.g. status = "active" AND tenantId = "t1". Keys may be mapped property names
+// (e.g., "tenantId") or Go field names (e.g., "TenantID"), and are validated against the
+// entity's mappings. Values are converted like FindByProperty's, and a nil value matches
+// nodes without the property. An empty map is rejected rather than treated as FindAll, so
+// that a missing filter cannot silently load a whole label.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - props: The values to match, keyed by property or field name.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities. Returns an empty slice if no entities match.
+func (r *Repository[T]) FindByProperties(ctx context.Context, props map[string]interface{}, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("FindByProperties", opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(props) == 0 {
+		return nil, fmt.Errorf("FindByProperties on %s needs at least one property; use FindAll to load every entity", r.meta.Label)
+	}
+
+	matchProps := make(map[string]interface{}, len(props))
+	for key, value := range props {
+		propName, err := r.resolveProperty(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := matchProps[propName]; dup {
+			return nil, fmt.Errorf("property '%s' of entity type %s is given more than once", propName, r.meta.Label)
+		}
+		matchProps[propName] = value
+	}
+
+	query, params, err := r.propertiesQuery(matchProps, "n")
 	if err != nil {
 		return nil, err
 	}
 
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
-		// An empty result set is not considered an error for FindAll.
-		if errors.Is(err, ErrNotFound) {
-			return []*T{}, nil
-		}
 		return nil, err
 	}
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
 
-	// Map all resulting records to a slice of entity structs.
-	entities := make([]*T, len(eagerResult.Records))
-	for i, record := range eagerResult.Records {
-		nodeValue, _ := record.Get("n")
-		node := nodeValue.(neo4j.Node)
-
-		entity := new(T)
-		if err := mapNodeToStruct(node, entity, r.meta); err != nil {
-			return nil, err // Return on the first mapping error.
-		}
-		entities[i] = entity
+// resolveProperty maps a Go field name or a mapped property name to the property name.
+// Field names take precedence; parseTagsFromType rejects mappings where that matters.
+func (r *Repository[T]) resolveProperty(key string) (string, error) {
+	if propName, ok := r.meta.Mappings[key]; ok {
+		return propName, nil
 	}
-
-	return entities, nil
+	if err := r.requireMappedProperty(key); err != nil {
+		return "", err
+	}
+	return key, nil
 }
 
-// FindByProperty retrieves all entities of type T that match a specific property-value pair.
-// This is useful for querying on non-primary-key fields (e.g., finding users by email).
+// ExistsByProperty reports whether at least one entity has the given property value, such
+// as whether an email address is already taken. Unlike CountByProperty it stops at the first
+// match instead of counting every matching node.
 //
 // Parameters:
-//   - propName: The name of the property in the Neo4j node (e.g., "email").
-//   - propValue: The value to match for the given property.
+//   - ctx: The context for the query execution.
+//   - propName: The name of the property in the Neo4j node (e.g., "email"), or the Go
+//     field name mapped to it (e.g., "Email").
+//   - propValue: The value to match for the given property. A nil value matches the
+//     nodes that lack the property.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
 //
 // Returns:
 //
-//	A slice of pointers to the found entities. Returns an empty slice if no entities match.
-func (r *Repository[T]) FindByProperty(ctx context.Context, propName string, propValue interface{}) ([]*T, error) {
-	// Safety check: ensure the property name is a valid, mapped property for the entity.
-	isMappedProperty := false
-	for _, p := range r.meta.Mappings {
-		if p == propName {
-			isMappedProperty = true
-			break
-		}
+//	true if a matching node exists, false otherwise, or an error if the property is not
+//	mapped or the query fails.
+func (r *Repository[T]) ExistsByProperty(ctx context.Context, propName string, propValue interface{}, opts ...FindOption) (bool, error) {
+	options, err := parseOptions("ExistsByProperty", opts, lookupOptions)
+	if err != nil {
+		return false, err
 	}
-	if !isMappedProperty {
-		return nil, fmt.Errorf("property '%s' is not a mapped property for entity type %s", propName, r.meta.Label)
+	propName, err = r.resolveProperty(propName)
+	if err != nil {
+		return false, err
 	}
 
-	// Build the MATCH query with the specified property.
-	props := map[string]interface{}{propName: propValue}
-	query, params, err := gocypher.NewQueryBuilder().
-		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
-		Return("n").
-		Build()
+	query, params, err := r.propertyQuery(propName, propValue, "true AS exists")
 	if err != nil {
-		return nil, err
+		return false, err
 	}
+	// gocypher has no LIMIT clause, so it is appended to the built query.
+	query += "\nLIMIT 1"
 
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			return []*T{}, nil
-		}
-		return nil, err
+		return false, err
 	}
+	return existsFromResult(eagerResult), nil
+}
 
-	// Map all resulting records to a slice of entity structs.
-	entities := make([]*T, len(eagerResult.Records))
-	for i, record := range eagerResult.Records {
-		nodeValue, _ := record.Get("n")
-		node := nodeValue.(neo4j.Node)
+// propertyQuery renders `MATCH (n:Label {prop: $value}) RETURN <returns>`. A nil value,
+// including a typed nil pointer, slice or map, renders `WHERE n.prop IS NULL` instead,
+// since a null in a property map never matches anything.
+func (r *Repository[T]) propertyQuery(propName string, propValue interface{}, returns string) (string, map[string]interface{}, error) {
+	return r.propertiesQuery(map[string]interface{}{propName: propValue}, returns)
+}
 
-		entity := new(T)
-		if err := mapNodeToStruct(node, entity, r.meta); err != nil {
-			return nil, err
+// propertiesQuery is propertyQuery for several properties, all of which must match: the
+// non-nil values are converted like conditions, or like the IDs of FindByID for the
+// primary key, and matched in the node pattern, and nil values become `IS NULL`
+// predicates, in property name order.
+func (r *Repository[T]) propertiesQuery(props map[string]interface{}, returns string) (string, map[string]interface{}, error) {
+	matchProps := make(map[string]interface{}, len(props))
+	var nullProps []string
+	for propName, propValue := range props {
+		if isNilValue(propValue) {
+			nullProps = append(nullProps, propName)
+			continue
+		}
+		fieldName, _ := r.meta.fieldForProperty(propName)
+		convert := r.meta.conditionValue
+		if fieldName == r.meta.PKField {
+			// Keys are converted like the IDs given to FindByID, whatever their Go type.
+			convert = func(_ string, value interface{}) (interface{}, error) { return r.meta.pkParam(value) }
+		}
+		value, err := convert(fieldName, propValue)
+		if err != nil {
+			return "", nil, err
 		}
-		entities[i] = entity
+		matchProps[propName] = value
 	}
+	node := gocypher.N("n", r.meta.Label)
+	if len(matchProps) > 0 {
+		node = node.WithProperties(matchProps)
+	}
+	query, params, err := gocypher.NewQueryBuilder().Match(node).Return(returns).Build()
+	if err != nil || len(nullProps) == 0 {
+		return query, params, err
+	}
+	// gocypher has no WHERE, so the predicates are rendered here. The property names come
+	// from the validated mappings.
+	sort.Strings(nullProps)
+	predicates := make([]string, len(nullProps))
+	for i, propName := range nullProps {
+		predicates[i] = "n." + propName + " IS NULL"
+	}
+	match, rest, _ := strings.Cut(query, "\nRETURN ")
+	return match + "\nWHERE " + strings.Join(predicates, " AND ") + "\nRETURN " + rest, params, nil
+}
+
+// isNilValue reports whether value is nil, or a nil pointer, slice or map.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+	return false
+}
 
-	return entities, nil
+// requireMappedProperty checks that propName is a mapped property of the entity.
+func (r *Repository[T]) requireMappedProperty(propName string) error {
+	if _, ok := r.meta.fieldForProperty(propName); !ok {
+		return fmt.Errorf("property '%s' is not a mapped property for entity type %s", propName, r.meta.Label)
+	}
+	return nil
 }
 
 // Find executes a custom query defined by a gocypher.QueryBuilder and intelligently
@@ -287,9 +1825,11 @@ func (r *Repository[T]) FindByProperty(ctx context.Context, propName string, pro
 //
 // The method inspects each result record and maps the returned data to the fields
 // of the entity struct T.
-//   - If a full neo4j.Node is returned (e.g., `RETURN u`), all struct fields are populated.
+//   - If a full Node is returned (e.g., `RETURN u`), all struct fields are populated.
 //   - If specific properties are returned (e.g., `RETURN u.name, u.email`), only the
 //     corresponding struct fields will be populated, leaving the others as their zero value.
+//     A column with a custom name (e.g., `RETURN u.name AS displayName`) is mapped onto
+//     the field whose tag declares it as alias (e.g., `crud:"property:name,alias:displayName"`).
 //
 // Example for a full entity:
 //
@@ -308,18 +1848,27 @@ func (r *Repository[T]) FindByProperty(ctx context.Context, propName string, pro
 //
 // Parameters:
 //   - qb: A configured gocypher.QueryBuilder instance that defines the query.
+//   - opts: Optional per-call settings, such as RewriteQuery or CollectMappingErrors.
 //
 // Returns:
 //
 //	A slice of pointers to the found entities, populated with the data returned by
 //	the query. Returns an empty slice if no records are found.
-func (r *Repository[T]) Find(ctx context.Context, qb *gocypher.QueryBuilder) ([]*T, error) {
-	query, params, err := qb.Build()
+//
+// Pass CollectMappingErrors to skip records that cannot be mapped instead of failing.
+// If the builder is nil or cannot be built, the error is a *QueryBuildError; the same
+// applies to every method that accepts a builder.
+func (r *Repository[T]) Find(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("Find", opts)
 	if err != nil {
-		return nil, fmt.Errorf("could not build query: %w", err)
+		return nil, err
+	}
+	query, params, err := buildQuery("Find", r.meta.Label, qb)
+	if err != nil {
+		return nil, err
 	}
 
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			return []*T{}, nil
@@ -327,56 +1876,53 @@ func (r *Repository[T]) Find(ctx context.Context, qb *gocypher.QueryBuilder) ([]
 		return nil, err
 	}
 
-	var entities []*T
-
-	// 1. Iterate over each record (row) returned by Neo4j.
-	for _, record := range eagerResult.Records {
-		entity := new(T)
-		val := reflect.ValueOf(entity).Elem()
+	// Iterate over each record (row) returned by Neo4j and hydrate an entity from it.
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
 
-		// Optimization: Check if a full node is present in the result. If so, map it directly.
-		// This is a common case (e.g., RETURN n) and is more efficient.
-		var mappedFromNode bool
-		for _, value := range record.Values {
-			if node, ok := value.(neo4j.Node); ok {
-				if err := mapNodeToStruct(node, entity, r.meta); err != nil {
-					return nil, err
-				}
-				mappedFromNode = true
-				break
-			}
-		}
+// FindRaw is the escape hatch of Find for Cypher the query builder cannot express, such
+// as CALL subqueries or pattern comprehensions. It executes the given statement and maps
+// each record onto an entity exactly as Find does, from a returned node or from property
+// projections. Values must be passed as parameters, never formatted into the statement.
+//
+// Example:
+//
+//	users, err := userRepo.FindRaw(ctx,
+//	    "MATCH (u:User) WHERE size([(u)-[:WROTE]->(p:Post) | p]) > $min RETURN u",
+//	    map[string]any{"min": 10})
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - cypher: The Cypher statement to execute.
+//   - params: The statement's parameters; may be nil.
+//   - opts: Optional per-call settings, such as RewriteQuery or CollectMappingErrors.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities, or an empty slice if no records are found,
+//	or an error if the statement is empty or the query or mapping fails.
+func (r *Repository[T]) FindRaw(ctx context.Context, cypher string, params map[string]interface{}, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("FindRaw", opts)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(cypher) == "" {
+		return nil, fmt.Errorf("FindRaw on %s: the Cypher statement is empty", r.meta.Label)
+	}
+	// The parameters are copied, since rewriters may add their own.
+	params = maps.Clone(params)
+	if params == nil {
+		params = map[string]interface{}{}
+	}
 
-		// 2. If the result did not contain a full node, hydrate the struct property by property.
-		// This handles partial projections (e.g., RETURN u.name, u.email).
-		if !mappedFromNode {
-			// Iterate over the Go struct's mapped fields.
-			for goFieldName, neo4jPropName := range r.meta.Mappings {
-				field := val.FieldByName(goFieldName)
-
-				// 3. Find a key in the result record that matches the struct's property name.
-				// This works for direct aliases (`RETURN u.name AS name`) and for property projections (`RETURN u.name`).
-				var foundValue any
-				var found bool
-				for _, key := range record.Keys {
-					if key == neo4jPropName || strings.HasSuffix(key, "."+neo4jPropName) {
-						foundValue, found = record.Get(key)
-						break
-					}
-				}
-
-				// 4. If a matching value was found, set it on the corresponding struct field.
-				if found && field.IsValid() && field.CanSet() {
-					if foundValue != nil {
-						field.Set(reflect.ValueOf(foundValue))
-					}
-				}
-			}
+	eagerResult, err := r.run(ctx, cypher, params, options)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return []*T{}, nil
 		}
-		entities = append(entities, entity)
+		return nil, err
 	}
-
-	return entities, nil
+	return r.mapRecords(ctx, eagerResult.Records, options)
 }
 
 // FindOne executes a query expected to return a single entity.
@@ -388,15 +1934,19 @@ func (r *Repository[T]) Find(ctx context.Context, qb *gocypher.QueryBuilder) ([]
 // Returns:
 //   - A pointer to the found entity if exactly one record is returned.
 //   - An ErrNotFound error if the query returns zero records.
-//   - An error if the query returns more than one record, indicating a data consistency issue.
+//   - A *MultipleFoundError (matching ErrMultipleFound) if the query returns more than one record.
 //   - Any other error encountered during query execution or mapping.
-func (r *Repository[T]) FindOne(ctx context.Context, qb *gocypher.QueryBuilder) (*T, error) {
-	query, params, err := qb.Build()
+func (r *Repository[T]) FindOne(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (*T, error) {
+	options, err := parseOptions("FindOne", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("FindOne", r.meta.Label, qb)
 	if err != nil {
-		return nil, fmt.Errorf("could not build query: %w", err)
+		return nil, err
 	}
 
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
 		return nil, err // This will propagate ErrNotFound from the runner if applicable.
 	}
@@ -406,45 +1956,16 @@ func (r *Repository[T]) FindOne(ctx context.Context, qb *gocypher.QueryBuilder)
 		return nil, ErrNotFound
 	}
 	if len(eagerResult.Records) > 1 {
-		return nil, fmt.Errorf("expected 1 record but found %d", len(eagerResult.Records))
+		return nil, &MultipleFoundError{Label: r.meta.Label, Count: len(eagerResult.Records)}
 	}
 
 	// --- Mapping Logic (reused from Find) ---
-	record := eagerResult.Records[0]
 	entity := new(T)
-	val := reflect.ValueOf(entity).Elem()
-
-	var mappedFromNode bool
-	for _, value := range record.Values {
-		if node, ok := value.(neo4j.Node); ok {
-			if err := mapNodeToStruct(node, entity, r.meta); err != nil {
-				return nil, err
-			}
-			mappedFromNode = true
-			break
-		}
-	}
-
-	if !mappedFromNode {
-		for goFieldName, neo4jPropName := range r.meta.Mappings {
-			field := val.FieldByName(goFieldName)
-			var foundValue any
-			var found bool
-			for _, key := range record.Keys {
-				if key == neo4jPropName || strings.HasSuffix(key, "."+neo4jPropName) {
-					foundValue, found = record.Get(key)
-					break
-				}
-			}
-			if found && field.IsValid() && field.CanSet() {
-				if foundValue != nil {
-					field.Set(reflect.ValueOf(foundValue))
-				}
-			}
-		}
+	if err := mapRecordToStruct(eagerResult.Records[0], entity, r.meta); err != nil {
+		return nil, err
 	}
 
-	return entity, nil
+	return entity, r.afterLoad(ctx, entity, -1)
 }
 
 // FindFirst executes a query and returns only the first entity found, ignoring any
@@ -458,13 +1979,17 @@ func (r *Repository[T]) FindOne(ctx context.Context, qb *gocypher.QueryBuilder)
 //   - A pointer to the first found entity.
 //   - An ErrNotFound error if the query returns zero records.
 //   - Any other error encountered during query execution or mapping.
-func (r *Repository[T]) FindFirst(ctx context.Context, qb *gocypher.QueryBuilder) (*T, error) {
-	query, params, err := qb.Build()
+func (r *Repository[T]) FindFirst(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (*T, error) {
+	options, err := parseOptions("FindFirst", opts, lookupOptions)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := buildQuery("FindFirst", r.meta.Label, qb)
 	if err != nil {
-		return nil, fmt.Errorf("could not build query: %w", err)
+		return nil, err
 	}
 
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
 		return nil, err // This will propagate ErrNotFound from the runner if applicable.
 	}
@@ -476,46 +2001,21 @@ func (r *Repository[T]) FindFirst(ctx context.Context, qb *gocypher.QueryBuilder
 	// Note: We do NOT check for len > 1. We intentionally take the first result.
 
 	// --- Mapping Logic (same as FindOne) ---
-	record := eagerResult.Records[0]
 	entity := new(T)
-	val := reflect.ValueOf(entity).Elem()
-
-	var mappedFromNode bool
-	for _, value := range record.Values {
-		if node, ok := value.(neo4j.Node); ok {
-			if err := mapNodeToStruct(node, entity, r.meta); err != nil {
-				return nil, err
-			}
-			mappedFromNode = true
-			break
-		}
-	}
-
-	if !mappedFromNode {
-		for goFieldName, neo4jPropName := range r.meta.Mappings {
-			field := val.FieldByName(goFieldName)
-			var foundValue any
-			var found bool
-			for _, key := range record.Keys {
-				if key == neo4jPropName || strings.HasSuffix(key, "."+neo4jPropName) {
-					foundValue, found = record.Get(key)
-					break
-				}
-			}
-			if found && field.IsValid() && field.CanSet() {
-				if foundValue != nil {
-					field.Set(reflect.ValueOf(foundValue))
-				}
-			}
-		}
+	if err := mapRecordToStruct(eagerResult.Records[0], entity, r.meta); err != nil {
+		return nil, err
 	}
 
-	return entity, nil
+	return entity, r.afterLoad(ctx, entity, -1)
 }
 
 // Count returns the total number of entities of type T in the database.
 // It performs a `MATCH (n:Label) RETURN count(n)` query.
-func (r *Repository[T]) Count(ctx context.Context) (int64, error) {
+func (r *Repository[T]) Count(ctx context.Context, opts ...FindOption) (int64, error) {
+	options, err := parseOptions("Count", opts, lookupOptions)
+	if err != nil {
+		return 0, err
+	}
 	qb := gocypher.NewQueryBuilder().
 		Match(gocypher.N("n", r.meta.Label)).
 		Return("count(n) AS count")
@@ -526,7 +2026,7 @@ func (r *Repository[T]) Count(ctx context.Context) (int64, error) {
 	}
 
 	// We use the raw runner because we expect a number, not an entity.
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
 		return 0, err
 	}
@@ -549,22 +2049,25 @@ func (r *Repository[T]) Count(ctx context.Context) (int64, error) {
 // property-value pair.
 //
 // Parameters:
-//   - propName: The name of the property in the Neo4j node.
-//   - propValue: The value to match for the given property.
-func (r *Repository[T]) CountByProperty(ctx context.Context, propName string, propValue interface{}) (int64, error) {
-	// ... (puedes añadir la misma validación de propiedad que en FindByProperty) ...
-
-	props := map[string]interface{}{propName: propValue}
-	qb := gocypher.NewQueryBuilder().
-		Match(gocypher.N("n", r.meta.Label).WithProperties(props)).
-		Return("count(n) AS count")
+//   - propName: The name of the property in the Neo4j node, or the Go field name mapped to it.
+//   - propValue: The value to match for the given property. A nil value counts the nodes
+//     that lack the property.
+func (r *Repository[T]) CountByProperty(ctx context.Context, propName string, propValue interface{}, opts ...FindOption) (int64, error) {
+	options, err := parseOptions("CountByProperty", opts, lookupOptions)
+	if err != nil {
+		return 0, err
+	}
+	propName, err = r.resolveProperty(propName)
+	if err != nil {
+		return 0, err
+	}
 
-	query, params, err := qb.Build()
+	query, params, err := r.propertyQuery(propName, propValue, "count(n) AS count")
 	if err != nil {
 		return 0, fmt.Errorf("could not build count query: %w", err)
 	}
 
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
 		return 0, err
 	}
@@ -595,14 +2098,18 @@ func (r *Repository[T]) CountByProperty(ctx context.Context, propName string, pr
 //	    Where("u.age > 30").
 //	    Return("count(u) AS count") // The "AS count" is required.
 //	total, err := userRepo.CountWithQuery(ctx, qb)
-func (r *Repository[T]) CountWithQuery(ctx context.Context, qb *gocypher.QueryBuilder) (int64, error) {
-	query, params, err := qb.Build()
+func (r *Repository[T]) CountWithQuery(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (int64, error) {
+	options, err := parseOptions("CountWithQuery", opts, lookupOptions)
 	if err != nil {
-		return 0, fmt.Errorf("could not build count query: %w", err)
+		return 0, err
+	}
+	query, params, err := buildQuery("CountWithQuery", r.meta.Label, qb)
+	if err != nil {
+		return 0, err
 	}
 
 	// We use the raw runner because we expect a number, not an entity.
-	eagerResult, err := r.runner.Run(ctx, query, params)
+	eagerResult, err := r.run(ctx, query, params, options)
 	if err != nil {
 		// If the query returns no rows (e.g., MATCH fails), the count is 0.
 		if errors.Is(err, ErrNotFound) {
@@ -625,52 +2132,332 @@ func (r *Repository[T]) CountWithQuery(ctx context.Context, qb *gocypher.QueryBu
 	return countValue.(int64), nil
 }
 
-// SaveAll creates or updates a slice of entities in a single, efficient database call.
+// ExistsWithQuery reports whether a custom query matches anything. The builder supplies
+// only the MATCH (and WHERE) logic; ExistsWithQuery appends `RETURN true AS exists LIMIT 1`
+// itself, so the database stops at the first match instead of counting every one as
+// CountWithQuery would.
+//
+// Example:
+//
+//	qb := gocypher.NewQueryBuilder().
+//	    Match(gocypher.N("u", "User").WithProperties(map[string]any{"email": email}))
+//	taken, err := userRepo.ExistsWithQuery(ctx, qb)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - qb: A configured gocypher.QueryBuilder instance without a RETURN clause.
+//   - opts: Optional per-call settings, such as UseIndex or RewriteQuery.
+//
+// Returns:
+//
+//	true if the query matched at least once, false otherwise, or an error if the builder
+//	already has a RETURN clause, cannot be built, or the query fails.
+func (r *Repository[T]) ExistsWithQuery(ctx context.Context, qb *gocypher.QueryBuilder, opts ...FindOption) (bool, error) {
+	options, err := parseOptions("ExistsWithQuery", opts, lookupOptions)
+	if err != nil {
+		return false, err
+	}
+	query, params, err := buildQuery("ExistsWithQuery", r.meta.Label, qb)
+	if err != nil {
+		return false, err
+	}
+	if hasClause(query, "RETURN") {
+		return false, &QueryBuildError{
+			Operation: "ExistsWithQuery",
+			Label:     r.meta.Label,
+			Clauses:   builderClauses(qb),
+			Err:       fmt.Errorf("the builder must not have a RETURN clause; ExistsWithQuery adds its own"),
+		}
+	}
+	// gocypher has no LIMIT clause, so the projection and limit are appended to the built query.
+	query += "\nRETURN true AS exists\nLIMIT 1"
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return false, err
+	}
+	return existsFromResult(eagerResult), nil
+}
+
+// SaveAll creates or updates a slice of entities with as few database calls as possible.
 // It uses an UNWIND + MERGE Cypher query to perform a bulk "upsert" operation.
 // This is significantly more performant than calling Save in a loop.
 //
+// Large slices are split into chunks of the repository's batch size (1000 by default,
+// configurable with WithBatchSize), each sent as a single statement. Chunks are committed
+// independently: if one fails, the chunks before it remain saved and the returned error
+// identifies the failing chunk and its range of entities.
+//
+// Like Save, properties are merged onto existing nodes (`SET n += ...`), so properties
+// not mapped by the entity are left untouched.
+//
 // Parameters:
 //   - ctx: The context for the query execution.
 //   - entities: A slice of pointers to the struct instances to be saved.
+//   - opts: Optional per-call settings, such as RewriteQuery.
 //
 // Returns:
 //
-//	An error if the query execution fails.
-func (r *Repository[T]) SaveAll(ctx context.Context, entities []*T) error {
+//	An error if an entity has a zero-value primary key or shares its key with another
+//	entity of the slice (checked before anything is sent), or if the query execution fails.
+func (r *Repository[T]) SaveAll(ctx context.Context, entities []*T, opts ...WriteOption) error {
+	options, err := r.parseWriteOptions("SaveAll", opts, writeOptions)
+	if err != nil {
+		return err
+	}
 	if len(entities) == 0 {
 		return nil // Nothing to do.
 	}
 
-	// 1. Create a list of maps, where each map represents the properties of an entity.
-	// This list will be passed as a single parameter to the Cypher query.
-	var propsList []map[string]interface{}
-	for _, entity := range entities {
-		val := reflect.ValueOf(entity).Elem()
-		props := make(map[string]interface{})
-		for fieldName, propName := range r.meta.Mappings {
-			props[propName] = val.FieldByName(fieldName).Interface()
-		}
-		propsList = append(propsList, props)
+	// 1. Create a list of rows, where each row holds the primary key and the other
+	// properties of an entity.
+	rows, err := r.saveRows(ctx, entities)
+	if err != nil {
+		return err
 	}
 
 	// 2. Construct the UNWIND query.
-	// UNWIND turns the list of maps into individual rows.
+	// UNWIND turns the list of rows into individual rows.
 	// MERGE finds a node by its primary key or creates it if it doesn't exist.
-	// SET updates all properties for both new and existing nodes.
+	// SET merges the remaining properties for both new and existing nodes.
 	query := fmt.Sprintf(
-		"UNWIND $propsList AS props\n"+
-			"MERGE (n:%s {%s: props.%s})\n"+
-			"SET n = props",
+		"UNWIND $rows AS row\n"+
+			"MERGE (n:%s {%s: row.pk})\n"+
+			"SET n += row.props",
 		r.meta.Label,
 		r.meta.PKProp,
-		r.meta.PKProp,
 	)
+	if len(r.meta.Defaults) > 0 {
+		query = strings.Replace(query, "\nSET", "\nON CREATE SET n += row.defaults\nSET", 1)
+	}
 
-	params := map[string]interface{}{
-		"propsList": propsList,
+	// 3. Execute the bulk operation, one chunk at a time.
+	batchSize := r.config.batchSizeOrDefault()
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		params := map[string]interface{}{"rows": rows[start:end]}
+		if _, err := r.run(ctx, query, params, options); err != nil {
+			return fmt.Errorf("could not save chunk %d (entities %d to %d): %w", start/batchSize, start, end-1, err)
+		}
 	}
+	return nil
+}
 
-	// 3. Execute the bulk operation.
-	_, err := r.runner.Run(ctx, query, params)
-	return err
+// saveRows prepares the UNWIND rows of a bulk write, each holding the primary key and the
+// other properties of an entity, and the defaults to write on creation if the entity has
+// fields tagged `default:` (see createDefaults). Zero-value and duplicate keys are rejected
+// before touching the database, since their entities would be merged onto the same node
+// and UpsertAll would count the extra rows as updates.
+func (r *Repository[T]) saveRows(ctx context.Context, entities []*T) ([]map[string]interface{}, error) {
+	rows := make([]map[string]interface{}, 0, len(entities))
+	seen := make(map[interface{}]int, len(entities))
+	for i, entity := range entities {
+		if entity == nil {
+			return nil, fmt.Errorf("entity at index %d is nil", i)
+		}
+		if err := r.prepareSave(ctx, entity, i); err != nil {
+			return nil, err
+		}
+		val := reflect.ValueOf(entity).Elem()
+		pkField := val.FieldByName(r.meta.PKField)
+		if pkField.IsZero() {
+			return nil, fmt.Errorf("entity at index %d has a zero-value primary key (%s)", i, r.meta.PKField)
+		}
+		if pkField.Type().Comparable() {
+			if first, ok := seen[pkField.Interface()]; ok {
+				return nil, fmt.Errorf("entities at index %d and %d have the same primary key (%s = %v)", first, i, r.meta.PKField, pkField.Interface())
+			}
+			seen[pkField.Interface()] = i
+		}
+		r.touchUpdatedAt(val)
+		props, err := r.savedProperties(val)
+		if err != nil {
+			return nil, err
+		}
+		row := map[string]interface{}{"pk": pkField.Interface(), "props": props}
+		if len(r.meta.Defaults) > 0 {
+			if row["defaults"], err = r.meta.createDefaults(val); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// mapRecords hydrates one entity per record using mapRecordToStruct.
+// By default the first mapping failure aborts the operation. When the CollectMappingErrors
+// option is set, unmappable records are skipped and their failures are returned together
+// as a *MappingErrors alongside the successfully mapped entities. Records beyond the
+// call's WithMaxResults cap are dropped and reported as a *ResultTruncatedError. Entities
+// implementing AfterLoader have their hook run.
+func (r *Repository[T]) mapRecords(ctx context.Context, records []*Record, options *queryOptions) ([]*T, error) {
+	var truncated error
+	if options.resultCap > 0 && int64(len(records)) > options.resultCap {
+		records = records[:options.resultCap]
+		truncated = &ResultTruncatedError{Label: r.meta.Label, Limit: options.resultCap}
+	}
+	entities := make([]*T, 0, len(records))
+	var mappingErrs []*MappingError
+
+	for _, record := range records {
+		entity := r.newEntity()
+		if err := mapRecordToStruct(record, entity, r.meta); err != nil {
+			r.release(entity)
+			var mappingErr *MappingError
+			if !options.collectMappingErrors || !errors.As(err, &mappingErr) {
+				r.Release(entities)
+				return nil, err // Return on the first mapping error.
+			}
+			mappingErrs = append(mappingErrs, mappingErr)
+			continue
+		}
+		entities = append(entities, entity)
+	}
+
+	// AfterLoad hooks run once mapping is complete; the first failure is returned together
+	// with every loaded entity.
+	for i, entity := range entities {
+		if err := r.afterLoad(ctx, entity, i); err != nil {
+			return entities, err
+		}
+	}
+
+	if len(mappingErrs) > 0 {
+		if truncated != nil {
+			return entities, errors.Join(&MappingErrors{Errors: mappingErrs}, truncated)
+		}
+		return entities, &MappingErrors{Errors: mappingErrs}
+	}
+	return entities, truncated
+}
+
+// Release returns entities obtained from a finder to the pool configured with
+// WithEntityPool, for reuse by later calls. The entities must not be used afterwards.
+// Without a pool, Release does nothing.
+func (r *Repository[T]) Release(entities []*T) {
+	for _, entity := range entities {
+		r.release(entity)
+	}
+}
+
+// newEntity returns a zeroed entity, from the pool if one is configured.
+func (r *Repository[T]) newEntity() *T {
+	if r.config.entityPool == nil {
+		return new(T)
+	}
+	entity, ok := r.config.entityPool.Get().(*T)
+	if !ok || entity == nil {
+		return new(T)
+	}
+	var zero T
+	*entity = zero
+	return entity
+}
+
+// release puts a single entity back into the pool, if one is configured.
+func (r *Repository[T]) release(entity *T) {
+	if r.config.entityPool != nil && entity != nil {
+		r.config.entityPool.Put(entity)
+	}
+}
+
+// run is the single point through which the repository executes queries. It applies the
+// library's own rewriters (e.g., index hints), then the repository's rewriters, then the
+// per-call rewriters, and finally executes the query with the runner.
+func (r *Repository[T]) run(ctx context.Context, query string, params map[string]interface{}, options *queryOptions) (*ResultSet, error) {
+	if options == nil {
+		options = newQueryOptions(nil)
+	}
+	if options.distanceOrder != nil {
+		if err := requireFeature(ctx, r.runner, features.PointDistance); err != nil {
+			return nil, err
+		}
+	}
+	query, params, err := r.rewrite(query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+
+	result, err := execute(ctx, r.runner, query, params, r.callConfig(options))
+	if err != nil {
+		return nil, annotateHintError(err, options.indexHints)
+	}
+	return result, nil
+}
+
+// stream is the streaming counterpart of run: it applies the same rewriters and returns a
+// cursor over the records, streamed when the runner is a StreamRunner and buffered
+// otherwise. Per-call settings such as OnDatabase are applied to the stream too.
+func (r *Repository[T]) stream(ctx context.Context, query string, params map[string]interface{}, options *queryOptions) (RecordStream, error) {
+	if options == nil {
+		options = newQueryOptions(nil)
+	}
+	streamer, ok := r.runner.(StreamRunner)
+	if !ok {
+		result, err := r.run(ctx, query, params, options)
+		if err != nil {
+			return nil, err
+		}
+		return &bufferedStream{records: result.Records}, nil
+	}
+
+	query, params, err := r.rewrite(query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+	records, err := executeStream(ctx, streamer, query, params, r.callConfig(options))
+	if err != nil {
+		return nil, annotateHintError(err, options.indexHints)
+	}
+	return records, nil
+}
+
+// rewrite applies the library's own rewriters, then the repository's, then the per-call ones.
+func (r *Repository[T]) rewrite(query string, params map[string]interface{}, options *queryOptions) (string, map[string]interface{}, error) {
+	var rewriters []QueryRewriter
+	if len(options.indexHints) > 0 {
+		rewriters = append(rewriters, &indexHintRewriter{hints: options.indexHints})
+	}
+	if options.distanceOrder != nil {
+		if err := r.requirePointProperty(options.distanceOrder.propName); err != nil {
+			return "", nil, err
+		}
+		rewriters = append(rewriters, &distanceOrderRewriter{order: options.distanceOrder})
+	}
+	rewriters = append(rewriters, r.config.rewriters...)
+	rewriters = append(rewriters, options.rewriters...)
+	if options.resultCap > 0 {
+		rewriters = append(rewriters, &resultCapRewriter{limit: options.resultCap})
+	}
+	return applyRewriters(query, params, rewriters)
+}
+
+// runnerWith returns a DBRunner that routes queries through run with the given options,
+// for helpers that accept a DBRunner.
+func (r *Repository[T]) runnerWith(options *queryOptions) DBRunner {
+	return &repositoryRunner[T]{repo: r, options: options}
+}
+
+// repositoryRunner is the DBRunner returned by runnerWith. It forwards feature checks to
+// the repository's runner, so that helpers given it can gate version-dependent statements.
+type repositoryRunner[T any] struct {
+	repo    *Repository[T]
+	options *queryOptions
+}
+
+// Run executes the query through the repository's run.
+func (rr *repositoryRunner[T]) Run(ctx context.Context, query string, params map[string]interface{}) (*ResultSet, error) {
+	return rr.repo.run(ctx, query, params, rr.options)
+}
+
+// RequireFeature checks the feature against the repository's runner.
+func (rr *repositoryRunner[T]) RequireFeature(ctx context.Context, f features.Feature) error {
+	return requireFeature(ctx, rr.repo.runner, f)
 }
@@ -0,0 +1,167 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The types in this file decouple the package's API from the Neo4j driver. Runners
+// convert driver results into them (see Neo4jExecutor and FromV5Runner), so that the rest
+// of the package, and code built on it, never handles driver types directly.
+
+// ResultSet is a fully buffered query result.
+type ResultSet struct {
+	// Keys are the names of the returned columns, in order.
+	Keys []string
+	// Records are the returned rows.
+	Records []*Record
+	// Counters report the updates made by the query.
+	Counters Counters
+}
+
+// Record is a single row of a ResultSet. Values are plain Go values, or Node,
+// Relationship, Path, Point and Point3D for graph and spatial values, and time.Time,
+// LocalDateTime, LocalDate, LocalTime and Duration for temporal values.
+type Record struct {
+	// Keys are the column names, shared with the ResultSet.
+	Keys []string
+	// Values are the column values, in the same order as Keys.
+	Values []any
+}
+
+// NewRecord creates a Record from alternating keys and values, which is convenient when
+// faking results in tests:
+//
+//	neopersist.NewRecord("n", node, "count", int64(3))
+func NewRecord(keysAndValues ...any) *Record {
+	record := &Record{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, _ := keysAndValues[i].(string)
+		record.Keys = append(record.Keys, key)
+		record.Values = append(record.Values, keysAndValues[i+1])
+	}
+	return record
+}
+
+// Get returns the value of the column with the given key, and whether it exists.
+func (r *Record) Get(key string) (any, bool) {
+	for i, k := range r.Keys {
+		if k == key {
+			return r.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// Counters report the updates made by a query, as returned in the result summary.
+type Counters struct {
+	NodesCreated         int64
+	NodesDeleted         int64
+	RelationshipsCreated int64
+	RelationshipsDeleted int64
+	PropertiesSet        int64
+	LabelsAdded          int64
+	LabelsRemoved        int64
+	ConstraintsAdded     int64
+	ConstraintsRemoved   int64
+	IndexesAdded         int64
+	IndexesRemoved       int64
+}
+
+// Node is a graph node returned by a query.
+type Node struct {
+	ElementID string
+	Labels    []string
+	Props     map[string]any
+}
+
+// Relationship is a graph relationship returned by a query.
+type Relationship struct {
+	ElementID      string
+	StartElementID string
+	EndElementID   string
+	Type           string
+	Props          map[string]any
+}
+
+// Path is a graph path returned by a query. Segment i goes from Nodes[i] to Nodes[i+1]
+// through Relationships[i], which may be stored in either direction.
+type Path struct {
+	Nodes         []Node
+	Relationships []Relationship
+}
+
+// DBError is an error reported by the database server, such as a constraint violation.
+// Runners return it wrapped around the driver's own error, which stays reachable through
+// errors.As.
+type DBError struct {
+	// Code is the Neo4j status code (e.g., "Neo.ClientError.Schema.ConstraintValidationFailed").
+	Code string
+	// Message is the server's description of the error.
+	Message string
+	// cause is the driver error this one was converted from.
+	cause error
+}
+
+// Error implements the error interface.
+func (e *DBError) Error() string {
+	return fmt.Sprintf("Neo4jError: %s (%s)", e.Code, e.Message)
+}
+
+// Unwrap returns the driver error this one was converted from, if any.
+func (e *DBError) Unwrap() error {
+	return e.cause
+}
+
+// dbErrorCode returns the server status code carried by err, or "" if there is none.
+func dbErrorCode(err error) string {
+	var dbErr *DBError
+	if errors.As(err, &dbErr) {
+		return dbErr.Code
+	}
+	return ""
+}
+
+// RecordStream is a cursor over the records of a query that is still running, returned by
+// StreamRunner.Stream. It must be closed once the caller is done with it.
+type RecordStream interface {
+	// Next returns the next record, or io.EOF once the result is exhausted.
+	Next(ctx context.Context) (*Record, error)
+	// Close releases the resources held by the stream, such as the database session.
+	Close(ctx context.Context) error
+}
+
+// StreamRunner is implemented by runners that can return records one by one instead of
+// buffering the whole result, such as Neo4jExecutor. Streaming finders like FindAllIter
+// use it when available and fall back to a buffered Run otherwise.
+type StreamRunner interface {
+	DBRunner
+	// Stream executes a Cypher query and returns a cursor over its records.
+	Stream(ctx context.Context, query string, params map[string]interface{}) (RecordStream, error)
+}
+
+// bufferedStream is a RecordStream over an already buffered ResultSet.
+type bufferedStream struct {
+	records []*Record
+}
+
+// Next returns the next buffered record, or io.EOF.
+func (s *bufferedStream) Next(ctx context.Context) (*Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.records) == 0 {
+		return nil, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+// Close discards the remaining records.
+func (s *bufferedStream) Close(context.Context) error {
+	s.records = nil
+	return nil
+}
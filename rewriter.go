@@ -0,0 +1,52 @@
+package neopersist
+
+import (
+	"fmt"
+)
+
+// QueryRewriter post-processes a built query before it is sent to the database. It is the
+// sanctioned extension point for Cypher that gocypher cannot express (planner hints,
+// subqueries, etc.): the library's own advanced features are implemented as rewriters,
+// and user-supplied rewriters provide an escape hatch without abandoning the repository API.
+//
+// Rewriters may return the given params map modified in place or a new map.
+type QueryRewriter interface {
+	Rewrite(query string, params map[string]any) (string, map[string]any, error)
+}
+
+// QueryRewriterFunc is an adapter that allows an ordinary function to be used as a QueryRewriter.
+type QueryRewriterFunc func(query string, params map[string]any) (string, map[string]any, error)
+
+// Rewrite calls f(query, params).
+func (f QueryRewriterFunc) Rewrite(query string, params map[string]any) (string, map[string]any, error) {
+	return f(query, params)
+}
+
+// RewriteQuery returns a QueryOption that applies rw to the query of a single call, after
+// the library's own rewriters and any rewriters configured on the repository.
+func RewriteQuery(rw QueryRewriter) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optRewrite
+		o.rewriters = append(o.rewriters, rw)
+	}
+}
+
+// WithQueryRewriter returns a RepositoryOption that applies rw to every query issued by
+// the repository, before any per-call rewriters.
+func WithQueryRewriter(rw QueryRewriter) RepositoryOption {
+	return func(r *repositoryConfig) {
+		r.rewriters = append(r.rewriters, rw)
+	}
+}
+
+// applyRewriters runs the rewriters in order, feeding each the output of the previous one.
+func applyRewriters(query string, params map[string]any, rewriters []QueryRewriter) (string, map[string]any, error) {
+	for _, rw := range rewriters {
+		var err error
+		query, params, err = rw.Rewrite(query, params)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not rewrite query: %w", err)
+		}
+	}
+	return query, params, nil
+}
@@ -0,0 +1,106 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+)
+
+// WithSeed returns a QueryOption under which FindRandom draws a reproducible sample: the
+// same seed over the same data always yields the same entities, in the same order, which
+// keeps tests and demo fixtures stable.
+func WithSeed(seed int64) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optSeed
+		o.seed = &seed
+	}
+}
+
+// FindRandom returns up to n entities of type T picked at random, for spot checks of
+// data quality or for seeding demos. If the label has fewer than n nodes, all of them
+// are returned, in random order.
+//
+// By default the sample is drawn by the database and differs on every call. With
+// WithSeed, the primary keys are read in order and the sample is drawn from them with
+// the seed, so it is reproducible; this reads every key of the label and is meant for
+// tests rather than large production labels.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - n: The maximum number of entities to return; must be positive.
+//   - opts: Optional per-call settings, such as WithSeed or CollectMappingErrors.
+//
+// Returns:
+//
+//	The sampled entities, or an error if n is not positive or a query fails.
+func (r *Repository[T]) FindRandom(ctx context.Context, n int64, opts ...FindOption) ([]*T, error) {
+	options, err := parseOptions("FindRandom", opts, pageOptions|optSeed)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("sample size must be positive, got %d", n)
+	}
+	if options.seed != nil {
+		return r.findSeededSample(ctx, n, *options.seed, options)
+	}
+
+	// gocypher has no WITH, ORDER BY or LIMIT, so the query is rendered here.
+	query := fmt.Sprintf("MATCH (n:%s)\nWITH n, rand() AS r\nORDER BY r\nLIMIT $sampleSize\nRETURN n", r.meta.Label)
+	eagerResult, err := r.run(ctx, query, map[string]interface{}{"sampleSize": n}, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// findSeededSample draws the primary keys of a FindRandom sample with a seeded generator,
+// then loads the sampled entities and returns them in the order they were drawn.
+func (r *Repository[T]) findSeededSample(ctx context.Context, n, seed int64, options *queryOptions) ([]*T, error) {
+	keysQuery := fmt.Sprintf("MATCH (n:%s)\nRETURN n.%s AS pk\nORDER BY pk", r.meta.Label, r.meta.PKProp)
+	keysResult, err := r.run(ctx, keysQuery, map[string]interface{}{}, options)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]interface{}, len(keysResult.Records))
+	for i, record := range keysResult.Records {
+		keys[i], _ = record.Get("pk")
+	}
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	if int64(len(keys)) > n {
+		keys = keys[:n]
+	}
+	if len(keys) == 0 {
+		return []*T{}, nil
+	}
+
+	query := fmt.Sprintf("MATCH (n:%s)\nWHERE n.%s IN $ids\nRETURN n", r.meta.Label, r.meta.PKProp)
+	eagerResult, err := r.run(ctx, query, map[string]interface{}{"ids": keys}, options)
+	if err != nil {
+		return nil, err
+	}
+	// Restore the drawn order, which the IN lookup does not preserve.
+	position := make(map[interface{}]int, len(keys))
+	for i, key := range keys {
+		position[key] = i
+	}
+	records := make([]*Record, len(keys))
+	for _, record := range eagerResult.Records {
+		value, _ := record.Get("n")
+		node, ok := value.(Node)
+		if !ok {
+			continue
+		}
+		if i, ok := position[node.Props[r.meta.PKProp]]; ok {
+			records[i] = record
+		}
+	}
+	ordered := records[:0]
+	for _, record := range records {
+		if record != nil {
+			ordered = append(ordered, record)
+		}
+	}
+	return r.mapRecords(ctx, ordered, options)
+}
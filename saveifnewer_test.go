@@ -0,0 +1,56 @@
+package neopersist_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Reading is an entity versioned by a nillable timestamp.
+type Reading struct {
+	SensorID string     `crud:"pk,property:sensorId"`
+	Value    float64    `crud:"property:value"`
+	TakenAt  *time.Time `crud:"property:takenAt"`
+}
+
+func TestSaveIfNewerLocksTheNodeBeforeComparing(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return recordSet(neopersist.NewRecord("applied", false)), nil
+	}
+	repo, err := neopersist.NewRepository[Reading](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	takenAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	applied, err := repo.SaveIfNewer(context.Background(), &Reading{SensorID: "s1", Value: 21.5, TakenAt: &takenAt}, "TakenAt")
+	if err != nil {
+		t.Fatalf("SaveIfNewer: %v", err)
+	}
+	if applied {
+		t.Error("applied = true, want the stored timestamp to win")
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MERGE (n:Reading {sensorId: $pk}) SET n.sensorId = n.sensorId " +
+			"WITH n, (n.takenAt IS NULL OR n.takenAt < $ts) AS applied " +
+			"FOREACH (_ IN CASE WHEN applied THEN [1] ELSE [] END | SET n += $props) RETURN applied",
+		Params: map[string]any{"pk": "s1"},
+	})
+}
+
+func TestSaveIfNewerRejectsANilTimestamp(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Reading](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	if _, err := repo.SaveIfNewer(context.Background(), &Reading{SensorID: "s1", Value: 21.5}, "TakenAt"); err == nil {
+		t.Fatal("SaveIfNewer accepted a nil timestamp")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
@@ -0,0 +1,239 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// Severity classifies a ValidationFinding.
+type Severity string
+
+const (
+	// SeverityInfo findings are informational and need no action.
+	SeverityInfo Severity = "info"
+	// SeverityWarning findings point at likely drift that does not break the repository,
+	// such as a mapped property that no sampled node carries.
+	SeverityWarning Severity = "warning"
+	// SeverityError findings break the repository's assumptions, such as a primary key
+	// without a uniqueness constraint.
+	SeverityError Severity = "error"
+)
+
+// schemaSampleSize is the number of nodes sampled when checking which properties exist.
+const schemaSampleSize = 1000
+
+// ValidationFinding is a single result of ValidateAgainstDatabase.
+type ValidationFinding struct {
+	Severity Severity
+	// Label is the entity label the finding is about.
+	Label string
+	// Property is the property the finding is about, or empty for label-level findings.
+	Property string
+	// Message describes the finding.
+	Message string
+}
+
+// String formats the finding as "severity: Label.property: message".
+func (f ValidationFinding) String() string {
+	subject := f.Label
+	if f.Property != "" {
+		subject += "." + f.Property
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Severity, subject, f.Message)
+}
+
+// ValidationReport collects the findings of ValidateAgainstDatabase.
+type ValidationReport struct {
+	Findings []ValidationFinding
+}
+
+// HasErrors reports whether any finding has SeverityError.
+func (r *ValidationReport) HasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAgainstDatabase checks that the repository's expectations hold in the connected
+// database, so that drift can be caught, for example in CI against a staging database,
+// before deploying. It verifies that:
+//   - the label exists (error otherwise);
+//   - the primary key has a uniqueness or node key constraint (error otherwise);
+//   - each mapped property, and each property of n used by the named queries registered
+//     with WithNamedQuery, is present on at least one of a sample of nodes (warning
+//     otherwise, since sparse properties can legitimately be absent).
+//
+// The check only runs read-only queries (SHOW CONSTRAINTS, db.labels() and a sampling
+// MATCH). Findings are reported in the returned ValidationReport; the error is only set
+// when a query fails. Optional FindOption values, such as OnDatabase or WithTimeout, apply
+// to every query of the check.
+func (r *Repository[T]) ValidateAgainstDatabase(ctx context.Context, opts ...FindOption) (*ValidationReport, error) {
+	options, err := parseOptions("ValidateAgainstDatabase", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	report := &ValidationReport{}
+	if err := validateEntityAgainstDatabase(ctx, r.runnerWith(options), r.meta, r.config.namedQueries, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ValidateAgainstDatabase runs Repository.ValidateAgainstDatabase for each given entity
+// type and aggregates the findings into a single report.
+//
+// Example:
+//
+//	report, err := manager.ValidateAgainstDatabase(ctx, []any{models.User{}, models.Post{}})
+//	if err == nil && report.HasErrors() {
+//	    log.Fatal(report.Findings)
+//	}
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entityTypes: Values of, or pointers to, the entity structs to validate.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The aggregated report, or an error if a type has invalid tags or a query fails.
+func (pm *PersistenceManager) ValidateAgainstDatabase(ctx context.Context, entityTypes []any, opts ...FindOption) (*ValidationReport, error) {
+	options, err := parseOptions("ValidateAgainstDatabase", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	report := &ValidationReport{}
+	for _, entityType := range entityTypes {
+		meta, err := pm.metadataFor(reflect.TypeOf(entityType))
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEntityAgainstDatabase(ctx, pm.runnerWith(options), meta, nil, report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// validateEntityAgainstDatabase appends the findings for one entity, and for the properties
+// its named queries use, to report.
+func validateEntityAgainstDatabase(ctx context.Context, runner DBRunner, meta *entityMetadata, namedQueries map[string]string, report *ValidationReport) error {
+	add := func(severity Severity, property, format string, args ...any) {
+		report.Findings = append(report.Findings, ValidationFinding{
+			Severity: severity,
+			Label:    meta.Label,
+			Property: property,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// 1. The label must exist at all.
+	labels, err := runner.Run(ctx, "CALL db.labels() YIELD label WHERE label = $label RETURN label", map[string]interface{}{"label": meta.Label})
+	if err != nil {
+		return fmt.Errorf("could not list labels: %w", err)
+	}
+	if len(labels.Records) == 0 {
+		add(SeverityError, "", "label does not exist in the database")
+	}
+
+	// 2. The primary key must be backed by a uniqueness constraint.
+	if err := requireFeature(ctx, runner, features.SchemaCommands); err != nil {
+		return err
+	}
+	constraints, err := runner.Run(ctx, "SHOW CONSTRAINTS YIELD name, type, labelsOrTypes, properties", nil)
+	if err != nil {
+		return fmt.Errorf("could not list constraints: %w", err)
+	}
+	pkConstraint := ""
+	for _, record := range constraints.Records {
+		name, _ := record.Get("name")
+		constraintType, _ := record.Get("type")
+		labelsOrTypes, _ := record.Get("labelsOrTypes")
+		properties, _ := record.Get("properties")
+		typeStr, _ := constraintType.(string)
+		if !strings.Contains(typeStr, "UNIQUENESS") && !strings.Contains(typeStr, "NODE_KEY") {
+			continue
+		}
+		if isSingleString(labelsOrTypes, meta.Label) && isSingleString(properties, meta.PKProp) {
+			pkConstraint, _ = name.(string)
+			break
+		}
+	}
+	if pkConstraint == "" {
+		add(SeverityError, meta.PKProp, "primary key has no uniqueness constraint")
+	} else {
+		add(SeverityInfo, meta.PKProp, "primary key is backed by constraint %s", pkConstraint)
+	}
+
+	// 3. Mapped properties should exist on at least some nodes. Only a sample is inspected
+	// to keep the check cheap on large labels.
+	if len(labels.Records) == 0 {
+		return nil
+	}
+	sampleQuery := fmt.Sprintf("MATCH (n:%s)\nWITH n LIMIT $sampleSize\nUNWIND keys(n) AS key\nRETURN DISTINCT key", meta.Label)
+	sample, err := runner.Run(ctx, sampleQuery, map[string]interface{}{"sampleSize": schemaSampleSize})
+	if err != nil {
+		return fmt.Errorf("could not sample %s nodes: %w", meta.Label, err)
+	}
+	present := make(map[string]bool, len(sample.Records))
+	for _, record := range sample.Records {
+		key, _ := record.Get("key")
+		if k, ok := key.(string); ok {
+			present[k] = true
+		}
+	}
+	queryProps := namedQueryProperties(namedQueries)
+	fieldNames := make([]string, 0, len(meta.Mappings))
+	for fieldName := range meta.Mappings {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	mapped := make(map[string]bool, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		propName := meta.Mappings[fieldName]
+		mapped[propName] = true
+		if present[propName] {
+			continue
+		}
+		found := false
+		for _, alias := range meta.Aliases[fieldName] {
+			found = found || present[alias]
+		}
+		if found {
+			add(SeverityWarning, propName, "property is only present under a legacy alias in the sampled nodes%s; consider BackfillAliases", usedBy(queryProps[propName]))
+			continue
+		}
+		add(SeverityWarning, propName, "property was not found on any of the %d sampled nodes%s", schemaSampleSize, usedBy(queryProps[propName]))
+	}
+
+	// Named queries may also use properties that no field maps.
+	unmapped := make([]string, 0, len(queryProps))
+	for propName := range queryProps {
+		if !mapped[propName] && !present[propName] {
+			unmapped = append(unmapped, propName)
+		}
+	}
+	sort.Strings(unmapped)
+	for _, propName := range unmapped {
+		add(SeverityWarning, propName, "unmapped property was not found on any of the %d sampled nodes%s", schemaSampleSize, usedBy(queryProps[propName]))
+	}
+	return nil
+}
+
+// isSingleString reports whether value is a list holding exactly the string want.
+func isSingleString(value any, want string) bool {
+	list, ok := value.([]interface{})
+	if !ok || len(list) != 1 {
+		return false
+	}
+	s, _ := list[0].(string)
+	return s == want
+}
@@ -0,0 +1,140 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// liveSchema answers the queries of ValidateAgainstDatabase for a User label with a
+// primary key constraint whose sampled nodes carry the given properties.
+func liveSchema(keys ...string) func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+	return func(_ int, query string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		result := &neopersist.ResultSet{}
+		switch {
+		case strings.HasPrefix(query, "CALL db.labels()"):
+			result.Records = append(result.Records, neopersist.NewRecord("label", "User"))
+		case strings.HasPrefix(query, "SHOW CONSTRAINTS"):
+			result.Records = append(result.Records, neopersist.NewRecord(
+				"name", "User_userId_unique", "type", "UNIQUENESS",
+				"labelsOrTypes", []interface{}{"User"}, "properties", []interface{}{"userId"}))
+		default:
+			for _, key := range keys {
+				result.Records = append(result.Records, neopersist.NewRecord("key", key))
+			}
+		}
+		return result, nil
+	}
+}
+
+// warnings returns the warning findings of report, keyed by property.
+func warnings(report *neopersist.ValidationReport) map[string]string {
+	found := make(map[string]string)
+	for _, finding := range report.Findings {
+		if finding.Severity == neopersist.SeverityWarning {
+			found[finding.Property] = finding.Message
+		}
+	}
+	return found
+}
+
+func TestValidateAgainstDatabaseChecksNamedQueryProperties(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = liveSchema("userId", "name", "email", "age", "nickname")
+	repo, err := neopersist.NewRepository[User](runner,
+		neopersist.WithNamedQuery("byNickname", "MATCH (n:User) WHERE n.nickname = $nick RETURN n"),
+		neopersist.WithNamedQuery("inactive", "MATCH (n:User) WHERE n.lastLogin < $before AND n.`legacy flag` RETURN n"),
+		neopersist.WithNamedQuery("stale", "MATCH (n:User) WHERE n.lastLogin IS NULL RETURN n"),
+	)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	report, err := repo.ValidateAgainstDatabase(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateAgainstDatabase: %v", err)
+	}
+	got := warnings(report)
+	want := map[string]string{
+		"lastLogin":   "unmapped property was not found on any of the 1000 sampled nodes (used by named queries: inactive, stale)",
+		"legacy flag": "unmapped property was not found on any of the 1000 sampled nodes (used by named queries: inactive)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("warnings = %v, want %v", got, want)
+	}
+	for prop, message := range want {
+		if got[prop] != message {
+			t.Errorf("warning on %s = %q, want %q", prop, got[prop], message)
+		}
+	}
+	if report.HasErrors() {
+		t.Errorf("unexpected errors: %v", report.Findings)
+	}
+	for _, call := range runner.Calls() {
+		if writes(call.Query) {
+			t.Errorf("validation sent a write query: %q", call.Query)
+		}
+	}
+}
+
+func TestValidateAgainstDatabaseNamesQueriesUsingMissingMappedProperties(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = liveSchema("userId", "name", "age")
+	repo, err := neopersist.NewRepository[User](runner,
+		neopersist.WithNamedQuery("byEmail", "MATCH (n:User {userId: $id}) WHERE n.email = $email RETURN n"))
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	report, err := repo.ValidateAgainstDatabase(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateAgainstDatabase: %v", err)
+	}
+	got := warnings(report)
+	if len(got) != 1 || got["email"] != "property was not found on any of the 1000 sampled nodes (used by named queries: byEmail)" {
+		t.Errorf("warnings = %v, want only email, naming byEmail", got)
+	}
+}
+
+func TestFindNamedRunsTheRegisteredStatement(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(userNode("u1", "Ada")), nil
+	}
+	repo, err := neopersist.NewRepository[User](runner,
+		neopersist.WithNamedQuery("byName", "MATCH (n:User) WHERE n.name = $name RETURN n"))
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	users, err := repo.FindNamed(context.Background(), "byName", map[string]any{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("FindNamed: %v", err)
+	}
+	if len(users) != 1 || users[0].Name != "Ada" {
+		t.Errorf("users = %v, want Ada", users)
+	}
+	neopersisttest.AssertCalls(t, runner, neopersisttest.Expect{
+		Query:  "MATCH (n:User) WHERE n.name = $name RETURN n",
+		Params: map[string]any{"name": "Ada"},
+	})
+
+	if _, err := repo.FindNamed(context.Background(), "missing", nil); err == nil {
+		t.Error("FindNamed accepted an unregistered name")
+	}
+}
+
+// writes reports whether a query contains a clause writing to the graph.
+func writes(query string) bool {
+	for _, line := range strings.Split(query, "\n") {
+		for _, clause := range []string{"CREATE ", "MERGE ", "SET ", "DELETE ", "DETACH DELETE ", "REMOVE "} {
+			if strings.HasPrefix(strings.TrimSpace(line), clause) {
+				return true
+			}
+		}
+	}
+	return false
+}
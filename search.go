@@ -0,0 +1,88 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// FindByPropertyContains retrieves all entities whose string property contains the given
+// substring, such as users whose email contains "@example.com".
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a property mapped to a string field (e.g., "email").
+//   - substr: The substring to search for; it is passed as a parameter.
+//   - caseInsensitive: Whether to compare both sides in lower case.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	A slice of pointers to the matching entities, or an error if the property is not
+//	mapped to a string field or the query fails.
+func (r *Repository[T]) FindByPropertyContains(ctx context.Context, propName string, substr string, caseInsensitive bool, opts ...FindOption) ([]*T, error) {
+	return r.findByStringOperator(ctx, "FindByPropertyContains", "CONTAINS", propName, substr, caseInsensitive, opts)
+}
+
+// FindByPropertyStartsWith retrieves all entities whose string property starts with the
+// given prefix, which is the query behind autocompletion. A case-sensitive prefix search
+// can use a range index on the property; a case-insensitive one cannot.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a property mapped to a string field (e.g., "name").
+//   - prefix: The prefix to search for; it is passed as a parameter.
+//   - caseInsensitive: Whether to compare both sides in lower case.
+//   - opts: Optional per-call settings, such as UseIndex or CollectMappingErrors.
+//
+// Returns:
+//
+//	A slice of pointers to the matching entities, or an error if the property is not
+//	mapped to a string field or the query fails.
+func (r *Repository[T]) FindByPropertyStartsWith(ctx context.Context, propName string, prefix string, caseInsensitive bool, opts ...FindOption) ([]*T, error) {
+	return r.findByStringOperator(ctx, "FindByPropertyStartsWith", "STARTS WITH", propName, prefix, caseInsensitive, opts)
+}
+
+// findByStringOperator renders `MATCH (n:Label) WHERE n.prop <operator> $search RETURN n`,
+// lower-casing both operands when caseInsensitive is set.
+func (r *Repository[T]) findByStringOperator(ctx context.Context, operation, operator, propName, search string, caseInsensitive bool, opts []FindOption) ([]*T, error) {
+	options, err := r.parseListOptions(operation, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.requireStringProperty(propName); err != nil {
+		return nil, err
+	}
+
+	// gocypher has no WHERE, so the predicate is rendered here. The property name comes
+	// from the validated mappings; the search text is always a parameter.
+	subject, value := "n."+propName, "$search"
+	if caseInsensitive {
+		subject, value = "toLower("+subject+")", "toLower("+value+")"
+	}
+	query := fmt.Sprintf("MATCH (n:%s)\nWHERE %s %s %s\nRETURN n", r.meta.Label, subject, operator, value)
+	params := map[string]interface{}{"search": search}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// requireStringProperty checks that propName is mapped to a string field of the entity.
+func (r *Repository[T]) requireStringProperty(propName string) error {
+	if err := r.requireMappedProperty(propName); err != nil {
+		return err
+	}
+	fieldName, _ := r.meta.fieldForProperty(propName)
+	fieldType := r.meta.Types[fieldName]
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.String {
+		return fmt.Errorf("property '%s' of entity type %s is mapped to field %s of type %s, not a string",
+			propName, r.meta.Label, fieldName, r.meta.Types[fieldName])
+	}
+	return nil
+}
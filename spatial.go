@@ -0,0 +1,270 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+const (
+	// SRIDWGS84 is the spatial reference ID of geographic WGS-84 points (longitude/latitude).
+	SRIDWGS84 uint32 = 4326
+	// SRIDCartesian is the spatial reference ID of 2D cartesian points.
+	SRIDCartesian uint32 = 7203
+	// SRIDWGS843D is the spatial reference ID of 3D geographic WGS-84 points (longitude,
+	// latitude, height).
+	SRIDWGS843D uint32 = 4979
+	// SRIDCartesian3D is the spatial reference ID of 3D cartesian points.
+	SRIDCartesian3D uint32 = 9157
+)
+
+// Point is a 2D spatial value stored as a Neo4j point property, from Point and *Point
+// fields. For geographic points (SRID 4326) X is the longitude and Y the latitude, in
+// degrees; for cartesian points (SRID 7203) they are plain coordinates. The SRID is never
+// inferred: points are built with GeoPoint or CartesianPoint, or with an explicit SRID,
+// and saving a point with any other SRID fails before reaching the database.
+type Point struct {
+	X    float64
+	Y    float64
+	SRID uint32
+}
+
+// GeoPoint returns a WGS-84 geographic point for the given latitude and longitude.
+func GeoPoint(latitude, longitude float64) Point {
+	return Point{X: longitude, Y: latitude, SRID: SRIDWGS84}
+}
+
+// CartesianPoint returns a 2D cartesian point.
+func CartesianPoint(x, y float64) Point {
+	return Point{X: x, Y: y, SRID: SRIDCartesian}
+}
+
+// Point3D is a 3D spatial value stored as a Neo4j point property, from Point3D and
+// *Point3D fields. For geographic points (SRID 4979) X is the longitude and Y the latitude,
+// in degrees, and Z the height in meters; for cartesian points (SRID 9157) they are plain
+// coordinates. As with Point, the SRID is never inferred.
+type Point3D struct {
+	X    float64
+	Y    float64
+	Z    float64
+	SRID uint32
+}
+
+// GeoPoint3D returns a WGS-84 geographic point for the given latitude, longitude and
+// height.
+func GeoPoint3D(latitude, longitude, height float64) Point3D {
+	return Point3D{X: longitude, Y: latitude, Z: height, SRID: SRIDWGS843D}
+}
+
+// CartesianPoint3D returns a 3D cartesian point.
+func CartesianPoint3D(x, y, z float64) Point3D {
+	return Point3D{X: x, Y: y, Z: z, SRID: SRIDCartesian3D}
+}
+
+// checkSRID reports an error if srid is not one of the supported 2D SRIDs.
+func checkSRID(srid uint32) error {
+	if srid != SRIDWGS84 && srid != SRIDCartesian {
+		return fmt.Errorf("unsupported SRID %d (must be %d for geographic or %d for cartesian points)", srid, SRIDWGS84, SRIDCartesian)
+	}
+	return nil
+}
+
+// checkSRID3D reports an error if srid is not one of the supported 3D SRIDs.
+func checkSRID3D(srid uint32) error {
+	if srid != SRIDWGS843D && srid != SRIDCartesian3D {
+		return fmt.Errorf("unsupported SRID %d (must be %d for geographic or %d for cartesian 3D points)", srid, SRIDWGS843D, SRIDCartesian3D)
+	}
+	return nil
+}
+
+// checkPointValues checks the SRID of the entity's Point, Point3D and non-nil pointer
+// point fields before they are saved. Zero points of `omitempty` fields are skipped, since
+// they are not written.
+func (m *entityMetadata) checkPointValues(val reflect.Value) error {
+	for fieldName, typ := range m.Types {
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if typ != pointType && typ != point3DType {
+			continue
+		}
+		field := m.field(val, fieldName)
+		if !field.IsValid() || isNilValue(field.Interface()) || (m.OmitEmpty[fieldName] && field.IsZero()) {
+			continue
+		}
+		var err error
+		switch point := reflect.Indirect(field).Interface().(type) {
+		case Point:
+			err = checkSRID(point.SRID)
+		case Point3D:
+			err = checkSRID3D(point.SRID)
+		}
+		if err != nil {
+			return fmt.Errorf("field %s of entity type %s: %w", fieldName, m.Label, err)
+		}
+	}
+	return nil
+}
+
+var (
+	// pointType is the reflect.Type of Point, used to validate point-typed fields.
+	pointType = reflect.TypeOf(Point{})
+	// point3DType is the reflect.Type of Point3D.
+	point3DType = reflect.TypeOf(Point3D{})
+)
+
+// distanceOrder is the sort requested with OrderByDistance.
+type distanceOrder struct {
+	propName string
+	from     Point
+}
+
+// OrderByDistance returns a QueryOption that sorts the results of FindAll, FindByProperty,
+// FindWithinBox or FindWithinDistance by increasing distance between the given point
+// property and from, compiling to `ORDER BY point.distance(n.prop, $from)`. The property
+// must be mapped to a field of type Point or *Point. Nodes without the property are sorted
+// last.
+func OrderByDistance(propName string, from Point) QueryOption {
+	return func(o *queryOptions) {
+		o.used |= optDistanceOrder
+		o.distanceOrder = &distanceOrder{propName: propName, from: from}
+	}
+}
+
+// distanceOrderRewriter appends the ORDER BY clause requested with OrderByDistance to a
+// query ending in `RETURN n`.
+type distanceOrderRewriter struct {
+	order *distanceOrder
+}
+
+// Rewrite implements QueryRewriter.
+func (rw *distanceOrderRewriter) Rewrite(query string, params map[string]any) (string, map[string]any, error) {
+	if params == nil {
+		params = make(map[string]any)
+	}
+	params["distanceFrom"] = rw.order.from
+	query = fmt.Sprintf("%s\nORDER BY point.distance(n.%s, $distanceFrom)", query, rw.order.propName)
+	return query, params, nil
+}
+
+// requirePointProperty checks that propName is mapped to a Point or *Point field of the
+// entity.
+func (r *Repository[T]) requirePointProperty(propName string) error {
+	if err := r.requireMappedProperty(propName); err != nil {
+		return err
+	}
+	fieldName, _ := r.meta.fieldForProperty(propName)
+	if typ := r.meta.Types[fieldName]; typ != pointType && typ != reflect.PointerTo(pointType) {
+		return fmt.Errorf("property '%s' of entity type %s is mapped to field %s of type %s, not a Point",
+			propName, r.meta.Label, fieldName, r.meta.Types[fieldName])
+	}
+	return nil
+}
+
+// FindWithinBox retrieves all entities whose point property lies inside the rectangle
+// delimited by the south-west and north-east corners, edges included. This is the query
+// behind map viewports. Combine it with OrderByDistance to sort the matches.
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a point property mapped to a Point field.
+//   - southWest: The corner with the smallest X (longitude) and Y (latitude).
+//   - northEast: The corner with the largest X (longitude) and Y (latitude).
+//   - opts: Optional per-call settings, such as OrderByDistance.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities, or an error if the property is not
+//	point-typed, the corners use different SRIDs, or the query fails.
+func (r *Repository[T]) FindWithinBox(ctx context.Context, propName string, southWest, northEast Point, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("FindWithinBox", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.requirePointProperty(propName); err != nil {
+		return nil, err
+	}
+	if southWest.SRID != northEast.SRID {
+		return nil, fmt.Errorf("box corners use different SRIDs (%d and %d)", southWest.SRID, northEast.SRID)
+	}
+
+	query := fmt.Sprintf(
+		"MATCH (n:%s)\n"+
+			"WHERE n.%s.srid = $srid\n"+
+			"AND n.%s.x >= $minX AND n.%s.x <= $maxX\n"+
+			"AND n.%s.y >= $minY AND n.%s.y <= $maxY\n"+
+			"RETURN n",
+		r.meta.Label,
+		propName,
+		propName, propName,
+		propName, propName,
+	)
+	params := map[string]interface{}{
+		"srid": int64(southWest.SRID),
+		"minX": southWest.X,
+		"maxX": northEast.X,
+		"minY": southWest.Y,
+		"maxY": northEast.Y,
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
+
+// FindWithinDistance retrieves all entities whose point property lies within the given
+// distance of center, compiling to `WHERE point.distance(n.prop, $center) <= $distance`.
+// Distances between geographic points (SRID 4326) are in meters; between cartesian points
+// (SRID 7203) they are in the units of the coordinates. Nodes whose point uses another
+// SRID than center never match. Combine it with OrderByDistance to sort the matches.
+//
+// Example:
+//
+//	shops, err := shopRepo.FindWithinDistance(ctx, "location", neopersist.GeoPoint(48.8566, 2.3522), 500,
+//	    neopersist.OrderByDistance("location", neopersist.GeoPoint(48.8566, 2.3522)))
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - propName: The name of a point property mapped to a Point field.
+//   - center: The point distances are measured from; its SRID must be set explicitly.
+//   - meters: The maximum distance, inclusive; must not be negative.
+//   - opts: Optional per-call settings, such as OrderByDistance.
+//
+// Returns:
+//
+//	A slice of pointers to the found entities, or an error if the property is not
+//	point-typed, center has an unsupported SRID, meters is negative or the query fails.
+func (r *Repository[T]) FindWithinDistance(ctx context.Context, propName string, center Point, meters float64, opts ...FindOption) ([]*T, error) {
+	options, err := r.parseListOptions("FindWithinDistance", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.requirePointProperty(propName); err != nil {
+		return nil, err
+	}
+	if err := checkSRID(center.SRID); err != nil {
+		return nil, err
+	}
+	if meters < 0 {
+		return nil, fmt.Errorf("distance must not be negative, got %g", meters)
+	}
+	if err := requireFeature(ctx, r.runner, features.PointDistance); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("MATCH (n:%s)\nWHERE point.distance(n.%s, $center) <= $distance\nRETURN n", r.meta.Label, propName)
+	params := map[string]interface{}{
+		"center":   center,
+		"distance": meters,
+	}
+
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapRecords(ctx, eagerResult.Records, options)
+}
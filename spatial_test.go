@@ -0,0 +1,110 @@
+package neopersist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Shop has a geographic location.
+type Shop struct {
+	ShopID   string            `crud:"pk,property:shopId"`
+	Location *neopersist.Point `crud:"property:location"`
+}
+
+func TestFindWithinBoxRendersInclusiveRangePredicates(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Shop](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	from := neopersist.CartesianPoint(0, 0)
+
+	_, err = repo.FindWithinBox(context.Background(), "location",
+		neopersist.CartesianPoint(1, 2), neopersist.CartesianPoint(3, 4),
+		neopersist.OrderByDistance("location", from))
+	if err != nil {
+		t.Fatalf("FindWithinBox: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (n:Shop) WHERE n.location.srid = $srid " +
+			"AND n.location.x >= $minX AND n.location.x <= $maxX " +
+			"AND n.location.y >= $minY AND n.location.y <= $maxY " +
+			"RETURN n ORDER BY point.distance(n.location, $distanceFrom)",
+		Params: map[string]any{
+			"srid": int64(neopersist.SRIDCartesian), "minX": 1.0, "maxX": 3.0, "minY": 2.0, "maxY": 4.0,
+			"distanceFrom": from,
+		},
+		ExactParams: true,
+	})
+}
+
+func TestSpatialFindersRequireAPointProperty(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	repo, err := neopersist.NewRepository[Shop](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	ctx := context.Background()
+	corner := neopersist.CartesianPoint(0, 0)
+
+	if _, err := repo.FindWithinBox(ctx, "shopId", corner, corner); err == nil {
+		t.Error("FindWithinBox accepted a string property")
+	}
+	if _, err := repo.FindAll(ctx, neopersist.OrderByDistance("shopId", corner)); err == nil {
+		t.Error("OrderByDistance accepted a string property")
+	}
+	if _, err := repo.FindWithinBox(ctx, "location", corner, neopersist.GeoPoint(1, 1)); err == nil {
+		t.Error("FindWithinBox accepted corners with different SRIDs")
+	}
+	if _, err := repo.FindWithinDistance(ctx, "location", corner, -1); err == nil {
+		t.Error("FindWithinDistance accepted a negative distance")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestSpatialFindersIntegration(t *testing.T) {
+	repo := integrationRepo[Shop](t)
+	ctx := context.Background()
+	seed := map[string]neopersist.Point{
+		"origin":    neopersist.CartesianPoint(0, 0),
+		"corner":    neopersist.CartesianPoint(10, 10), // on the north-east corner
+		"edge":      neopersist.CartesianPoint(5, 0),   // on the south edge
+		"inside":    neopersist.CartesianPoint(3, 4),
+		"outside":   neopersist.CartesianPoint(10.5, 5),
+		"negative":  neopersist.CartesianPoint(-0.1, 5),
+		"elsewhere": neopersist.GeoPoint(5, 5), // inside the numbers, but another SRID
+	}
+	for id, point := range seed {
+		if err := repo.Save(ctx, &Shop{ShopID: id, Location: &point}); err != nil {
+			t.Fatalf("Save %s: %v", id, err)
+		}
+	}
+
+	inBox, err := repo.FindWithinBox(ctx, "location", neopersist.CartesianPoint(0, 0), neopersist.CartesianPoint(10, 10),
+		neopersist.OrderByDistance("location", neopersist.CartesianPoint(0, 0)))
+	if err != nil {
+		t.Fatalf("FindWithinBox: %v", err)
+	}
+	want := []string{"origin", "inside", "edge", "corner"}
+	if len(inBox) != len(want) {
+		t.Fatalf("FindWithinBox returned %d shops, want %v", len(inBox), want)
+	}
+	for i, shop := range inBox {
+		if shop.ShopID != want[i] {
+			t.Errorf("shop %d = %s, want %s (in order of distance, edges included)", i, shop.ShopID, want[i])
+		}
+	}
+
+	near, err := repo.FindWithinDistance(ctx, "location", neopersist.CartesianPoint(0, 0), 5,
+		neopersist.OrderByDistance("location", neopersist.CartesianPoint(0, 0)))
+	if err != nil {
+		t.Fatalf("FindWithinDistance: %v", err)
+	}
+	// "inside" and "edge" are exactly 5 away, so the distance bound is inclusive.
+	if len(near) != 3 || near[0].ShopID != "origin" {
+		t.Errorf("FindWithinDistance returned %d shops starting with %v, want origin, inside and edge", len(near), near)
+	}
+}
@@ -1,15 +1,79 @@
 package neopersist
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 )
 
+// timeType is the reflect.Type of time.Time, used to validate timestamp fields.
+var timeType = reflect.TypeOf(time.Time{})
+
+// Labeled is implemented by entities whose node label differs from their struct name:
+//
+//	func (UserAccount) NodeLabel() string { return "User" }
+//
+// The label can also be declared with a blank marker field tagged with the `label:`
+// component. Declaring two different labels is an error.
+//
+//	type UserAccount struct {
+//	    _  struct{} `crud:"label:User"`
+//	    ID string    `crud:"pk,property:id"`
+//	}
+type Labeled interface {
+	NodeLabel() string
+}
+
+// labeledType is the reflect.Type of the Labeled interface.
+var labeledType = reflect.TypeOf((*Labeled)(nil)).Elem()
+
+// ignoreTag is the `crud` tag marking a field as deliberately not persisted, which
+// StrictTags requires on every exported field that is not mapped.
+const ignoreTag = "-"
+
+// ErrInvalidTags is matched, via errors.Is, by the *TagError returned when the `crud` tags
+// of an entity type are malformed.
+var ErrInvalidTags = errors.New("invalid crud tags")
+
+// TagError reports every problem found in the `crud` tags of an entity type, rather than
+// only the first, so that a misconfigured struct can be fixed in one go.
+type TagError struct {
+	// Type is the package-qualified name of the struct type (e.g., "models.User").
+	Type string
+	// Problems are the individual problems, in field declaration order, followed by those
+	// concerning the struct as a whole, such as a missing primary key.
+	Problems []error
+}
+
+// Error implements the error interface.
+func (e *TagError) Error() string {
+	if len(e.Problems) == 1 {
+		return fmt.Sprintf("%s on struct %s: %v", ErrInvalidTags, e.Type, e.Problems[0])
+	}
+	problems := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		problems[i] = problem.Error()
+	}
+	return fmt.Sprintf("%s on struct %s: %d problems: %s", ErrInvalidTags, e.Type, len(e.Problems), strings.Join(problems, "; "))
+}
+
+// Unwrap exposes both ErrInvalidTags and the individual problems to errors.Is and errors.As.
+func (e *TagError) Unwrap() []error {
+	return append([]error{ErrInvalidTags}, e.Problems...)
+}
+
+// stringSliceType is the reflect.Type of []string, used to validate labels fields.
+var stringSliceType = reflect.TypeOf([]string(nil))
+
 // entityMetadata holds the parsed `crud` tag information for a specific struct type.
 // This metadata is cached by the PersistenceManager to avoid costly reflection on every operation.
 type entityMetadata struct {
-	// Label is the graph node label, defaulting to the struct's name.
+	// Label is the graph node label, defaulting to the struct's name; see Labeled.
 	Label string
 	// PKField is the name of the struct field marked as the primary key.
 	PKField string
@@ -17,6 +81,106 @@ type entityMetadata struct {
 	PKProp string
 	// Mappings maps struct field names to their corresponding database property names.
 	Mappings map[string]string
+	// Types maps struct field names to their Go types, for mapped fields only.
+	Types map[string]reflect.Type
+	// Relations maps struct field names to their declared relationships (`rel:` tag component).
+	Relations map[string]*relationMetadata
+	// Aliases maps struct field names to legacy property names (`aliases:` tag component)
+	// that are read, in order, when the primary property is absent from a node.
+	Aliases map[string][]string
+	// ColumnAliases maps struct field names to result column names (`alias:` tag
+	// component) matched exactly when hydrating from projections, such as `displayName`
+	// in `RETURN u.name AS displayName`.
+	ColumnAliases map[string]string
+	// FieldIndex maps the names of tagged struct fields to their index in the struct, so
+	// that hot mapping paths can use reflect.Value.Field instead of FieldByName.
+	FieldIndex map[string]int
+	// FieldPaths maps the names of fields nested in a struct field tagged with `prefix:`,
+	// written with dots (e.g., "Address.City"), to their index path from the entity.
+	FieldPaths map[string][]int
+	// Computed maps struct field names to result column names (`computed:` tag component).
+	// Computed fields are filled from query results only and are never written.
+	Computed map[string]string
+	// OmitEmpty holds the names of the fields tagged with the `omitempty` tag component,
+	// which Save and SaveAll leave untouched on the node while they hold their zero value.
+	OmitEmpty map[string]bool
+	// ElementIDField is the name of the string field tagged with the `elementId` tag
+	// component, filled with the node's element ID when mapping. Empty if there is none.
+	ElementIDField string
+	// LabelsField is the name of the []string field tagged with the `labels` tag component,
+	// filled with the node's labels when mapping. Empty if there is none.
+	LabelsField string
+	// LabelsMode is how Save writes the LabelsField: "" to never write it, "add" to add the
+	// listed labels, or "sync" to also remove the labels not listed.
+	LabelsMode string
+	// UpdatedAtField is the name of the time.Time field marked with the `updatedAt` tag
+	// component, used for change tracking. Empty if the entity does not opt in.
+	UpdatedAtField string
+	// UpdatedAtProp is the property name of the UpdatedAtField in the database.
+	UpdatedAtProp string
+	// ChangeTrackingErr is the error returned by the methods needing an UpdatedAtField,
+	// such as Touch and ChangesSince, recorded when the metadata is parsed. Nil if the
+	// entity declares one.
+	ChangeTrackingErr error
+	// TimeStorage is the Neo4j type times are written as; see WithTimeStorage.
+	TimeStorage TimeStorage
+	// TimeLocation is the location loaded times are converted into, or nil to keep the
+	// stored offset; see WithTimeLocation.
+	TimeLocation *time.Location
+	// Converters maps the names of the fields converted by a PropertyConverter to it.
+	Converters map[string]PropertyConverter
+	// EnumFields holds the names of the fields tagged `enum:string`, which are written as
+	// the result of their String method; see WithEnumParser.
+	EnumFields map[string]bool
+	// Required lists, in declaration order, the fields tagged with the `required` tag
+	// component, which must not hold their zero value when the entity is written.
+	Required []string
+	// Defaults maps the names of the fields tagged `default:` to their parsed default,
+	// written in place of the zero value when a node is created.
+	Defaults map[string]reflect.Value
+	// Unique lists, in declaration order, the fields tagged with the `unique` tag component,
+	// whose properties EnsureConstraints backs with a uniqueness constraint.
+	Unique []string
+	// Indexes lists, in declaration order, the indexes declared with the `index` tag
+	// component, which EnsureIndexes creates.
+	Indexes []*indexMetadata
+	// FullTextIndexes lists, in declaration order, the full-text indexes declared with the
+	// `fulltext:` tag component, which EnsureIndexes creates and Search queries.
+	FullTextIndexes []*indexMetadata
+	// JSONFields holds the names of the fields tagged `json`, which are stored as JSON
+	// strings.
+	JSONFields map[string]bool
+	// Base64Fields holds the names of the []byte fields tagged `base64`, which are stored
+	// as base64 strings.
+	Base64Fields map[string]bool
+	// LenientMatching makes loads fall back to case-insensitive property names; see
+	// LenientPropertyMatching.
+	LenientMatching bool
+	// Vector is the embedding field declared with the `vector:` tag component, or nil;
+	// see SimilaritySearch.
+	Vector *vectorMetadata
+}
+
+// clone returns a copy of the metadata that a repository can apply its settings to
+// without affecting the original, which may be shared through a cache.
+func (m *entityMetadata) clone() *entityMetadata {
+	clone := *m
+	clone.Converters = maps.Clone(m.Converters)
+	return &clone
+}
+
+// relationMetadata holds a relationship declared on a struct field, for example
+// `crud:"rel:WROTE,direction:in"` on a Post.Author field.
+type relationMetadata struct {
+	// Field is the name of the struct field declaring the relationship.
+	Field string
+	// Type is the relationship type in the database (e.g., "WROTE").
+	Type string
+	// Direction is the relationship direction relative to the declaring entity.
+	Direction Direction
+	// TargetType is the struct type of the related entity, resolved from the field's
+	// type by stripping pointers and slices (e.g., *User and []*User both yield User).
+	TargetType reflect.Type
 }
 
 // parseTagsFromType is the core non-generic function that inspects a reflect.Type
@@ -28,52 +192,600 @@ func parseTagsFromType(typ reflect.Type) (*entityMetadata, error) {
 		typ = typ.Elem()
 	}
 	if typ.Kind() != reflect.Struct {
-		return nil, fmt.Errorf("type %s is not a struct", typ.Name())
+		return nil, fmt.Errorf("type %s is not a struct", typ)
 	}
 
 	meta := &entityMetadata{
-		Label:    typ.Name(),
-		Mappings: make(map[string]string),
+		Label:         typ.Name(),
+		Mappings:      make(map[string]string),
+		Types:         make(map[string]reflect.Type),
+		Relations:     make(map[string]*relationMetadata),
+		Aliases:       make(map[string][]string),
+		ColumnAliases: make(map[string]string),
+		Computed:      make(map[string]string),
+		FieldIndex:    make(map[string]int),
+		FieldPaths:    make(map[string][]int),
+		OmitEmpty:     make(map[string]bool),
+		EnumFields:    make(map[string]bool),
+		JSONFields:    make(map[string]bool),
+		Base64Fields:  make(map[string]bool),
+		Defaults:      make(map[string]reflect.Value),
 	}
 
+	labelSource := ""
+	if typ.Implements(labeledType) || reflect.PointerTo(typ).Implements(labeledType) {
+		meta.Label = reflect.New(typ).Interface().(Labeled).NodeLabel()
+		labelSource = "NodeLabel method"
+	}
+
+	// Problems are collected rather than returned one at a time, so that a misconfigured
+	// struct can be fixed in one go.
+	var problems []error
+	pkDeclared := false
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 		tag := field.Tag.Get("crud")
 
-		// Skip fields that are not part of the persistence mapping.
-		if tag == "" {
+		// Skip fields that are not part of the persistence mapping, or explicitly ignored.
+		if tag == "" || tag == ignoreTag {
 			continue
 		}
 
-		parts := strings.Split(tag, ",")
-		isPk := false
-		propName := ""
+		// A blank marker field declares the label of the struct, not a property.
+		if field.Name == "_" {
+			label, ok := strings.CutPrefix(tag, "label:")
+			if !ok || strings.Contains(label, ",") {
+				problems = append(problems, fmt.Errorf("blank field of struct %s must only carry a 'label:' tag component", typ.Name()))
+				continue
+			}
+			if labelSource != "" && label != meta.Label {
+				problems = append(problems, fmt.Errorf("struct %s declares label '%s' in a blank field but '%s' in its %s", typ.Name(), label, meta.Label, labelSource))
+				continue
+			}
+			meta.Label = label
+			labelSource = "blank field"
+			continue
+		}
+
+		if err := meta.parseField(field, tag, i); err != nil {
+			// A primary key that failed to parse is not also reported as missing.
+			pkDeclared = pkDeclared || slices.Contains(strings.Split(tag, ","), "pk")
+			problems = append(problems, err)
+		}
+	}
+
+	if meta.UpdatedAtField == "" {
+		meta.ChangeTrackingErr = fmt.Errorf("entity type %s declares no time.Time field tagged 'updatedAt'", meta.Label)
+	}
+	if meta.PKField == "" && !pkDeclared {
+		problems = append(problems, fmt.Errorf("no primary key ('pk') tag defined for struct %s", typ.Name()))
+	}
+	// Labels cannot be parameters, so a declared label must be safe to render as is.
+	if !identifierPattern.MatchString(meta.Label) {
+		problems = append(problems, fmt.Errorf("struct %s declares an invalid label '%s'", typ.Name(), meta.Label))
+	}
+
+	// Property-oriented methods accept Go field names as well as property names, so a
+	// field named like another field's property would make some keys ambiguous.
+	fieldNames := make([]string, 0, len(meta.Mappings))
+	for fieldName := range meta.Mappings {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		propName := meta.Mappings[fieldName]
+		if _, ok := meta.Mappings[propName]; ok && propName != fieldName {
+			problems = append(problems, fmt.Errorf("property '%s' of field %s is also the name of another mapped field of struct %s", propName, fieldName, typ.Name()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &TagError{Type: typ.String(), Problems: problems}
+	}
+	return meta, nil
+}
+
+// parseField records the persistence mapping of the struct field at index i, declared by
+// its `crud` tag, in the metadata.
+func (m *entityMetadata) parseField(field reflect.StructField, tag string, i int) error {
+	m.FieldIndex[field.Name] = i
+	parts := strings.Split(tag, ",")
+	isPk := false
+	isUpdatedAt := false
+	propName := ""
+	relType := ""
+	direction := ""
+	var aliases []string
+	computed := ""
+	isElementID := false
+	omitEmpty := false
+	required := false
+	unique := false
+	isJSON := false
+	isBase64 := false
+	indexName, hasIndex := "", false
+	fullText := ""
+	isVector := false
+	var vectorSettings []string
+	isLabels := false
+	labelsMode := ""
+	columnAlias := ""
+	prefix := ""
+	enumMode := ""
+	defaultValue, hasDefault := "", false
 
-		for _, part := range parts {
-			if part == "pk" {
-				isPk = true
+	for _, part := range parts {
+		if part == "pk" {
+			isPk = true
+		}
+		if part == "updatedAt" {
+			isUpdatedAt = true
+		}
+		if part == "elementId" {
+			isElementID = true
+		}
+		if part == "omitempty" {
+			omitEmpty = true
+		}
+		if part == "required" {
+			required = true
+		}
+		if part == "unique" {
+			unique = true
+		}
+		if part == "json" {
+			isJSON = true
+		}
+		if part == "base64" {
+			isBase64 = true
+		}
+		if strings.HasPrefix(part, "fulltext:") {
+			fullText = strings.TrimPrefix(part, "fulltext:")
+			if fullText == "" {
+				return fmt.Errorf("field %s has an empty 'fulltext' tag component", field.Name)
 			}
-			if strings.HasPrefix(part, "property:") {
-				propName = strings.TrimPrefix(part, "property:")
+		}
+		// The vector settings are comma-separated too, as in `vector:dim=1536,similarity=cosine`.
+		if strings.HasPrefix(part, "vector:") {
+			isVector = true
+			vectorSettings = append(vectorSettings, strings.TrimPrefix(part, "vector:"))
+		}
+		if strings.HasPrefix(part, "dim=") || strings.HasPrefix(part, "similarity=") {
+			vectorSettings = append(vectorSettings, part)
+		}
+		if part == "index" || strings.HasPrefix(part, "index:") {
+			indexName, hasIndex = strings.TrimPrefix(strings.TrimPrefix(part, "index"), ":"), true
+		}
+		if part == "labels" || strings.HasPrefix(part, "labels:") {
+			isLabels = true
+			labelsMode = strings.TrimPrefix(strings.TrimPrefix(part, "labels"), ":")
+		}
+		if strings.HasPrefix(part, "property:") {
+			propName = strings.TrimPrefix(part, "property:")
+			if propName == "" {
+				return fmt.Errorf("field %s has an empty 'property' tag component", field.Name)
+			}
+		}
+		if strings.HasPrefix(part, "rel:") {
+			relType = strings.TrimPrefix(part, "rel:")
+		}
+		if strings.HasPrefix(part, "direction:") {
+			direction = strings.TrimPrefix(part, "direction:")
+		}
+		if strings.HasPrefix(part, "computed:") {
+			computed = strings.TrimPrefix(part, "computed:")
+		}
+		if strings.HasPrefix(part, "prefix:") {
+			prefix = strings.TrimPrefix(part, "prefix:")
+		}
+		if strings.HasPrefix(part, "enum:") {
+			enumMode = strings.TrimPrefix(part, "enum:")
+		}
+		if strings.HasPrefix(part, "default:") {
+			defaultValue, hasDefault = strings.TrimPrefix(part, "default:"), true
+		}
+		if strings.HasPrefix(part, "alias:") {
+			columnAlias = strings.TrimPrefix(part, "alias:")
+			if columnAlias == "" {
+				return fmt.Errorf("field %s has an empty 'alias' tag component", field.Name)
 			}
 		}
+		if strings.HasPrefix(part, "aliases:") {
+			aliases = strings.Split(strings.TrimPrefix(part, "aliases:"), "|")
+		}
+	}
 
-		if propName == "" {
-			return nil, fmt.Errorf("field %s is missing 'property' tag component", field.Name)
+	// A nested value object is flattened into prefixed properties of the node.
+	if prefix != "" {
+		if len(parts) > 1 {
+			return fmt.Errorf("field %s cannot combine 'prefix' with other tag components", field.Name)
+		}
+		if err := m.parseNested(field.Type, field.Name, prefix, []int{i}, nil); err != nil {
+			return err
 		}
+		return nil
+	}
+
+	// Column aliases name the projection of a property, so only property fields have one.
+	if columnAlias != "" && (relType != "" || isElementID || computed != "" || isLabels) {
+		return fmt.Errorf("field %s cannot combine 'alias' with 'rel', 'elementId', 'computed' or 'labels' tag components", field.Name)
+	}
 
+	// Relationship fields are not node properties, so they are recorded separately.
+	if relType != "" {
+		rel, err := parseRelation(field, relType, direction)
+		if err != nil {
+			return err
+		}
+		if isPk || propName != "" || aliases != nil {
+			return fmt.Errorf("field %s cannot combine 'rel' with 'pk', 'property' or 'aliases' tag components", field.Name)
+		}
+		m.Relations[field.Name] = rel
+		return nil
+	}
+
+	// The element ID is assigned by the database and read from the node itself.
+	if isElementID {
+		if isPk || isUpdatedAt || propName != "" || aliases != nil || computed != "" || enumMode != "" || required || unique || hasIndex || fullText != "" || isVector || isJSON || isBase64 || hasDefault {
+			return fmt.Errorf("field %s cannot combine 'elementId' with other tag components", field.Name)
+		}
+		if field.Type.Kind() != reflect.String {
+			return fmt.Errorf("field %s tagged 'elementId' must be a string", field.Name)
+		}
+		if m.ElementIDField != "" {
+			return fmt.Errorf("fields %s and %s are both tagged 'elementId'", m.ElementIDField, field.Name)
+		}
+		m.ElementIDField = field.Name
+		return nil
+	}
+
+	// Labels are read from the node itself and written with SET/REMOVE, not as a property.
+	if isLabels {
+		if isPk || isUpdatedAt || propName != "" || aliases != nil || computed != "" || omitEmpty || enumMode != "" || required || unique || hasIndex || fullText != "" || isVector || isJSON || isBase64 || hasDefault {
+			return fmt.Errorf("field %s cannot combine 'labels' with other tag components", field.Name)
+		}
+		if field.Type != stringSliceType {
+			return fmt.Errorf("field %s tagged 'labels' must be a []string", field.Name)
+		}
+		if labelsMode != "" && labelsMode != "add" && labelsMode != "sync" {
+			return fmt.Errorf("field %s has an invalid labels mode '%s' (must be 'add' or 'sync')", field.Name, labelsMode)
+		}
+		if m.LabelsField != "" {
+			return fmt.Errorf("fields %s and %s are both tagged 'labels'", m.LabelsField, field.Name)
+		}
+		m.LabelsField = field.Name
+		m.LabelsMode = labelsMode
+		return nil
+	}
+
+	// Computed fields are read-only result columns, not node properties.
+	if computed != "" {
+		if isPk || isUpdatedAt || propName != "" || aliases != nil || enumMode != "" || required || unique || hasIndex || fullText != "" || isVector || isJSON || isBase64 || hasDefault {
+			return fmt.Errorf("field %s cannot combine 'computed' with other tag components", field.Name)
+		}
+		m.Computed[field.Name] = computed
+		return nil
+	}
+
+	if propName == "" {
+		return fmt.Errorf("field %s is missing 'property' tag component", field.Name)
+	}
+
+	if isPk {
+		if err := checkPKType(field.Name, field.Type); err != nil {
+			return err
+		}
+		m.PKField = field.Name
+		m.PKProp = propName
+	}
+	if omitEmpty {
+		// The primary key is always written, since nodes are merged on it.
+		if isPk {
+			return fmt.Errorf("field %s cannot combine 'pk' with 'omitempty'", field.Name)
+		}
+		m.OmitEmpty[field.Name] = true
+	}
+	if required {
+		m.Required = append(m.Required, field.Name)
+	}
+	// The primary key is always constrained, so tagging it `unique` changes nothing.
+	if unique && !isPk {
+		m.Unique = append(m.Unique, field.Name)
+	}
+	// A constrained property is already indexed, unless it is part of a composite index.
+	if hasIndex && (indexName != "" || !isPk && !unique) {
+		m.addIndex(indexName, field.Name)
+	}
+	if vectorSettings != nil {
+		if !isVector {
+			return fmt.Errorf("field %s has vector settings without a 'vector' tag component", field.Name)
+		}
+		if isPk {
+			return fmt.Errorf("field %s cannot combine 'pk' with 'vector'", field.Name)
+		}
+		if err := m.setVector(field.Name, field.Type, vectorSettings); err != nil {
+			return err
+		}
+	}
+	if fullText != "" {
+		if err := m.addFullTextIndex(fullText, field.Name, field.Type); err != nil {
+			return err
+		}
+	}
+	if hasDefault {
+		if isPk || enumMode != "" || isUpdatedAt {
+			return fmt.Errorf("field %s cannot combine 'default' with 'pk', 'enum' or 'updatedAt'", field.Name)
+		}
+		value, err := parseDefault(field.Name, field.Type, defaultValue)
+		if err != nil {
+			return err
+		}
+		m.Defaults[field.Name] = value
+	}
+	if aliases != nil {
+		// Nodes are matched on the primary key property only, so an alias there
+		// would make legacy nodes unreachable by ID.
+		if isPk {
+			return fmt.Errorf("field %s cannot combine 'pk' with 'aliases'", field.Name)
+		}
+		for _, alias := range aliases {
+			if alias == "" || alias == propName {
+				return fmt.Errorf("field %s has an invalid alias '%s'", field.Name, alias)
+			}
+		}
+		m.Aliases[field.Name] = aliases
+	}
+	if isUpdatedAt {
+		if field.Type != timeType {
+			return fmt.Errorf("field %s tagged 'updatedAt' must be of type time.Time", field.Name)
+		}
+		if m.UpdatedAtField != "" {
+			return fmt.Errorf("fields %s and %s are both tagged 'updatedAt'", m.UpdatedAtField, field.Name)
+		}
+		m.UpdatedAtField = field.Name
+		m.UpdatedAtProp = propName
+	}
+	if enumMode != "" {
+		if enumMode != "string" {
+			return fmt.Errorf("field %s has an invalid enum mode '%s' (must be 'string')", field.Name, enumMode)
+		}
 		if isPk {
-			meta.PKField = field.Name
-			meta.PKProp = propName
+			return fmt.Errorf("field %s cannot combine 'pk' with 'enum'", field.Name)
+		}
+		if field.Type.Kind() == reflect.Pointer || !field.Type.Implements(stringerType) {
+			return fmt.Errorf("field %s tagged 'enum:string' must be of a non-pointer type with a String method", field.Name)
+		}
+		m.EnumFields[field.Name] = true
+	}
+	if columnAlias != "" {
+		for other, alias := range m.ColumnAliases {
+			if alias == columnAlias {
+				return fmt.Errorf("fields %s and %s have the same alias '%s'", other, field.Name, columnAlias)
+			}
 		}
-		meta.Mappings[field.Name] = propName
+		m.ColumnAliases[field.Name] = columnAlias
+	}
+	if isBase64 {
+		if isPk || enumMode != "" || hasDefault || isJSON {
+			return fmt.Errorf("field %s cannot combine 'base64' with 'pk', 'enum', 'default' or 'json'", field.Name)
+		}
+		if !isBytesType(field.Type) {
+			return fmt.Errorf("field %s tagged 'base64' must be a []byte", field.Name)
+		}
+		m.Base64Fields[field.Name] = true
+	}
+	if isJSON {
+		if isPk || enumMode != "" || hasDefault {
+			return fmt.Errorf("field %s cannot combine 'json' with 'pk', 'enum' or 'default'", field.Name)
+		}
+		m.JSONFields[field.Name] = true
+	} else if err := checkListType(field.Name, field.Type); err != nil {
+		return err
+	}
+	if err := m.checkPropertyFree(field.Name, propName); err != nil {
+		return err
 	}
+	m.Mappings[field.Name] = propName
+	m.Types[field.Name] = field.Type
+	return nil
+}
 
-	if meta.PKField == "" {
-		return nil, fmt.Errorf("no primary key ('pk') tag defined for struct %s", typ.Name())
+// parseNested records the fields of a struct field tagged with `prefix:`, such as an
+// Address field tagged `crud:"prefix:address_"`, as mappings named after their path
+// (e.g., "Address.City") onto prefixed properties (e.g., "address_city"). Nested fields
+// support the `property`, `omitempty`, `required`, `unique` and `index` tag components, or `prefix` for
+// deeper nesting. The enclosing argument lists the nested types being parsed around this
+// one, so that a type nesting itself is reported instead of being flattened forever.
+func (m *entityMetadata) parseNested(typ reflect.Type, name, prefix string, path []int, enclosing []reflect.Type) error {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		return fmt.Errorf("field %s tagged 'prefix' must be a struct or a pointer to a struct", name)
+	}
+	if slices.Contains(enclosing, typ) {
+		return fmt.Errorf("field %s tagged 'prefix' nests type %s within itself", name, typ)
 	}
+	enclosing = append(enclosing[:len(enclosing):len(enclosing)], typ)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("crud")
+		if tag == "" || tag == ignoreTag {
+			continue
+		}
+		fieldName := name + "." + field.Name
+		fieldPath := append(append([]int(nil), path...), i)
 
-	return meta, nil
+		if nestedPrefix, ok := strings.CutPrefix(tag, "prefix:"); ok {
+			if strings.Contains(nestedPrefix, ",") {
+				return fmt.Errorf("nested field %s cannot combine 'prefix' with other tag components", fieldName)
+			}
+			if err := m.parseNested(field.Type, fieldName, prefix+nestedPrefix, fieldPath, enclosing); err != nil {
+				return err
+			}
+			continue
+		}
+
+		propName := ""
+		for _, part := range strings.Split(tag, ",") {
+			switch {
+			case strings.HasPrefix(part, "property:"):
+				propName = strings.TrimPrefix(part, "property:")
+				if propName == "" {
+					return fmt.Errorf("nested field %s has an empty 'property' tag component", fieldName)
+				}
+			case part == "omitempty":
+				m.OmitEmpty[fieldName] = true
+			case part == "required":
+				m.Required = append(m.Required, fieldName)
+			case part == "unique":
+				m.Unique = append(m.Unique, fieldName)
+			case part == "index" || strings.HasPrefix(part, "index:"):
+				m.addIndex(strings.TrimPrefix(strings.TrimPrefix(part, "index"), ":"), fieldName)
+			default:
+				return fmt.Errorf("nested field %s supports only 'property', 'omitempty', 'required', 'unique', 'index' and 'prefix' tag components, got '%s'", fieldName, part)
+			}
+		}
+		if propName == "" {
+			return fmt.Errorf("nested field %s is missing 'property' tag component", fieldName)
+		}
+		if err := checkListType(fieldName, field.Type); err != nil {
+			return err
+		}
+		if err := m.checkPropertyFree(fieldName, prefix+propName); err != nil {
+			return err
+		}
+		m.Mappings[fieldName] = prefix + propName
+		m.Types[fieldName] = field.Type
+		m.FieldPaths[fieldName] = fieldPath
+	}
+	return nil
+}
+
+// field returns the named field of val, a struct value of the entity's type. For a nested
+// field behind a nil pointer, it returns the zero reflect.Value; see settableField.
+func (m *entityMetadata) field(val reflect.Value, fieldName string) reflect.Value {
+	if path, ok := m.FieldPaths[fieldName]; ok {
+		for k, i := range path {
+			if k > 0 && val.Kind() == reflect.Pointer {
+				if val.IsNil() {
+					return reflect.Value{}
+				}
+				val = val.Elem()
+			}
+			val = val.Field(i)
+		}
+		return val
+	}
+	if i, ok := m.FieldIndex[fieldName]; ok {
+		return val.Field(i)
+	}
+	return val.FieldByName(fieldName)
+}
+
+// settableField is like field, but allocates the nil pointers leading to a nested field,
+// so that a value can be assigned to it.
+func (m *entityMetadata) settableField(val reflect.Value, fieldName string) reflect.Value {
+	path, ok := m.FieldPaths[fieldName]
+	if !ok {
+		return m.field(val, fieldName)
+	}
+	for k, i := range path {
+		if k > 0 && val.Kind() == reflect.Pointer {
+			if val.IsNil() {
+				if !val.CanSet() {
+					return reflect.Value{}
+				}
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+		val = val.Field(i)
+	}
+	return val
+}
+
+// fieldForProperty returns the name of the struct field mapped to the given property.
+func (m *entityMetadata) fieldForProperty(propName string) (string, bool) {
+	for fieldName, p := range m.Mappings {
+		if p == propName {
+			return fieldName, true
+		}
+	}
+	return "", false
+}
+
+// parseRelation builds the relationship metadata for a field tagged with `rel:`.
+func parseRelation(field reflect.StructField, relType, direction string) (*relationMetadata, error) {
+	// Relationship types cannot be parameters, so they are validated before being rendered.
+	if !identifierPattern.MatchString(relType) {
+		return nil, fmt.Errorf("field %s has an invalid relationship type '%s'", field.Name, relType)
+	}
+	rel := &relationMetadata{Field: field.Name, Type: relType, Direction: Outgoing}
+	switch Direction(direction) {
+	case "", Outgoing:
+	case Incoming:
+		rel.Direction = Incoming
+	default:
+		return nil, fmt.Errorf("field %s has invalid direction '%s' (expected 'out' or 'in')", field.Name, direction)
+	}
+
+	target := field.Type
+	for target.Kind() == reflect.Ptr || target.Kind() == reflect.Slice {
+		target = target.Elem()
+	}
+	if target.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("relationship field %s must be a struct, pointer or slice of structs", field.Name)
+	}
+	rel.TargetType = target
+	return rel, nil
+}
+
+// checkPropertyFree reports an error if a field other than fieldName is already mapped
+// to propName, since both would write the same property and load the same value.
+func (m *entityMetadata) checkPropertyFree(fieldName, propName string) error {
+	for other, prop := range m.Mappings {
+		if prop != propName {
+			continue
+		}
+		if other == m.PKField || fieldName == m.PKField {
+			return fmt.Errorf("fields %s and %s both map to property '%s', which is the primary key", other, fieldName, propName)
+		}
+		return fmt.Errorf("fields %s and %s both map to property '%s'", other, fieldName, propName)
+	}
+	return nil
+}
+
+// ValidateEntities parses the `crud` tags of the given entity types, so that misconfigured
+// models can fail a service at startup rather than when a repository is first created,
+// often deep in request handling. PersistenceManager.RegisterEntities does the same and
+// also caches the parsed metadata.
+//
+// Example:
+//
+//	if err := neopersist.ValidateEntities(models.User{}, models.Post{}); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Parameters:
+//   - entityTypes: Values of, or pointers to, the entity structs to validate.
+//
+// Returns:
+//
+//	nil if every type is valid, or the *TagError of each invalid type, joined.
+func ValidateEntities(entityTypes ...any) error {
+	var errs []error
+	for _, entityType := range entityTypes {
+		typ := reflect.TypeOf(entityType)
+		if typ == nil {
+			errs = append(errs, fmt.Errorf("entity type must not be nil"))
+			continue
+		}
+		if _, err := parseTagsFromType(typ); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // parseTags is a generic convenience wrapper around parseTagsFromType.
@@ -84,3 +796,42 @@ func parseTags[T any]() (*entityMetadata, error) {
 	typ := reflect.TypeOf(instance)
 	return parseTagsFromType(typ)
 }
+
+// checkStrictTags reports the exported fields of typ, including those of nested structs
+// tagged with `prefix:`, that carry neither a `crud` tag nor the `crud:"-"` ignore marker.
+// The enclosing argument lists the types being checked around typ, as in parseNested.
+func checkStrictTags(typ reflect.Type, name string, enclosing []reflect.Type) error {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if slices.Contains(enclosing, typ) {
+		return fmt.Errorf("field %s tagged 'prefix' nests type %s within itself", name, typ)
+	}
+	enclosing = append(enclosing[:len(enclosing):len(enclosing)], typ)
+	var untagged []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldName := field.Name
+		if name != "" {
+			fieldName = name + "." + field.Name
+		}
+		tag := field.Tag.Get("crud")
+		if tag == "" {
+			untagged = append(untagged, fieldName)
+			continue
+		}
+		if strings.HasPrefix(tag, "prefix:") {
+			if err := checkStrictTags(field.Type, fieldName, enclosing); err != nil {
+				return err
+			}
+		}
+	}
+	if len(untagged) > 0 {
+		return fmt.Errorf("struct %s has exported fields without a crud tag: %s; tag them, or mark them `crud:\"-\"` to ignore them",
+			typ.Name(), strings.Join(untagged, ", "))
+	}
+	return nil
+}
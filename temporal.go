@@ -0,0 +1,167 @@
+package neopersist
+
+import (
+	"reflect"
+	"time"
+)
+
+// LocalDateTime is a date and time without a time zone, as stored in Neo4j LOCAL DATETIME
+// values. Only its wall clock is meaningful; the location of the underlying time.Time is
+// ignored.
+type LocalDateTime time.Time
+
+// Time returns the wall clock of t as a time.Time in the given location.
+func (t LocalDateTime) Time(loc *time.Location) time.Time {
+	return wallClock(time.Time(t), loc)
+}
+
+// LocalDate is a calendar date, as stored in Neo4j DATE values. Only its year, month and
+// day are meaningful.
+type LocalDate time.Time
+
+// Time returns midnight at the start of d as a time.Time in the given location.
+func (d LocalDate) Time(loc *time.Location) time.Time {
+	date := time.Time(d)
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// LocalTime is a time of day without a time zone, as stored in Neo4j LOCAL TIME values.
+// Only its clock is meaningful; the date and location of the underlying time.Time are
+// ignored when it is written.
+type LocalTime time.Time
+
+// Time returns the clock of t as a time.Time in the given location, on the date of the
+// underlying time.Time.
+func (t LocalTime) Time(loc *time.Location) time.Time {
+	return wallClock(time.Time(t), loc)
+}
+
+// wallClock returns the date and clock of t, read in its own location, as a time.Time in
+// loc.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// TimeStorage selects the Neo4j type that time.Time fields are written as.
+type TimeStorage int
+
+const (
+	// TimeAsDateTime writes times as DATETIME values, which keep their UTC offset. It is
+	// the default.
+	TimeAsDateTime TimeStorage = iota
+	// TimeAsLocalDateTime writes times as LOCAL DATETIME values, which keep only the wall
+	// clock in the location set with WithTimeLocation, or in the time's own location.
+	TimeAsLocalDateTime
+)
+
+// WithTimeStorage returns a RepositoryOption selecting how time.Time and *time.Time fields
+// are written, as DATETIME (the default) or LOCAL DATETIME values. Query parameters built
+// from times by the repository, such as Field conditions, are converted the same way, so
+// that they compare with the stored values.
+func WithTimeStorage(storage TimeStorage) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.timeStorage = storage
+	}
+}
+
+// WithTimeLocation returns a RepositoryOption setting the location of the times the
+// repository loads into time.Time and *time.Time fields. Zoned values are converted into
+// it, and values without a time zone (LOCAL DATETIME, DATE and LOCAL TIME) are read as a
+// wall clock in it. Times are also converted into it before being written as LOCAL DATETIME values.
+// Without this option, zoned values keep the offset they were stored with and zone-less
+// values are read in time.Local.
+func WithTimeLocation(loc *time.Location) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.timeLocation = loc
+	}
+}
+
+// timeParam converts a time into the value written for it, according to TimeStorage and
+// TimeLocation.
+func (m *entityMetadata) timeParam(t time.Time) interface{} {
+	if m.TimeLocation != nil {
+		t = t.In(m.TimeLocation)
+	}
+	if m.TimeStorage == TimeAsLocalDateTime {
+		return LocalDateTime(t)
+	}
+	return t
+}
+
+// paramValue converts a value passed as a query parameter for a mapped property: times,
+// directly, behind a non-nil pointer or in a slice, are converted with timeParam,
+// time.Duration values into Duration, float32 slices are widened to the 64-bit floats
+// Neo4j stores, and other values are returned unchanged.
+func (m *entityMetadata) paramValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return m.timeParam(v)
+	case *time.Time:
+		if v != nil {
+			return m.timeParam(*v)
+		}
+	case []time.Time:
+		if v != nil {
+			list := make([]any, len(v))
+			for i, t := range v {
+				list[i] = m.timeParam(t)
+			}
+			return list
+		}
+	case time.Duration:
+		return NewDuration(v)
+	case *time.Duration:
+		if v != nil {
+			return NewDuration(*v)
+		}
+	case []float32:
+		if v != nil {
+			return float64List(v)
+		}
+	}
+	return value
+}
+
+// setTimeValue assigns a temporal database value to a time.Time or *time.Time field,
+// applying TimeLocation. It reports false, leaving the field untouched, if the value is not
+// temporal or the field is not a time.
+func (m *entityMetadata) setTimeValue(field reflect.Value, value any) bool {
+	loc := m.TimeLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+		if m.TimeLocation != nil {
+			t = t.In(m.TimeLocation)
+		}
+	case LocalDateTime:
+		t = v.Time(loc)
+	case LocalDate:
+		t = v.Time(loc)
+	case LocalTime:
+		t = v.Time(loc)
+	default:
+		return false
+	}
+	switch field.Type() {
+	case timeType:
+		field.Set(reflect.ValueOf(t))
+	case reflect.PointerTo(timeType):
+		field.Set(reflect.ValueOf(&t))
+	default:
+		return false
+	}
+	return true
+}
+
+// isZeroField reports whether a field holds its zero value. Times count as zero when
+// time.Time.IsZero says so, whatever their location.
+func isZeroField(field reflect.Value) bool {
+	if field.Type() == timeType {
+		return field.Interface().(time.Time).IsZero()
+	}
+	return field.IsZero()
+}
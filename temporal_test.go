@@ -0,0 +1,114 @@
+package neopersist_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Flight has zone-less temporal fields and a 3D position.
+type Flight struct {
+	ID        string               `crud:"pk,property:id"`
+	Day       neopersist.LocalDate `crud:"property:day"`
+	Departure neopersist.LocalTime `crud:"property:departure"`
+	Position  *neopersist.Point3D  `crud:"property:position"`
+	Landed    time.Time            `crud:"property:landed"`
+}
+
+func TestZonelessValuesAndPoint3DAreSavedAsTheirOwnTypes(t *testing.T) {
+	runner := newFlightRunner(t, nil)
+	repo, err := neopersist.NewRepository[Flight](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	day := neopersist.LocalDate(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC))
+	departure := neopersist.LocalTime(time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC))
+	position := neopersist.GeoPoint3D(48.85, 2.35, 10000)
+
+	if err := repo.Save(context.Background(), &Flight{ID: "f1", Day: day, Departure: departure, Position: &position}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	call := runner.Calls()[0]
+	if value := setValue(t, call, "day"); value != day {
+		t.Errorf("day saved as %#v, want a LocalDate", value)
+	}
+	if value := setValue(t, call, "departure"); value != departure {
+		t.Errorf("departure saved as %#v, want a LocalTime", value)
+	}
+	if value := setValue(t, call, "position"); value != &position {
+		t.Errorf("position saved as %#v, want the Point3D", value)
+	}
+}
+
+func TestSavingAPoint3DChecksItsSRID(t *testing.T) {
+	runner := newFlightRunner(t, nil)
+	repo, err := neopersist.NewRepository[Flight](runner)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	flat := neopersist.Point3D{X: 1, Y: 2, Z: 3, SRID: neopersist.SRIDCartesian}
+
+	err = repo.Save(context.Background(), &Flight{ID: "f1", Position: &flat})
+	if err == nil || !strings.Contains(err.Error(), "unsupported SRID 7203") || !strings.Contains(err.Error(), "field Position") {
+		t.Fatalf("Save error = %v, want an unsupported SRID for field Position", err)
+	}
+	if n := len(runner.Calls()); n != 0 {
+		t.Errorf("sent %d queries, want none", n)
+	}
+}
+
+func TestZonelessValuesAndPoint3DLoad(t *testing.T) {
+	day := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+	departure := time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)
+	runner := newFlightRunner(t, map[string]any{
+		"day":       neopersist.LocalDate(day),
+		"departure": neopersist.LocalTime(departure),
+		"position":  neopersist.CartesianPoint3D(1, 2, 3),
+		"landed":    neopersist.LocalDate(day),
+	})
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("no time zone database: %v", err)
+	}
+	repo, err := neopersist.NewRepository[Flight](runner, neopersist.WithTimeLocation(paris))
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	flight, err := repo.FindByID(context.Background(), "f1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got := flight.Day.Time(time.UTC); !got.Equal(day) {
+		t.Errorf("Day = %v, want %v", got, day)
+	}
+	if got := time.Time(flight.Departure); got.Hour() != 14 || got.Minute() != 30 {
+		t.Errorf("Departure = %v, want 14:30", got)
+	}
+	if flight.Position == nil || *flight.Position != neopersist.CartesianPoint3D(1, 2, 3) {
+		t.Errorf("Position = %v, want (1, 2, 3)", flight.Position)
+	}
+	// A date loaded into a time field is midnight in the repository's location.
+	if want := time.Date(2024, 3, 9, 0, 0, 0, 0, paris); !flight.Landed.Equal(want) || flight.Landed.Location() != paris {
+		t.Errorf("Landed = %v, want %v", flight.Landed, want)
+	}
+}
+
+// newFlightRunner returns a FakeRunner answering every query with a Flight node holding
+// the given properties.
+func newFlightRunner(t *testing.T, props map[string]any) *neopersisttest.FakeRunner {
+	t.Helper()
+	runner := neopersisttest.NewFakeRunner()
+	if props == nil {
+		props = map[string]any{}
+	}
+	props["id"] = "f1"
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return nodeResult(neopersist.Node{ElementID: "4:test:f1", Labels: []string{"Flight"}, Props: props}), nil
+	}
+	return runner
+}
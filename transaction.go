@@ -0,0 +1,84 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrTransactionsUnsupported is returned by WithTransaction when the manager's runner
+// cannot run several queries in one transaction.
+var ErrTransactionsUnsupported = errors.New("runner does not support transactions")
+
+// TransactionRunner is implemented by runners that can run several queries in a single
+// transaction, such as Neo4jExecutor.
+type TransactionRunner interface {
+	DBRunner
+	// RunInTransaction calls fn with a DBRunner whose queries all run in one transaction,
+	// committed when fn returns nil and rolled back when it returns an error.
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context, tx DBRunner) error) error
+}
+
+// WithTransaction calls fn with a manager whose queries all run in one transaction, so
+// that a group of writes is applied entirely or not at all. Repositories created from
+// the transaction's manager with RepositoryFor take part in the transaction too. The
+// transaction is committed when fn returns nil and rolled back when it returns an error,
+// which WithTransaction then returns.
+//
+// The transaction's manager has the policies and registrations of pm at the time of the
+// call. Per-call OnDatabase and ReadOnly settings cannot be applied inside a transaction.
+//
+// Example:
+//
+//	err := pm.WithTransaction(ctx, func(ctx context.Context, tx *neopersist.PersistenceManager) error {
+//	    posts, err := neopersist.RepositoryFor[models.Post](tx)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if err := posts.Save(ctx, post); err != nil {
+//	        return err
+//	    }
+//	    return tx.CreateRelation(ctx, author, post, "WROTE", nil)
+//	})
+//
+// Returns:
+//
+//	The error returned by fn or by the transaction, or an error wrapping
+//	ErrTransactionsUnsupported if the manager's runner is not a TransactionRunner.
+func (pm *PersistenceManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx *PersistenceManager) error) error {
+	runner, ok := pm.runner.(TransactionRunner)
+	if !ok {
+		return fmt.Errorf("%w: %T does not implement TransactionRunner", ErrTransactionsUnsupported, pm.runner)
+	}
+	return runner.RunInTransaction(ctx, func(ctx context.Context, tx DBRunner) error {
+		return fn(ctx, pm.withRunner(tx))
+	})
+}
+
+// withRunner returns a manager running its queries with runner, with a copy of pm's
+// policies, registrations and cached metadata.
+func (pm *PersistenceManager) withRunner(runner DBRunner) *PersistenceManager {
+	derived := &PersistenceManager{
+		runner:            runner,
+		forbidDestructive: pm.forbidDestructive,
+		provenance:        pm.provenance,
+		lockTTL:           pm.lockTTL,
+	}
+	pm.countersMu.RLock()
+	derived.counters = append([]CounterSpec(nil), pm.counters...)
+	pm.countersMu.RUnlock()
+	copySyncMap(&derived.metaCache, &pm.metaCache)
+	copySyncMap(&derived.validators, &pm.validators)
+	copySyncMap(&derived.converters, &pm.converters)
+	copySyncMap(&derived.enumParsers, &pm.enumParsers)
+	return derived
+}
+
+// copySyncMap stores every entry of src into dst.
+func copySyncMap(dst, src *sync.Map) {
+	src.Range(func(key, value any) bool {
+		dst.Store(key, value)
+		return true
+	})
+}
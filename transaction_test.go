@@ -0,0 +1,122 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// txRunner is a TransactionRunner sending queries outside a transaction to outside and
+// queries in a transaction to inside, and recording how each transaction ended.
+type txRunner struct {
+	outside, inside *neopersisttest.FakeRunner
+	outcomes        []string
+}
+
+func (r *txRunner) Run(ctx context.Context, query string, params map[string]interface{}) (*neopersist.ResultSet, error) {
+	return r.outside.Run(ctx, query, params)
+}
+
+func (r *txRunner) RunInTransaction(ctx context.Context, fn func(ctx context.Context, tx neopersist.DBRunner) error) error {
+	err := fn(ctx, r.inside)
+	if err != nil {
+		r.outcomes = append(r.outcomes, "rollback")
+	} else {
+		r.outcomes = append(r.outcomes, "commit")
+	}
+	return err
+}
+
+func newTxRunner() *txRunner {
+	return &txRunner{outside: neopersisttest.NewFakeRunner(), inside: neopersisttest.NewFakeRunner()}
+}
+
+func TestWithTransactionRunsRepositoriesAndRelationsInTheTransaction(t *testing.T) {
+	runner := newTxRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+	ada, bob := &User{UserID: "u1", Name: "Ada"}, &User{UserID: "u2", Name: "Bob"}
+
+	err := pm.WithTransaction(context.Background(), func(ctx context.Context, tx *neopersist.PersistenceManager) error {
+		users, err := neopersist.RepositoryFor[User](tx)
+		if err != nil {
+			return err
+		}
+		if err := users.SaveAll(ctx, []*User{ada, bob}); err != nil {
+			return err
+		}
+		return tx.CreateRelation(ctx, ada, bob, "FOLLOWS", nil)
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction: %v", err)
+	}
+	neopersisttest.AssertCallCount(t, runner.outside, 0)
+	neopersisttest.AssertCalls(t, runner.inside,
+		neopersisttest.Expect{QueryContains: []string{"UNWIND $rows AS row", "MERGE (n:User"}},
+		neopersisttest.Expect{QueryContains: []string{"CREATE (a)-[r:FOLLOWS]->(b)"}},
+	)
+	if len(runner.outcomes) != 1 || runner.outcomes[0] != "commit" {
+		t.Errorf("outcomes = %v, want [commit]", runner.outcomes)
+	}
+}
+
+func TestWithTransactionReturnsTheErrorAndRollsBack(t *testing.T) {
+	runner := newTxRunner()
+	pm := neopersist.NewPersistenceManager(runner)
+	failure := errors.New("out of stock")
+
+	err := pm.WithTransaction(context.Background(), func(ctx context.Context, tx *neopersist.PersistenceManager) error {
+		users, err := neopersist.RepositoryFor[User](tx)
+		if err != nil {
+			return err
+		}
+		if err := users.Save(ctx, &User{UserID: "u1"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithTransaction error = %v, want %v", err, failure)
+	}
+	if len(runner.outcomes) != 1 || runner.outcomes[0] != "rollback" {
+		t.Errorf("outcomes = %v, want [rollback]", runner.outcomes)
+	}
+}
+
+func TestWithTransactionKeepsTheManagerPolicies(t *testing.T) {
+	runner := newTxRunner()
+	pm := neopersist.NewPersistenceManager(runner, neopersist.AllowDestructive(false))
+
+	err := pm.WithTransaction(context.Background(), func(ctx context.Context, tx *neopersist.PersistenceManager) error {
+		users, err := neopersist.RepositoryFor[User](tx)
+		if err != nil {
+			return err
+		}
+		_, err = users.DeleteAll(ctx)
+		return err
+	})
+	if !errors.Is(err, neopersist.ErrDestructiveForbidden) {
+		t.Fatalf("WithTransaction error = %v, want ErrDestructiveForbidden", err)
+	}
+	neopersisttest.AssertCallCount(t, runner.inside, 0)
+}
+
+func TestWithTransactionRequiresATransactionRunner(t *testing.T) {
+	// Embedding only the DBRunner interface hides the FakeRunner's RunInTransaction.
+	runner := struct{ neopersist.DBRunner }{neopersisttest.NewFakeRunner()}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	called := false
+	err := pm.WithTransaction(context.Background(), func(context.Context, *neopersist.PersistenceManager) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, neopersist.ErrTransactionsUnsupported) {
+		t.Fatalf("WithTransaction error = %v, want ErrTransactionsUnsupported", err)
+	}
+	if called {
+		t.Error("fn was called without a transaction")
+	}
+}
@@ -0,0 +1,277 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// treeKey identifies an entity of the tree, so that a node reached through several paths
+// is hydrated once.
+type treeKey struct {
+	typ reflect.Type
+	id  any
+}
+
+// treeVisit is a node of the tree waiting for its relationship fields to be wired.
+type treeVisit struct {
+	node   Node
+	entity reflect.Value
+	meta   *entityMetadata
+	level  int
+}
+
+// treeLink is a related entity to assign to a relationship field of its parent, which is
+// on the given level of the tree.
+type treeLink struct {
+	field reflect.Value
+	child reflect.Value
+	level int
+}
+
+// FindTree loads the entity of type T with the given primary key as the root of a typed
+// object graph: the relationship fields declared on T with the `rel:` tag component are
+// filled with the related entities, whose own relationship fields are filled in turn, up
+// to depth levels below the root. For example, with depth 2 a User gets its Posts, and
+// each Post its Comments.
+//
+// The graph is fetched in a single query, with a variable-length MATCH over the
+// relationship types declared on the types reachable from T, each followed only in the
+// direction it is declared in, which collects every distinct node and relationship
+// reached. It is assembled in memory by following, on each level, the types and
+// directions declared on that level's struct.
+// Nodes reached through several paths, as in diamond-shaped graphs, are hydrated once and
+// shared between pointer fields, and cycles resolve to the entity already loaded. Each
+// node is expanded on the shallowest level it is found on.
+//
+// Example:
+//
+//	user, err := neopersist.FindTree[models.User](ctx, manager, "user-1", 2)
+//	for _, post := range user.Posts {
+//	    fmt.Println(post.Title, len(post.Comments))
+//	}
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - pm: The PersistenceManager running the query.
+//   - rootID: The primary key value of the root entity.
+//   - depth: The number of relationship levels to load below the root; 0 loads the root
+//     alone.
+//   - opts: Optional per-call settings, such as OnDatabase, WithTimeout or ReadOnly.
+//
+// Returns:
+//
+//	A pointer to the root entity, ErrNotFound if no node has the key, or an error if depth
+//	is negative, a related type has invalid tags, or the query or mapping fails.
+func FindTree[T any](ctx context.Context, pm *PersistenceManager, rootID any, depth int, opts ...FindOption) (*T, error) {
+	options, err := parseOptions("FindTree", opts, statementOptions)
+	if err != nil {
+		return nil, err
+	}
+	if depth < 0 {
+		return nil, fmt.Errorf("depth must not be negative, got %d", depth)
+	}
+	rootType := reflect.TypeOf((*T)(nil)).Elem()
+	meta, err := pm.metadataFor(rootType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := meta.pkParam(rootID)
+	if err != nil {
+		return nil, err
+	}
+	outgoing, incoming, err := pm.treeRelationshipTypes(rootType, depth)
+	if err != nil {
+		return nil, err
+	}
+	query, params := treeQuery(meta, depth, outgoing, incoming)
+	params["id"] = key
+	result, err := pm.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Records) == 0 {
+		return nil, ErrNotFound
+	}
+
+	record := result.Records[0]
+	rootValue, _ := record.Get("root")
+	root, ok := rootValue.(Node)
+	if !ok {
+		return nil, fmt.Errorf("return value 'root' is not a node")
+	}
+	nodesValue, _ := record.Get("nodes")
+	relsValue, _ := record.Get("rels")
+	nodes, _ := nodesValue.([]any)
+	rels, _ := relsValue.([]any)
+
+	entity, err := pm.assembleTree(root, rootType, meta, depth, nodes, rels)
+	if err != nil {
+		return nil, err
+	}
+	return entity.Interface().(*T), nil
+}
+
+// treeRelationshipTypes lists, sorted, the relationship types declared as outgoing and as
+// incoming on typ and on the types it leads to within depth levels. A type declared in
+// both directions is listed in both.
+func (pm *PersistenceManager) treeRelationshipTypes(typ reflect.Type, depth int) (outgoing, incoming []string, err error) {
+	seen := map[Direction]map[string]bool{Outgoing: {}, Incoming: {}}
+	level := []reflect.Type{typ}
+	visited := map[reflect.Type]bool{typ: true}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		var next []reflect.Type
+		for _, t := range level {
+			meta, err := pm.metadataFor(t)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, rel := range meta.Relations {
+				seen[rel.Direction][rel.Type] = true
+				if !visited[rel.TargetType] {
+					visited[rel.TargetType] = true
+					next = append(next, rel.TargetType)
+				}
+			}
+		}
+		level = next
+	}
+	return slices.Sorted(maps.Keys(seen[Outgoing])), slices.Sorted(maps.Keys(seen[Incoming])), nil
+}
+
+// treeQuery renders the query of FindTree, given the relationship types declared as
+// outgoing and as incoming. Relationships are followed away from the root in their
+// declared direction: with the arrow of the pattern when every type shares one direction,
+// and otherwise by checking, on each step, that the relationship of a type declared in
+// one direction only starts or ends at the node the step leaves from. Paths are unwound
+// into their relationships, so that each distinct relationship, and its end nodes, is
+// returned once however many paths share it.
+func treeQuery(meta *entityMetadata, depth int, outgoing, incoming []string) (string, map[string]interface{}) {
+	params := map[string]interface{}{}
+	match := fmt.Sprintf("MATCH (root:%s {%s: $id})", meta.Label, meta.PKProp)
+	if len(outgoing) == 0 && len(incoming) == 0 {
+		return match + "\nRETURN root, [] AS nodes, [] AS rels", params
+	}
+
+	relTypes := slices.Compact(slices.Sorted(slices.Values(append(slices.Clone(outgoing), incoming...))))
+	pattern := fmt.Sprintf("-[:%s*1..%d]-", strings.Join(relTypes, "|"), depth)
+	where := ""
+	switch {
+	case len(incoming) == 0:
+		pattern += ">"
+	case len(outgoing) == 0:
+		pattern = "<" + pattern
+	default:
+		outOnly := slices.DeleteFunc(slices.Clone(outgoing), func(t string) bool { return slices.Contains(incoming, t) })
+		inOnly := slices.DeleteFunc(slices.Clone(incoming), func(t string) bool { return slices.Contains(outgoing, t) })
+		if len(outOnly) > 0 || len(inOnly) > 0 {
+			where = "\nWHERE all(i IN range(0, length(path) - 1) WHERE\n" +
+				"  CASE WHEN type(relationships(path)[i]) IN $outgoingOnly THEN startNode(relationships(path)[i]) = nodes(path)[i]\n" +
+				"       WHEN type(relationships(path)[i]) IN $incomingOnly THEN endNode(relationships(path)[i]) = nodes(path)[i]\n" +
+				"       ELSE true END)"
+			params["outgoingOnly"] = outOnly
+			params["incomingOnly"] = inOnly
+		}
+	}
+	return match + "\n" +
+		"OPTIONAL MATCH path = (root)" + pattern + "()" + where + "\n" +
+		"UNWIND coalesce(relationships(path), [null]) AS rel\n" +
+		"WITH root, collect(DISTINCT rel) AS rels\n" +
+		"RETURN root, [r IN rels | startNode(r)] + [r IN rels | endNode(r)] AS nodes, rels", params
+}
+
+// assembleTree hydrates the root node and, breadth first so that every node is expanded on
+// the shallowest level it appears on, the nodes related to it through the declared
+// relationship fields.
+func (pm *PersistenceManager) assembleTree(root Node, rootType reflect.Type, meta *entityMetadata, depth int, nodes, rels []any) (reflect.Value, error) {
+	// A node at an end of several relationships is returned once for each of them.
+	byID := make(map[string]Node, len(nodes))
+	for _, value := range nodes {
+		if node, ok := value.(Node); ok {
+			byID[node.ElementID] = node
+		}
+	}
+	outgoing := make(map[string][]Relationship)
+	incoming := make(map[string][]Relationship)
+	for _, value := range rels {
+		rel, ok := value.(Relationship)
+		if !ok {
+			continue
+		}
+		outgoing[rel.StartElementID] = append(outgoing[rel.StartElementID], rel)
+		incoming[rel.EndElementID] = append(incoming[rel.EndElementID], rel)
+	}
+
+	entities := make(map[treeKey]reflect.Value)
+	var queue []*treeVisit
+	// hydrate returns the entity of a node, creating it and queueing its expansion on first sight.
+	hydrate := func(node Node, typ reflect.Type, meta *entityMetadata, level int) (reflect.Value, error) {
+		key := treeKey{typ: typ, id: node.Props[meta.PKProp]}
+		if key.id == nil {
+			key.id = node.ElementID
+		}
+		if entity, ok := entities[key]; ok {
+			return entity, nil
+		}
+		entity := reflect.New(typ)
+		if err := mapNodeToStruct(node, entity.Interface(), meta); err != nil {
+			return reflect.Value{}, err
+		}
+		entities[key] = entity
+		queue = append(queue, &treeVisit{node: node, entity: entity, meta: meta, level: level})
+		return entity, nil
+	}
+
+	rootEntity, err := hydrate(root, rootType, meta, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	var links []treeLink
+	for len(queue) > 0 {
+		visit := queue[0]
+		queue = queue[1:]
+		if visit.level >= depth {
+			continue
+		}
+		for _, rel := range visit.meta.Relations {
+			targetMeta, err := pm.metadataFor(rel.TargetType)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			candidates, end := outgoing[visit.node.ElementID], func(r Relationship) string { return r.EndElementID }
+			if rel.Direction == Incoming {
+				candidates, end = incoming[visit.node.ElementID], func(r Relationship) string { return r.StartElementID }
+			}
+			field := visit.entity.Elem().FieldByName(rel.Field)
+			for _, candidate := range candidates {
+				node, ok := byID[end(candidate)]
+				if candidate.Type != rel.Type || !ok || !slices.Contains(node.Labels, targetMeta.Label) {
+					continue
+				}
+				child, err := hydrate(node, rel.TargetType, targetMeta, visit.level+1)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				links = append(links, treeLink{field: field, child: child, level: visit.level})
+			}
+		}
+	}
+
+	// Links are applied deepest first, so that relationship fields holding structs by
+	// value receive children whose own relationships are already filled.
+	sort.SliceStable(links, func(i, j int) bool { return links[i].level > links[j].level })
+	for _, link := range links {
+		typ := link.field.Type()
+		switch {
+		case typ.Kind() == reflect.Slice:
+			link.field.Set(reflect.Append(link.field, adaptRelated(link.child, typ.Elem())))
+		case link.field.IsZero():
+			link.field.Set(adaptRelated(link.child, typ))
+		}
+	}
+	return rootEntity, nil
+}
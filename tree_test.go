@@ -0,0 +1,103 @@
+package neopersist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// Blogger wrote posts, which are commented on.
+type Blogger struct {
+	ID    string      `crud:"pk,property:id"`
+	Posts []*BlogPost `crud:"rel:WROTE,direction:out"`
+}
+
+type BlogPost struct {
+	ID       string         `crud:"pk,property:id"`
+	Comments []*BlogComment `crud:"rel:COMMENTED_ON,direction:in"`
+}
+
+type BlogComment struct {
+	ID string `crud:"pk,property:id"`
+}
+
+// Follower follows other followers, in one direction only.
+type Follower struct {
+	ID      string      `crud:"pk,property:id"`
+	Follows []*Follower `crud:"rel:FOLLOWS,direction:out"`
+}
+
+func treeNode(label, id string) neopersist.Node {
+	return neopersist.Node{ElementID: id, Labels: []string{label}, Props: map[string]any{"id": id}}
+}
+
+func treeRel(id, typ, start, end string) neopersist.Relationship {
+	return neopersist.Relationship{ElementID: id, Type: typ, StartElementID: start, EndElementID: end}
+}
+
+func TestFindTreeFollowsDeclaredDirectionsAndCollectsDistinctElements(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		b1, p1, p2, c1 := treeNode("Blogger", "b1"), treeNode("BlogPost", "p1"), treeNode("BlogPost", "p2"), treeNode("BlogComment", "c1")
+		wrote1, wrote2 := treeRel("r1", "WROTE", "b1", "p1"), treeRel("r2", "WROTE", "b1", "p2")
+		commented := treeRel("r3", "COMMENTED_ON", "c1", "p1")
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord(
+			"root", b1,
+			"nodes", []any{b1, b1, c1, p1, p2, p1},
+			"rels", []any{wrote1, wrote2, commented},
+		)}}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	blogger, err := neopersist.FindTree[Blogger](context.Background(), pm, "b1", 2)
+	if err != nil {
+		t.Fatalf("FindTree: %v", err)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		Query: "MATCH (root:Blogger {id: $id}) " +
+			"OPTIONAL MATCH path = (root)-[:COMMENTED_ON|WROTE*1..2]-() " +
+			"WHERE all(i IN range(0, length(path) - 1) WHERE " +
+			"CASE WHEN type(relationships(path)[i]) IN $outgoingOnly THEN startNode(relationships(path)[i]) = nodes(path)[i] " +
+			"WHEN type(relationships(path)[i]) IN $incomingOnly THEN endNode(relationships(path)[i]) = nodes(path)[i] " +
+			"ELSE true END) " +
+			"UNWIND coalesce(relationships(path), [null]) AS rel " +
+			"WITH root, collect(DISTINCT rel) AS rels " +
+			"RETURN root, [r IN rels | startNode(r)] + [r IN rels | endNode(r)] AS nodes, rels",
+		Params:      map[string]any{"id": "b1", "outgoingOnly": []string{"WROTE"}, "incomingOnly": []string{"COMMENTED_ON"}},
+		ExactParams: true,
+	})
+	if len(blogger.Posts) != 2 {
+		t.Fatalf("Posts = %d, want 2", len(blogger.Posts))
+	}
+	for _, post := range blogger.Posts {
+		want := map[string]int{"p1": 1, "p2": 0}[post.ID]
+		if len(post.Comments) != want {
+			t.Errorf("post %s has %d comment(s), want %d", post.ID, len(post.Comments), want)
+		}
+	}
+}
+
+func TestFindTreeUsesTheArrowWhenEveryTypeSharesADirection(t *testing.T) {
+	runner := neopersisttest.NewFakeRunner()
+	runner.Respond = func(int, string, map[string]interface{}) (*neopersist.ResultSet, error) {
+		return &neopersist.ResultSet{Records: []*neopersist.Record{neopersist.NewRecord(
+			"root", treeNode("Follower", "f1"), "nodes", []any{}, "rels", []any{},
+		)}}, nil
+	}
+	pm := neopersist.NewPersistenceManager(runner)
+
+	follower, err := neopersist.FindTree[Follower](context.Background(), pm, "f1", 3)
+	if err != nil {
+		t.Fatalf("FindTree: %v", err)
+	}
+	if len(follower.Follows) != 0 {
+		t.Errorf("Follows = %v, want none", follower.Follows)
+	}
+	neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{
+		QueryContains: []string{"OPTIONAL MATCH path = (root)-[:FOLLOWS*1..3]->() UNWIND"},
+		Params:        map[string]any{"id": "f1"},
+		ExactParams:   true,
+	})
+}
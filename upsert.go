@@ -0,0 +1,119 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConflictStrategy decides what UpsertAll does with entities whose primary key already
+// exists in the database, and with those whose key does not.
+type ConflictStrategy int
+
+const (
+	// Overwrite creates missing nodes and writes the entity's properties onto existing
+	// ones, like SaveAll.
+	Overwrite ConflictStrategy = iota
+	// CreateOnly creates missing nodes and leaves existing ones untouched.
+	CreateOnly
+	// UpdateOnly writes the entity's properties onto existing nodes and never creates any.
+	UpdateOnly
+)
+
+// String returns the name of the strategy.
+func (s ConflictStrategy) String() string {
+	switch s {
+	case Overwrite:
+		return "Overwrite"
+	case CreateOnly:
+		return "CreateOnly"
+	case UpdateOnly:
+		return "UpdateOnly"
+	}
+	return fmt.Sprintf("ConflictStrategy(%d)", int(s))
+}
+
+// upsertQuery renders the UNWIND statement applying the strategy to a chunk of rows. Each
+// statement returns the number of rows that matched or created a node, from which the
+// updated count is derived.
+func (r *Repository[T]) upsertQuery(strategy ConflictStrategy) (string, error) {
+	var write string
+	switch strategy {
+	case Overwrite:
+		write = "MERGE (n:%s {%s: row.pk})\nSET n += row.props"
+		// Defaults of fields tagged `default:` are only written to created nodes.
+		if len(r.meta.Defaults) > 0 {
+			write = "MERGE (n:%s {%s: row.pk})\nON CREATE SET n += row.defaults\nSET n += row.props"
+		}
+	case CreateOnly:
+		write = "MERGE (n:%s {%s: row.pk})\nON CREATE SET n += row.props"
+		if len(r.meta.Defaults) > 0 {
+			write += ", n += row.defaults"
+		}
+	case UpdateOnly:
+		write = "MATCH (n:%s {%s: row.pk})\nSET n += row.props"
+	default:
+		return "", fmt.Errorf("unknown conflict strategy %s", strategy)
+	}
+	return "UNWIND $rows AS row\n" + fmt.Sprintf(write, r.meta.Label, r.meta.PKProp) + "\nRETURN count(n) AS total", nil
+}
+
+// UpsertAll writes a slice of entities like SaveAll, with control over conflicts: the
+// strategy decides whether existing nodes are overwritten (Overwrite), left untouched
+// (CreateOnly), or are the only ones written (UpdateOnly). Each chunk is a single UNWIND
+// statement, split and committed like SaveAll's.
+//
+// The created and updated counts are read from the results, so that import runs can be
+// audited: created comes from the summary counters, and updated is the number of
+// existing nodes that were written, which is always zero under CreateOnly. Entities
+// skipped by the strategy count as neither.
+//
+// Example:
+//
+//	created, updated, err := productRepo.UpsertAll(ctx, products, neopersist.CreateOnly)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - entities: A slice of pointers to the struct instances to be written.
+//   - strategy: How to treat existing and missing nodes.
+//   - opts: Optional per-call settings, such as RewriteQuery.
+//
+// Returns:
+//
+//	The number of nodes created and updated, or an error if an entity has a zero-value
+//	primary key or shares its key with another entity of the slice (checked before
+//	anything is sent), the strategy is unknown, or a chunk fails, together with the
+//	counts of the chunks before it.
+func (r *Repository[T]) UpsertAll(ctx context.Context, entities []*T, strategy ConflictStrategy, opts ...WriteOption) (created, updated int64, err error) {
+	options, err := r.parseWriteOptions("UpsertAll", opts, writeOptions)
+	if err != nil {
+		return 0, 0, err
+	}
+	query, err := r.upsertQuery(strategy)
+	if err != nil {
+		return 0, 0, err
+	}
+	rows, err := r.saveRows(ctx, entities)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	batchSize := r.config.batchSizeOrDefault()
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		params := map[string]interface{}{"rows": rows[start:end]}
+		result, err := r.run(ctx, query, params, options)
+		if err != nil {
+			return created, updated, fmt.Errorf("could not upsert chunk %d (entities %d to %d): %w", start/batchSize, start, end-1, err)
+		}
+		var total int64
+		if len(result.Records) > 0 {
+			value, _ := result.Records[0].Get("total")
+			total, _ = value.(int64)
+		}
+		created += result.Counters.NodesCreated
+		if strategy != CreateOnly {
+			updated += total - result.Counters.NodesCreated
+		}
+	}
+	return created, updated, nil
+}
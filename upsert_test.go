@@ -0,0 +1,117 @@
+package neopersist_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist"
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/neopersisttest"
+)
+
+// upsertResult answers an UpsertAll chunk: total rows matched or created a node, created
+// of which were created.
+func upsertResult(total, created int64) *neopersist.ResultSet {
+	return &neopersist.ResultSet{
+		Records:  []*neopersist.Record{neopersist.NewRecord("total", total)},
+		Counters: neopersist.Counters{NodesCreated: created},
+	}
+}
+
+func threeUsers() []*User {
+	return []*User{{UserID: "u1", Name: "Ada"}, {UserID: "u2", Name: "Bob"}, {UserID: "u3", Name: "Cy"}}
+}
+
+func TestUpsertAllStrategies(t *testing.T) {
+	tests := []struct {
+		strategy neopersist.ConflictStrategy
+		query    string
+		// chunks answers the two chunks of three users in batches of two.
+		chunks                   [2]*neopersist.ResultSet
+		wantCreated, wantUpdated int64
+	}{
+		{
+			strategy: neopersist.Overwrite,
+			query:    "UNWIND $rows AS row MERGE (n:User {userId: row.pk}) SET n += row.props RETURN count(n) AS total",
+			// Every row writes a node; one of the first chunk's is new.
+			chunks:      [2]*neopersist.ResultSet{upsertResult(2, 1), upsertResult(1, 0)},
+			wantCreated: 1, wantUpdated: 2,
+		},
+		{
+			strategy: neopersist.CreateOnly,
+			query:    "UNWIND $rows AS row MERGE (n:User {userId: row.pk}) ON CREATE SET n += row.props RETURN count(n) AS total",
+			// Existing nodes are matched but left untouched, so they are not updates.
+			chunks:      [2]*neopersist.ResultSet{upsertResult(2, 1), upsertResult(1, 1)},
+			wantCreated: 2, wantUpdated: 0,
+		},
+		{
+			strategy: neopersist.UpdateOnly,
+			query:    "UNWIND $rows AS row MATCH (n:User {userId: row.pk}) SET n += row.props RETURN count(n) AS total",
+			// Rows without a node are skipped and counted as neither.
+			chunks:      [2]*neopersist.ResultSet{upsertResult(1, 0), upsertResult(0, 0)},
+			wantCreated: 0, wantUpdated: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			repo, runner := newUserRepo(t, neopersist.WithBatchSize(2))
+			runner.Respond = func(index int, _ string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+				return tt.chunks[index], nil
+			}
+
+			created, updated, err := repo.UpsertAll(context.Background(), threeUsers(), tt.strategy)
+			if err != nil {
+				t.Fatalf("UpsertAll: %v", err)
+			}
+			if created != tt.wantCreated || updated != tt.wantUpdated {
+				t.Errorf("UpsertAll = %d created, %d updated, want %d and %d", created, updated, tt.wantCreated, tt.wantUpdated)
+			}
+			neopersisttest.AssertQuery(t, runner, neopersisttest.Expect{Query: tt.query, Times: 2})
+			if rows := runner.Calls()[0].Params["rows"].([]map[string]interface{}); len(rows) != 2 || rows[0]["pk"] != "u1" {
+				t.Errorf("first chunk rows = %v, want u1 and u2", rows)
+			}
+		})
+	}
+}
+
+func TestUpsertAllRejectsDuplicateKeys(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	users := append(threeUsers(), &User{UserID: "u2", Name: "Bobby"})
+
+	_, _, err := repo.UpsertAll(context.Background(), users, neopersist.Overwrite)
+	if err == nil || !strings.Contains(err.Error(), "index 1 and 3") {
+		t.Fatalf("UpsertAll error = %v, want the indexes of the duplicate keys", err)
+	}
+	if err := repo.SaveAll(context.Background(), users); err == nil {
+		t.Fatal("SaveAll accepted duplicate keys")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
+
+func TestUpsertAllReportsTheCountsBeforeAFailedChunk(t *testing.T) {
+	repo, runner := newUserRepo(t, neopersist.WithBatchSize(2))
+	failure := errors.New("deadlock")
+	runner.Respond = func(index int, _ string, _ map[string]interface{}) (*neopersist.ResultSet, error) {
+		if index == 1 {
+			return nil, failure
+		}
+		return upsertResult(2, 1), nil
+	}
+
+	created, updated, err := repo.UpsertAll(context.Background(), threeUsers(), neopersist.Overwrite)
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "chunk 1 (entities 2 to 2)") {
+		t.Fatalf("UpsertAll error = %v, want the failing chunk", err)
+	}
+	if created != 1 || updated != 1 {
+		t.Errorf("UpsertAll = %d created, %d updated, want the first chunk's 1 and 1", created, updated)
+	}
+}
+
+func TestUpsertAllRejectsUnknownStrategies(t *testing.T) {
+	repo, runner := newUserRepo(t)
+	if _, _, err := repo.UpsertAll(context.Background(), threeUsers(), neopersist.ConflictStrategy(7)); err == nil {
+		t.Fatal("UpsertAll accepted an unknown strategy")
+	}
+	neopersisttest.AssertCallCount(t, runner, 0)
+}
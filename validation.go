@@ -0,0 +1,141 @@
+package neopersist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrValidation is matched, via errors.Is, by the *ValidationError returned when an
+// entity fails validation before being written.
+var ErrValidation = errors.New("validation failed")
+
+// Validator is implemented by entities that can check their own invariants. Save,
+// SaveReturning, Create, Update, FindOrCreate and SaveAll call Validate on the entity
+// pointer, after any BeforeSave hook, and refuse to write an invalid entity.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError reports an entity rejected by its Validate method or by a validator
+// registered with WithValidator or RegisterValidator. Nothing is sent to the database.
+type ValidationError struct {
+	// Label is the label of the entity type.
+	Label string
+	// Index is the position of the invalid entity in a bulk operation, or -1 for
+	// single-entity operations.
+	Index int
+	// Err is the error returned by the validator.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("%s: %s: %v", ErrValidation, e.Label, e.Err)
+	}
+	return fmt.Sprintf("%s: %s at index %d: %v", ErrValidation, e.Label, e.Index, e.Err)
+}
+
+// Unwrap exposes both ErrValidation and the validator's own error to errors.Is and errors.As.
+func (e *ValidationError) Unwrap() []error {
+	return []error{ErrValidation, e.Err}
+}
+
+// RequiredFieldsError lists the fields tagged `required` that an entity left at their
+// zero value. It is returned wrapped in a *ValidationError, so it matches ErrValidation.
+type RequiredFieldsError struct {
+	// Fields are the names of the missing fields, in declaration order. Nested fields are
+	// written with dots (e.g., "Address.City").
+	Fields []string
+}
+
+// Error implements the error interface.
+func (e *RequiredFieldsError) Error() string {
+	return fmt.Sprintf("missing required field(s): %s", strings.Join(e.Fields, ", "))
+}
+
+// checkRequired reports every required field of val that holds its zero value or, for a
+// nested field, sits behind a nil pointer.
+func (m *entityMetadata) checkRequired(val reflect.Value) error {
+	var missing []string
+	for _, fieldName := range m.Required {
+		if field := m.field(val, fieldName); !field.IsValid() || isZeroField(field) {
+			missing = append(missing, fieldName)
+		}
+	}
+	if len(missing) > 0 {
+		return &RequiredFieldsError{Fields: missing}
+	}
+	return nil
+}
+
+// validatorType is the reflect.Type of the Validator interface.
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+// WithValidator returns a RepositoryOption validating every entity before it is written,
+// in addition to the entity's own Validate method, if any. It suits rules that do not
+// belong on the model, or models that cannot be changed.
+//
+// Example:
+//
+//	repo, err := neopersist.NewRepository[models.User](runner, neopersist.WithValidator(func(u *models.User) error {
+//	    if !strings.Contains(u.Email, "@") {
+//	        return fmt.Errorf("invalid email %q", u.Email)
+//	    }
+//	    return nil
+//	}))
+func WithValidator[T any](fn func(*T) error) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.validator = func(entity any) error { return fn(entity.(*T)) }
+		c.validatorType = reflect.TypeOf((*T)(nil))
+	}
+}
+
+// RegisterValidator registers a validator for entities of type T on the manager.
+// Repositories created afterwards with RepositoryFor apply it like WithValidator.
+func RegisterValidator[T any](pm *PersistenceManager, fn func(*T) error) {
+	pm.validators.Store(reflect.TypeOf((*T)(nil)), WithValidator(fn))
+}
+
+// validatorOption returns the RepositoryOption registered for *T with RegisterValidator,
+// or nil.
+func (pm *PersistenceManager) validatorOption(ptrType reflect.Type) RepositoryOption {
+	if opt, ok := pm.validators.Load(ptrType); ok {
+		return opt.(RepositoryOption)
+	}
+	return nil
+}
+
+// prepareSave runs the BeforeSave hook, then checks the required fields and runs the
+// validators of an entity about to be written, and checks the elements of its
+// slice-of-interface fields (see checkListValues) and the SRID of its points (see
+// checkPointValues). index is the position of the entity in
+// a bulk operation, or -1.
+func (r *Repository[T]) prepareSave(ctx context.Context, entity *T, index int) error {
+	if err := r.beforeSave(ctx, entity, index); err != nil {
+		return err
+	}
+	if entity == nil {
+		return nil
+	}
+	if err := r.meta.checkRequired(reflect.ValueOf(entity).Elem()); err != nil {
+		return &ValidationError{Label: r.meta.Label, Index: index, Err: err}
+	}
+	if r.hooks.validate {
+		if err := any(entity).(Validator).Validate(); err != nil {
+			return &ValidationError{Label: r.meta.Label, Index: index, Err: err}
+		}
+	}
+	if r.config.validator != nil {
+		if err := r.config.validator(entity); err != nil {
+			return &ValidationError{Label: r.meta.Label, Index: index, Err: err}
+		}
+	}
+	if err := r.meta.checkListValues(reflect.ValueOf(entity).Elem()); err != nil {
+		return err
+	}
+	return r.meta.checkPointValues(reflect.ValueOf(entity).Elem())
+}
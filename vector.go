@@ -0,0 +1,143 @@
+package neopersist
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/saulfrancisco-ruizacevedo/go-neopersist/features"
+)
+
+// Vector similarity functions supported by Neo4j vector indexes.
+const (
+	similarityCosine    = "cosine"
+	similarityEuclidean = "euclidean"
+)
+
+// vectorMetadata is an embedding field declared with the `vector:` tag component, such as
+// `crud:"property:embedding,vector:dim=1536,similarity=cosine"`.
+type vectorMetadata struct {
+	// Field is the name of the []float32 or []float64 field holding the embedding.
+	Field string
+	// Dimensions is the length every embedding must have.
+	Dimensions int
+	// Similarity is the similarity function of the index, "cosine" (the default) or
+	// "euclidean".
+	Similarity string
+}
+
+// setVector records the field fieldName, of type typ, as the entity's embedding, with the
+// dim= and similarity= settings of its tag.
+func (m *entityMetadata) setVector(fieldName string, typ reflect.Type, settings []string) error {
+	if typ.Kind() != reflect.Slice || (typ.Elem().Kind() != reflect.Float32 && typ.Elem().Kind() != reflect.Float64) {
+		return fmt.Errorf("field %s tagged 'vector' must be a []float32 or a []float64", fieldName)
+	}
+	if m.Vector != nil {
+		return fmt.Errorf("fields %s and %s are both tagged 'vector'", m.Vector.Field, fieldName)
+	}
+	vector := &vectorMetadata{Field: fieldName, Similarity: similarityCosine}
+	for _, setting := range settings {
+		key, value, _ := strings.Cut(setting, "=")
+		switch key {
+		case "dim":
+			dim, err := strconv.Atoi(value)
+			if err != nil || dim <= 0 {
+				return fmt.Errorf("field %s has an invalid vector dimension '%s'", fieldName, value)
+			}
+			vector.Dimensions = dim
+		case "similarity":
+			if value != similarityCosine && value != similarityEuclidean {
+				return fmt.Errorf("field %s has an invalid vector similarity '%s' (must be 'cosine' or 'euclidean')", fieldName, value)
+			}
+			vector.Similarity = value
+		default:
+			return fmt.Errorf("field %s has an invalid vector setting '%s'", fieldName, setting)
+		}
+	}
+	if vector.Dimensions == 0 {
+		return fmt.Errorf("field %s tagged 'vector' is missing its 'dim=' setting", fieldName)
+	}
+	m.Vector = vector
+	return nil
+}
+
+// vectorIndexName returns the database name of the entity's vector index.
+func (m *entityMetadata) vectorIndexName() string {
+	return m.Label + "_" + m.Mappings[m.Vector.Field] + "_vector"
+}
+
+// vectorIndexQuery renders the statement creating the entity's vector index.
+func (m *entityMetadata) vectorIndexQuery() string {
+	return fmt.Sprintf("CREATE VECTOR INDEX %s IF NOT EXISTS\nFOR (n:%s)\nON (n.%s)\nOPTIONS {indexConfig: {`vector.dimensions`: %d, `vector.similarity_function`: '%s'}}",
+		quoteIdentifier(m.vectorIndexName()), m.Label, m.Mappings[m.Vector.Field], m.Vector.Dimensions, m.Vector.Similarity)
+}
+
+// SimilaritySearch returns the k entities whose embedding, the field tagged with the
+// `vector:` tag component, is the most similar to the given one, with their similarity
+// score, best match first. It queries the vector index EnsureIndexes creates as
+// "<Label>_<property>_vector", which requires Neo4j 5.15 or later.
+//
+// Embeddings are stored as lists of floats, which Neo4j keeps in 64 bits: []float32
+// fields are widened when written and narrowed back when loaded.
+//
+// Example:
+//
+//	type Document struct {
+//	    ID        string    `crud:"pk,property:id"`
+//	    Embedding []float32 `crud:"property:embedding,vector:dim=1536,similarity=cosine"`
+//	}
+//	results, err := documentRepo.SimilaritySearch(ctx, queryEmbedding, 5)
+//
+// Parameters:
+//   - ctx: The context for the query execution.
+//   - embedding: The query vector; its length must match the field's dimension.
+//   - k: The number of nearest neighbours to return; must be positive.
+//   - opts: Optional per-call settings, such as OnDatabase or WithTimeout.
+//
+// Returns:
+//
+//	The most similar entities and their scores, or an error if the entity type has no
+//	vector field, the embedding has the wrong dimension, k is not positive or the query
+//	fails.
+func (r *Repository[T]) SimilaritySearch(ctx context.Context, embedding []float32, k int, opts ...FindOption) ([]ScoredResult[T], error) {
+	options, err := parseOptions("SimilaritySearch", opts, procedureOptions)
+	if err != nil {
+		return nil, err
+	}
+	if r.meta.Vector == nil {
+		return nil, fmt.Errorf("entity type %s has no field tagged 'vector'", r.meta.Label)
+	}
+	if len(embedding) != r.meta.Vector.Dimensions {
+		return nil, fmt.Errorf("embedding has %d dimensions, but field %s of entity type %s has %d",
+			len(embedding), r.meta.Vector.Field, r.meta.Label, r.meta.Vector.Dimensions)
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	if err := requireFeature(ctx, r.runner, features.VectorIndex); err != nil {
+		return nil, err
+	}
+
+	query := "CALL db.index.vector.queryNodes($index, $k, $embedding) YIELD node, score\nRETURN node, score"
+	params := map[string]interface{}{
+		"index":     r.meta.vectorIndexName(),
+		"k":         int64(k),
+		"embedding": float64List(embedding),
+	}
+	eagerResult, err := r.run(ctx, query, params, options)
+	if err != nil {
+		return nil, err
+	}
+	return r.scoredResults(ctx, eagerResult.Records)
+}
+
+// float64List widens a float32 vector into the 64-bit floats Neo4j stores.
+func float64List(v []float32) []float64 {
+	list := make([]float64, len(v))
+	for i, f := range v {
+		list[i] = float64(f)
+	}
+	return list
+}